@@ -0,0 +1,11 @@
+// Package api embeds the OpenAPI document of the REST API.
+package api
+
+import _ "embed"
+
+//go:generate sh -c "protoc -I ../pkg -I $(go list -m -f {{.Dir}} github.com/google/gnostic-models) --openapi_out=. '--openapi_opt=title=Host Manager API,version=v1,default_response=false,naming=json,enum_type=string' ../pkg/proto/dns.proto ../pkg/proto/admin.proto ../pkg/proto/v2/dns.proto"
+
+// OpenAPI is the OpenAPI 3 document generated from the protos in pkg/proto.
+//
+//go:embed openapi.yaml
+var OpenAPI []byte
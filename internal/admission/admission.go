@@ -0,0 +1,205 @@
+package admission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"os"
+	"slices"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrDenied           = errors.New("change denied by policy")
+	ErrApprovalRequired = errors.New("change requires approval")
+)
+
+// Actions a change request can perform, matching the change kinds of the CLI.
+const (
+	ActionSetHostname     = "set-hostname"
+	ActionAddDNSServer    = "add-dns-server"
+	ActionRemoveDNSServer = "remove-dns-server"
+)
+
+// Policy is a set of content rules every change request must pass.
+type Policy struct {
+	Rules []Rule `yaml:"rules"`
+
+	programs []cel.Program
+}
+
+// Rule admits a change when its CEL expression evaluates to true. The
+// expression sees the change as request.action, request.hostname and
+// request.dns_server, the caller as request.requester and request.groups, and
+// the current host as state.hostname and state.dns_servers. in_cidr(ip, cidr)
+// checks IP ranges. A rule with actions
+// only applies to those actions. A rule with require_approval does not deny a
+// change it does not admit but holds it until a second identity approves it.
+type Rule struct {
+	Name            string   `yaml:"name"`
+	Actions         []string `yaml:"actions"`
+	Expression      string   `yaml:"expression"`
+	Message         string   `yaml:"message"`
+	RequireApproval bool     `yaml:"require_approval"`
+}
+
+// Request is the change being admitted.
+type Request struct {
+	Action    string
+	Hostname  string
+	DNSServer string
+	// Requester is the principal of the caller, empty if unauthenticated.
+	Requester string
+	Groups    []string
+}
+
+// State is the host as it is before the change.
+type State struct {
+	Hostname   string
+	DNSServers []string
+}
+
+func Load(file string) (*Policy, error) {
+	const op = "admission.Load"
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to read %s: %w", op, file, err)
+	}
+
+	var p Policy
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("%s: failed to parse %s: %w", op, file, err)
+	}
+
+	if err := p.compile(); err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, file, err)
+	}
+
+	return &p, nil
+}
+
+func (p *Policy) compile() error {
+	env, err := cel.NewEnv(
+		cel.Variable("request", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("state", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Function("in_cidr",
+			cel.Overload("in_cidr_string_string", []*cel.Type{cel.StringType, cel.StringType}, cel.BoolType,
+				cel.BinaryBinding(inCIDR),
+			),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	p.programs = make([]cel.Program, len(p.Rules))
+	for i, rule := range p.Rules {
+		if rule.Name == "" {
+			return fmt.Errorf("rule %d has no name", i)
+		}
+
+		for _, action := range rule.Actions {
+			if !slices.Contains([]string{ActionSetHostname, ActionAddDNSServer, ActionRemoveDNSServer}, action) {
+				return fmt.Errorf("rule %s: unknown action %q", rule.Name, action)
+			}
+		}
+
+		ast, issues := env.Compile(rule.Expression)
+		if issues.Err() != nil {
+			return fmt.Errorf("rule %s: %w", rule.Name, issues.Err())
+		}
+		if !ast.OutputType().IsExactType(cel.BoolType) {
+			return fmt.Errorf("rule %s: expression must be a bool, got %s", rule.Name, ast.OutputType())
+		}
+
+		p.programs[i], err = env.Program(ast)
+		if err != nil {
+			return fmt.Errorf("rule %s: %w", rule.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// Check evaluates every rule that applies to the request and returns
+// ErrDenied naming the first rule that does not admit it. A rule that fails
+// to evaluate denies the change. If only approval rules do not admit it,
+// ErrApprovalRequired names the first of them.
+func (p *Policy) Check(req Request, state State) error {
+	vars := map[string]any{
+		"request": map[string]any{
+			"action":     req.Action,
+			"hostname":   req.Hostname,
+			"dns_server": req.DNSServer,
+			"requester":  req.Requester,
+			"groups":     req.Groups,
+		},
+		"state": map[string]any{
+			"hostname":    state.Hostname,
+			"dns_servers": state.DNSServers,
+		},
+	}
+
+	var approval error
+	for i, rule := range p.Rules {
+		if len(rule.Actions) > 0 && !slices.Contains(rule.Actions, req.Action) {
+			continue
+		}
+
+		out, _, err := p.programs[i].Eval(vars)
+		if err != nil {
+			return fmt.Errorf("%w: rule %s failed: %v", ErrDenied, rule.Name, err)
+		}
+		if out == types.True {
+			continue
+		}
+
+		message := rule.Message
+		if message == "" {
+			message = rule.Expression
+		}
+
+		if !rule.RequireApproval {
+			return fmt.Errorf("%w: rule %s: %s", ErrDenied, rule.Name, message)
+		}
+		if approval == nil {
+			approval = fmt.Errorf("%w: rule %s: %s", ErrApprovalRequired, rule.Name, message)
+		}
+	}
+
+	return approval
+}
+
+type approvedKey struct{}
+
+// WithApproval marks ctx as running an approved change, which passes the
+// rules that require approval.
+func WithApproval(ctx context.Context) context.Context {
+	return context.WithValue(ctx, approvedKey{}, true)
+}
+
+// Approved reports whether ctx runs an approved change.
+func Approved(ctx context.Context) bool {
+	approved, _ := ctx.Value(approvedKey{}).(bool)
+	return approved
+}
+
+func inCIDR(ip, cidr ref.Val) ref.Val {
+	prefix, err := netip.ParsePrefix(string(cidr.(types.String)))
+	if err != nil {
+		return types.NewErr("invalid CIDR %q", cidr)
+	}
+
+	addr, err := netip.ParseAddr(string(ip.(types.String)))
+	if err != nil {
+		return types.False
+	}
+
+	return types.Bool(prefix.Contains(addr))
+}
@@ -0,0 +1,114 @@
+package admission
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
+
+	"hostManager/internal/auth"
+	"hostManager/internal/service"
+)
+
+// HostManager checks every change against a policy before passing it on.
+// Reverts are passed through unchecked so rollbacks always work. Without a
+// policy every change is passed on.
+type HostManager struct {
+	service.HostManager
+	policy atomic.Pointer[Policy]
+}
+
+func NewHostManager(next service.HostManager, policy *Policy) *HostManager {
+	m := &HostManager{HostManager: next}
+	m.policy.Store(policy)
+
+	return m
+}
+
+// SetPolicy replaces the policy for changes that have not been admitted yet.
+func (m *HostManager) SetPolicy(policy *Policy) {
+	m.policy.Store(policy)
+}
+
+// Enabled reports whether changes are checked against a policy.
+func (m *HostManager) Enabled() bool {
+	return m.policy.Load() != nil
+}
+
+func (m *HostManager) SetHostname(ctx context.Context, hostname, expectedVersion string) (service.Change, error) {
+	return m.HostManager.SetHostname(m.admit(ctx, Request{Action: ActionSetHostname, Hostname: hostname}), hostname, expectedVersion)
+}
+
+func (m *HostManager) AddDNSServer(ctx context.Context, server, expectedVersion string) (service.Change, error) {
+	return m.HostManager.AddDNSServer(m.admit(ctx, Request{Action: ActionAddDNSServer, DNSServer: server}), server, expectedVersion)
+}
+
+func (m *HostManager) RemoveDNSServer(ctx context.Context, server, expectedVersion string) (service.Change, error) {
+	return m.HostManager.RemoveDNSServer(m.admit(ctx, Request{Action: ActionRemoveDNSServer, DNSServer: server}), server, expectedVersion)
+}
+
+// UpdateResolverConfig admits every nameserver the update removes and then
+// every one it adds as if it was removed or added on its own, each against the
+// state the steps before it leave behind.
+func (m *HostManager) UpdateResolverConfig(ctx context.Context, cfg service.ResolverConfig, expectedVersion string) (service.Change, error) {
+	policy := m.policy.Load()
+	if policy == nil {
+		return m.HostManager.UpdateResolverConfig(ctx, cfg, expectedVersion)
+	}
+
+	checked := service.WithCheck(ctx, func(host service.HostState) error {
+		state := State(host)
+		for _, server := range slices.Clone(state.DNSServers) {
+			if slices.Contains(cfg.Nameservers, server) {
+				continue
+			}
+			if err := check(ctx, policy, Request{Action: ActionRemoveDNSServer, DNSServer: server}, state); err != nil {
+				return err
+			}
+			state.DNSServers = slices.DeleteFunc(slices.Clone(state.DNSServers), func(s string) bool { return s == server })
+		}
+
+		for _, server := range cfg.Nameservers {
+			if slices.Contains(state.DNSServers, server) {
+				continue
+			}
+			if err := check(ctx, policy, Request{Action: ActionAddDNSServer, DNSServer: server}, state); err != nil {
+				return err
+			}
+			state.DNSServers = append(slices.Clone(state.DNSServers), server)
+		}
+
+		return nil
+	})
+
+	return m.HostManager.UpdateResolverConfig(checked, cfg, expectedVersion)
+}
+
+// admit has the change made with the returned context checked against the
+// policy while the manager holds its lock, so that concurrent changes are
+// checked against the state they leave behind.
+func (m *HostManager) admit(ctx context.Context, req Request) context.Context {
+	policy := m.policy.Load()
+	if policy == nil {
+		return ctx
+	}
+
+	return service.WithCheck(ctx, func(host service.HostState) error {
+		return check(ctx, policy, req, State(host))
+	})
+}
+
+// check runs policy on req as the caller in ctx, letting approved changes
+// pass the approval rules.
+func check(ctx context.Context, policy *Policy, req Request, state State) error {
+	if id, ok := auth.FromContext(ctx); ok {
+		req.Requester, req.Groups = id.Principal(), id.Groups
+	}
+
+	err := policy.Check(req, state)
+	if errors.Is(err, ErrApprovalRequired) && Approved(ctx) {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,113 @@
+package admission
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"hostManager/internal/service"
+)
+
+// fakeHostManager holds the host in memory and records resolver updates.
+type fakeHostManager struct {
+	service.HostManager
+	hostname string
+	servers  []string
+	updated  bool
+}
+
+func (f *fakeHostManager) UpdateResolverConfig(ctx context.Context, cfg service.ResolverConfig, _ string) (service.Change, error) {
+	if err := service.Check(ctx, service.HostState{Hostname: f.hostname, DNSServers: f.servers}); err != nil {
+		return service.Change{}, err
+	}
+
+	f.servers = cfg.Nameservers
+	f.updated = true
+	return service.Change{}, nil
+}
+
+func TestUpdateResolverConfig(t *testing.T) {
+	rules := []Rule{
+		{
+			Name:       "max-nameservers",
+			Actions:    []string{ActionAddDNSServer},
+			Expression: "size(state.dns_servers) < 3",
+		},
+		{
+			Name:       "keep-one",
+			Actions:    []string{ActionRemoveDNSServer},
+			Expression: "size(state.dns_servers) > 1",
+		},
+		{
+			Name:            "public-approval",
+			Actions:         []string{ActionAddDNSServer},
+			Expression:      `!in_cidr(request.dns_server, "203.0.113.0/24")`,
+			RequireApproval: true,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		current  []string
+		update   []string
+		approved bool
+		wantErr  error
+	}{
+		{name: "adds up to the limit", current: []string{"192.0.2.1"}, update: []string{"192.0.2.1", "192.0.2.2", "192.0.2.3"}},
+		{
+			name:    "adds past the limit",
+			current: []string{"192.0.2.1"},
+			update:  []string{"192.0.2.1", "192.0.2.2", "192.0.2.3", "192.0.2.4"},
+			wantErr: ErrDenied,
+		},
+		{
+			name:    "removals make room first",
+			current: []string{"192.0.2.1", "192.0.2.2", "192.0.2.3"},
+			update:  []string{"192.0.2.1", "192.0.2.4"},
+		},
+		{
+			name:    "replace all",
+			current: []string{"192.0.2.1", "192.0.2.2"},
+			update:  []string{"192.0.2.3"},
+			wantErr: ErrDenied,
+		},
+		{name: "unchanged", current: []string{"192.0.2.1"}, update: []string{"192.0.2.1"}},
+		{
+			name:    "one step needs approval",
+			current: []string{"192.0.2.1"},
+			update:  []string{"192.0.2.1", "203.0.113.1"},
+			wantErr: ErrApprovalRequired,
+		},
+		{
+			name:     "approved",
+			current:  []string{"192.0.2.1"},
+			update:   []string{"192.0.2.1", "203.0.113.1"},
+			approved: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &Policy{Rules: rules}
+			if err := policy.compile(); err != nil {
+				t.Fatal(err)
+			}
+
+			next := &fakeHostManager{hostname: "host", servers: tt.current}
+			m := NewHostManager(next, policy)
+
+			ctx := context.Background()
+			if tt.approved {
+				ctx = WithApproval(ctx)
+			}
+
+			_, err := m.UpdateResolverConfig(ctx, service.ResolverConfig{Nameservers: tt.update}, "")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateResolverConfig() error = %v, want %v", err, tt.wantErr)
+			}
+			if next.updated != (tt.wantErr == nil) {
+				t.Fatalf("update applied = %t, want %t", next.updated, tt.wantErr == nil)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
@@ -8,10 +9,12 @@ import (
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
-	"gopkg.in/natefinch/lumberjack.v2"
 
 	"hostManager/internal/config"
+	"hostManager/internal/logging"
+	"hostManager/internal/tracing"
 	"hostManager/internal/transport/grpc"
+	"hostManager/internal/transport/mux"
 	"hostManager/internal/transport/rest"
 )
 
@@ -21,25 +24,77 @@ func StartApp() {
 		log.Fatal().Err(err).Msg("Failed to load config file")
 	}
 
-	log.Logger, err = setupLog(&cfg.LogConfig)
+	logger, logs, err := setupLog(&cfg.LogConfig)
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to setup logger")
 	}
+	log.Logger = logger
+	// Code logging through a context without a request logger uses the global one.
+	zerolog.DefaultContextLogger = &log.Logger
 
-	grpcServer := grpc.NewServer(cfg.GRPCConfig.Port, log.Logger, cfg.BackupConfig)
-	if err := grpcServer.Start(); err != nil {
-		log.Fatal().Err(err).Msg("Failed to start gRPC server")
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.TracingConfig)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to setup tracing")
+	}
+
+	grpcServer, err := grpc.NewServer(cfg, logs, log.Logger)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to create gRPC server")
+	}
+
+	// On the single mux port the REST gateway always reaches the gRPC server
+	// in-process, as there is no separate gRPC port to dial.
+	httpCfg := cfg.HTTPConfig
+	if cfg.MuxConfig.Port != "" {
+		httpCfg.GRPCServerEndpoint = ""
 	}
-	httpServer := rest.NewServer(cfg.HTTPConfig, log.Logger)
-	if err := httpServer.Start(); err != nil {
-		log.Fatal().Err(err).Msg("Failed to start HTTP server")
+	httpServer := rest.NewServer(httpCfg, grpcServer.DialInProcess, log.Logger)
+
+	var muxServer *mux.Server
+	if cfg.MuxConfig.Port == "" {
+		if err := grpcServer.Start(); err != nil {
+			log.Fatal().Err(err).Msg("Failed to start gRPC server")
+		}
+		if err := httpServer.Start(); err != nil {
+			log.Fatal().Err(err).Msg("Failed to start HTTP server")
+		}
+	} else {
+		muxServer = startMux(cfg.MuxConfig, grpcServer, httpServer, log.Logger)
 	}
 	log.Info().Msg("App started")
 
+	reloader := newReloader(cfg, grpcServer, muxServer, logs)
+	if cfg.ReloadConfig.WatchFile {
+		if err := reloader.watch(); err != nil {
+			log.Fatal().Err(err).Msg("Failed to watch config file")
+		}
+	}
+
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+
+	for running := true; running; {
+		select {
+		case <-hup:
+			reloader.reload()
+		case <-stop:
+			running = false
+		}
+	}
+
+	if err := reloader.Close(); err != nil {
+		log.Error().Err(err).Msg("Failed to close config file watcher")
+	}
 
-	<-stop
+	httpServer.CloseStreams()
+
+	if muxServer != nil {
+		if err := muxServer.Stop(); err != nil {
+			log.Fatal().Err(err).Msg("Failed to stop server")
+		}
+	}
 
 	if err := httpServer.Stop(); err != nil {
 		log.Fatal().Err(err).Msg("Failed to stop HTTP server")
@@ -50,26 +105,56 @@ func StartApp() {
 	grpcServer.Stop()
 	log.Info().Msg("grpc server stopped")
 
+	if err := shutdownTracing(context.Background()); err != nil {
+		log.Error().Err(err).Msg("Failed to flush traces")
+	}
+
 	log.Info().Msg("App stopped")
 
+	if err := logs.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to close log sinks: %v\n", err)
+	}
 }
 
-func setupLog(config *config.LogConfig) (zerolog.Logger, error) {
-	lumberjackCfg := config.Lumberjack
+// startMux serves gRPC, gRPC-Web, Connect and REST together on the single mux port.
+func startMux(cfg config.MuxConfig, grpcServer *grpc.Server, httpServer *rest.Server, log zerolog.Logger) *mux.Server {
+	if err := grpcServer.StartLocal(); err != nil {
+		log.Fatal().Err(err).Msg("Failed to start gRPC server")
+	}
+
+	restHandler, err := httpServer.Handler()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to create HTTP handler")
+	}
 
-	lr := &lumberjack.Logger{
-		Filename:   config.Path,
-		MaxSize:    int(lumberjackCfg.MaxSize),
-		MaxAge:     int(lumberjackCfg.MaxAge),
-		MaxBackups: int(lumberjackCfg.MaxBackups),
-		LocalTime:  lumberjackCfg.LocalTime,
-		Compress:   lumberjackCfg.Compress,
+	muxServer, err := mux.NewServer(cfg, grpcServer.Handler(), restHandler, log)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to create server")
+	}
+	if err := muxServer.Start(); err != nil {
+		log.Fatal().Err(err).Msg("Failed to start server")
 	}
 
+	return muxServer
+}
+
+// recentLogEntries is how many log entries are kept for the admin TailLogs RPC.
+const recentLogEntries = 1000
+
+// setupLog creates the server logger. It logs at every level and leaves the
+// filtering to logs, so the level can change at runtime.
+func setupLog(config *config.LogConfig) (zerolog.Logger, *logging.Logs, error) {
 	level, err := zerolog.ParseLevel(config.Level)
 	if err != nil {
-		return zerolog.Logger{}, fmt.Errorf("invalid log level: %s", config.Level)
+		return zerolog.Logger{}, nil, fmt.Errorf("invalid log level: %s", config.Level)
 	}
 
-	return zerolog.New(lr).Level(level).With().Timestamp().Logger(), nil
+	sinks, err := logging.OpenSinks(*config)
+	if err != nil {
+		return zerolog.Logger{}, nil, err
+	}
+
+	logs := logging.New(level, recentLogEntries, sinks)
+
+	return zerolog.New(logs).With().Timestamp().Logger(), logs, nil
 }
@@ -0,0 +1,192 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"hostManager/internal/config"
+	"hostManager/internal/logging"
+	"hostManager/internal/transport/grpc"
+	"hostManager/internal/transport/mux"
+)
+
+// reloadDelay lets an editor finish writing the config file before it is read.
+const reloadDelay = 200 * time.Millisecond
+
+// liveSections can change while the server runs, except that policies can only
+// be removed by a restart. TLS certificates can change too, as long as TLS
+// stays on; everything else takes a restart.
+var liveSections = []string{"log.", "backup.", "authz.", "admission.", "rate_limit."}
+
+// reloader applies the config file again on SIGHUP and, if enabled, whenever
+// the file changes.
+type reloader struct {
+	grpc    *grpc.Server
+	mux     *mux.Server
+	logs    *logging.Logs
+	started *config.Config
+	watcher *fsnotify.Watcher
+
+	mu      sync.Mutex
+	pending *time.Timer
+}
+
+func newReloader(cfg *config.Config, grpcServer *grpc.Server, muxServer *mux.Server, logs *logging.Logs) *reloader {
+	return &reloader{grpc: grpcServer, mux: muxServer, logs: logs, started: cfg}
+}
+
+// watch reloads the config whenever its file changes. The directory is
+// watched, as editors often replace files by renaming.
+func (r *reloader) watch() error {
+	const op = "app.watch"
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("%s: failed to create watcher: %w", op, err)
+	}
+
+	path := filepath.Clean(r.started.Path())
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		_ = watcher.Close()
+		return fmt.Errorf("%s: failed to watch %s: %w", op, path, err)
+	}
+	r.watcher = watcher
+
+	go func() {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != path || event.Has(fsnotify.Chmod) {
+					continue
+				}
+
+				r.mu.Lock()
+				if r.pending != nil {
+					r.pending.Reset(reloadDelay)
+				} else {
+					r.pending = time.AfterFunc(reloadDelay, r.reload)
+				}
+				r.mu.Unlock()
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Error().Str("op", op).Err(err).Msg("Config file watcher failed")
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (r *reloader) Close() error {
+	if r.watcher == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	if r.pending != nil {
+		r.pending.Stop()
+	}
+	r.mu.Unlock()
+
+	return r.watcher.Close()
+}
+
+// reload reads the config file again and applies what can change live. An
+// invalid config is rejected as a whole and the running one is kept.
+func (r *reloader) reload() {
+	const op = "app.reload"
+	l := log.With().Str("op", op).Logger()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pending = nil
+
+	cfg, err := r.started.Reload()
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to reload config, keeping the running one")
+		return
+	}
+
+	level, err := zerolog.ParseLevel(cfg.LogConfig.Level)
+	if err != nil {
+		l.Error().Str("logLevel", cfg.LogConfig.Level).Msg("Invalid log level in config, keeping the running one")
+		return
+	}
+
+	applyGRPC, err := r.grpc.PrepareReload(cfg)
+	if err != nil {
+		l.Error().Err(err).Msg("Invalid config, keeping the running one")
+		return
+	}
+
+	applyMux := func() {}
+	if r.mux != nil && cfg.MuxConfig.Port != "" {
+		applyMux, err = r.mux.PrepareReload(cfg.MuxConfig)
+		if err != nil {
+			l.Error().Err(err).Msg("Invalid config, keeping the running one")
+			return
+		}
+	}
+
+	// Sinks are opened last as they are the only step with side effects.
+	sinks, err := logging.OpenSinks(cfg.LogConfig)
+	if err != nil {
+		l.Error().Err(err).Msg("Invalid config, keeping the running one")
+		return
+	}
+
+	applyGRPC()
+	applyMux()
+	if err := r.logs.Reconfigure(level, sinks); err != nil {
+		l.Warn().Err(err).Msg("Failed to close previous log sinks")
+	}
+
+	var restart []string
+	for _, field := range config.Diff(r.started, cfg) {
+		if !r.live(field, cfg) {
+			restart = append(restart, field)
+		}
+	}
+	if len(restart) > 0 {
+		l.Warn().Strs("fields", restart).Msg("Config reloaded, changed fields take effect after a restart")
+		return
+	}
+
+	l.Info().Msg("Config reloaded")
+}
+
+func (r *reloader) live(field string, cfg *config.Config) bool {
+	switch field {
+	case "authz.policy_file":
+		return cfg.AuthzConfig.PolicyFile != ""
+	case "admission.policy_file":
+		return cfg.AdmissionConfig.PolicyFile != ""
+	}
+
+	for _, section := range liveSections {
+		if strings.HasPrefix(field, section) {
+			return true
+		}
+	}
+
+	switch {
+	case strings.HasPrefix(field, "grpc.tls."):
+		return r.started.GRPCConfig.TLS.CertFile != "" && cfg.GRPCConfig.TLS.CertFile != ""
+	case strings.HasPrefix(field, "mux.tls."):
+		return r.mux != nil && cfg.MuxConfig.Port != ""
+	}
+
+	return false
+}
@@ -0,0 +1,301 @@
+package approval
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"hostManager/internal/audit"
+	"hostManager/internal/auth"
+	"hostManager/internal/fsutil"
+)
+
+const requestsFileName = "pending-changes.json"
+
+var (
+	ErrRequestNotFound = errors.New("pending change not found")
+	ErrSelfApproval    = errors.New("a change must be approved by someone other than its requester")
+	ErrInProgress      = errors.New("pending change is already being applied")
+)
+
+// Request is a change waiting for approval.
+type Request struct {
+	ID string `json:"id"`
+	// Method is the mutation to run once approved and Payload its
+	// JSON-encoded request.
+	Method    string          `json:"method"`
+	Payload   json.RawMessage `json:"payload"`
+	Requester string          `json:"requester"`
+	// Principal names the requester the same way whichever method they
+	// authenticated with. Self-approval is checked on it.
+	Principal string `json:"principal"`
+	// Identity is the requester the change runs as once approved, nil for
+	// an unauthenticated requester.
+	Identity  *auth.Identity `json:"identity,omitempty"`
+	Diff      string         `json:"diff"`
+	CreatedAt time.Time      `json:"created_at"`
+	ExpiresAt time.Time      `json:"expires_at"`
+}
+
+// Queue holds changes until a second identity approves or rejects them.
+// Requests nobody decides on expire after the configured TTL. Requests are
+// persisted in the state directory so they survive restarts.
+type Queue struct {
+	ttl   time.Duration
+	audit *audit.Logger
+	path  string
+
+	mu       sync.Mutex
+	requests map[string]*Request
+	timers   map[string]*time.Timer
+	// running holds the requests being applied after an approval.
+	running map[string]bool
+}
+
+func New(stateDir string, ttl time.Duration, audit *audit.Logger) *Queue {
+	return &Queue{
+		ttl:      ttl,
+		audit:    audit,
+		path:     filepath.Join(stateDir, requestsFileName),
+		requests: make(map[string]*Request),
+		timers:   make(map[string]*time.Timer),
+		running:  make(map[string]bool),
+	}
+}
+
+// Start loads persisted requests and arms their expiry timers. Requests that
+// expired while the process was down expire right away.
+func (q *Queue) Start() error {
+	const op = "approval.Start"
+
+	if err := os.MkdirAll(filepath.Dir(q.path), 0750); err != nil {
+		return fmt.Errorf("op: %s, failed to create state dir: %w", op, err)
+	}
+
+	data, err := os.ReadFile(q.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("op: %s, failed to read %s: %w", op, q.path, err)
+	}
+
+	var requests []*Request
+	if err := json.Unmarshal(data, &requests); err != nil {
+		return fmt.Errorf("op: %s, failed to parse %s: %w", op, q.path, err)
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, req := range requests {
+		q.arm(req)
+	}
+
+	log.Info().Str("op", op).Int("count", len(requests)).Msg("Loaded pending changes")
+	return nil
+}
+
+// Stop disarms all timers. Requests stay persisted.
+func (q *Queue) Stop() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, timer := range q.timers {
+		timer.Stop()
+	}
+}
+
+// Add queues a request and sets its id and times.
+func (q *Queue) Add(req Request) (Request, error) {
+	const op = "approval.Add"
+
+	id, err := newRequestID()
+	if err != nil {
+		return Request{}, fmt.Errorf("op: %s, failed to generate id: %w", op, err)
+	}
+	req.ID = id
+	req.CreatedAt = time.Now()
+	req.ExpiresAt = req.CreatedAt.Add(q.ttl)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.arm(&req)
+	if err := q.save(); err != nil {
+		q.remove(req.ID)
+		return Request{}, fmt.Errorf("op: %s: %w", op, err)
+	}
+
+	q.audit.Record(audit.Record{Actor: req.Requester, Action: "request", Resource: "pending-change", Target: req.ID})
+	log.Info().Str("op", op).Str("requestID", req.ID).Str("method", req.Method).
+		Str("requester", req.Requester).Msg("Change waits for approval")
+	return req, nil
+}
+
+// List returns the pending requests, oldest first.
+func (q *Queue) List() []Request {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	requests := make([]Request, 0, len(q.requests))
+	for _, req := range q.requests {
+		requests = append(requests, *req)
+	}
+	sort.Slice(requests, func(i, j int) bool { return requests[i].CreatedAt.Before(requests[j].CreatedAt) })
+
+	return requests
+}
+
+// Approve runs the request and removes it once it succeeded, so that a change
+// that fails, e.g. on a version conflict, can be approved again. The approver,
+// named by principal as in Request.Principal, must differ from the requester.
+func (q *Queue) Approve(ctx context.Context, id, approver, principal string, run func(context.Context, Request) error) error {
+	const op = "approval.Approve"
+
+	q.mu.Lock()
+	req, ok := q.requests[id]
+	if !ok {
+		q.mu.Unlock()
+		return fmt.Errorf("op: %s, %s: %w", op, id, ErrRequestNotFound)
+	}
+	if req.Principal != "" && req.Principal == principal {
+		q.mu.Unlock()
+		return fmt.Errorf("op: %s, %s: %w", op, id, ErrSelfApproval)
+	}
+	if q.running[id] {
+		q.mu.Unlock()
+		return fmt.Errorf("op: %s, %s: %w", op, id, ErrInProgress)
+	}
+	q.running[id] = true
+	q.mu.Unlock()
+
+	err := run(ctx, *req)
+	q.audit.Record(audit.Record{Actor: approver, Action: "approve", Resource: "pending-change", Target: id, Err: err})
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	delete(q.running, id)
+	if err != nil {
+		// The request expired while it was being applied.
+		if !time.Now().Before(req.ExpiresAt) {
+			q.expireLocked(id)
+		}
+		return fmt.Errorf("op: %s, %s: %w", op, id, err)
+	}
+
+	q.remove(id)
+	if err := q.save(); err != nil {
+		log.Error().Str("op", op).Err(err).Msg("Failed to persist pending changes")
+	}
+
+	log.Info().Str("op", op).Str("requestID", id).Str("approver", approver).Msg("Change approved")
+	return nil
+}
+
+// Reject drops the request without running it.
+func (q *Queue) Reject(id, actor, reason string) error {
+	const op = "approval.Reject"
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if _, ok := q.requests[id]; !ok {
+		return fmt.Errorf("op: %s, %s: %w", op, id, ErrRequestNotFound)
+	}
+	if q.running[id] {
+		return fmt.Errorf("op: %s, %s: %w", op, id, ErrInProgress)
+	}
+	q.remove(id)
+
+	if err := q.save(); err != nil {
+		return fmt.Errorf("op: %s: %w", op, err)
+	}
+
+	q.audit.Record(audit.Record{Actor: actor, Action: "reject", Resource: "pending-change", Target: id})
+	log.Info().Str("op", op).Str("requestID", id).Str("actor", actor).Str("reason", reason).Msg("Change rejected")
+	return nil
+}
+
+func (q *Queue) arm(req *Request) {
+	q.requests[req.ID] = req
+	id := req.ID
+	q.timers[req.ID] = time.AfterFunc(time.Until(req.ExpiresAt), func() { q.expire(id) })
+}
+
+// remove forgets a request. The caller must hold q.mu.
+func (q *Queue) remove(id string) {
+	if timer, ok := q.timers[id]; ok {
+		timer.Stop()
+	}
+	delete(q.timers, id)
+	delete(q.requests, id)
+}
+
+func (q *Queue) expire(id string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	// The request was decided on after this timer fired, or is being applied
+	// and expires only if that fails.
+	if _, ok := q.requests[id]; !ok || q.running[id] {
+		return
+	}
+	q.expireLocked(id)
+}
+
+// expireLocked drops a request nobody decided on in time. The caller must
+// hold q.mu.
+func (q *Queue) expireLocked(id string) {
+	const op = "approval.expire"
+
+	q.remove(id)
+
+	q.audit.Record(audit.Record{Actor: "approval", Action: "expire", Resource: "pending-change", Target: id})
+	log.Info().Str("op", op).Str("requestID", id).Msg("Pending change expired without approval")
+
+	if err := q.save(); err != nil {
+		log.Error().Str("op", op).Err(err).Msg("Failed to persist pending changes")
+	}
+}
+
+// save persists the requests. The caller must hold q.mu.
+func (q *Queue) save() error {
+	const op = "approval.save"
+
+	requests := make([]*Request, 0, len(q.requests))
+	for _, req := range q.requests {
+		requests = append(requests, req)
+	}
+
+	data, err := json.Marshal(requests)
+	if err != nil {
+		return fmt.Errorf("op: %s, failed to encode requests: %w", op, err)
+	}
+
+	if err := fsutil.WriteFileAtomic(q.path, data); err != nil {
+		return fmt.Errorf("op: %s: %w", op, err)
+	}
+
+	return nil
+}
+
+func newRequestID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
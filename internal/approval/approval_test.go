@@ -0,0 +1,140 @@
+package approval
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"hostManager/internal/audit"
+)
+
+func newTestQueue(t *testing.T) (*Queue, string) {
+	t.Helper()
+	dir := t.TempDir()
+
+	auditLog, err := audit.New(filepath.Join(dir, "audit.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = auditLog.Close() })
+
+	stateDir := filepath.Join(dir, "state")
+	q := New(stateDir, time.Hour, auditLog)
+	if err := q.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(q.Stop)
+
+	return q, stateDir
+}
+
+func TestApprove(t *testing.T) {
+	ctx := context.Background()
+	errRun := errors.New("version mismatch")
+
+	tests := []struct {
+		name      string
+		requester string
+		principal string
+		approver  string
+		runErr    error
+		wantErr   error
+		// wantKept is whether the request is still pending afterwards.
+		wantKept bool
+	}{
+		{name: "approved", requester: "mtls:alice", principal: "alice", approver: "bob"},
+		{
+			name:      "self-approval over another method",
+			requester: "mtls:alice",
+			principal: "alice",
+			approver:  "alice",
+			wantErr:   ErrSelfApproval,
+			wantKept:  true,
+		},
+		{
+			name:      "failed change stays pending",
+			requester: "mtls:alice",
+			principal: "alice",
+			approver:  "bob",
+			runErr:    errRun,
+			wantErr:   errRun,
+			wantKept:  true,
+		},
+		{name: "unauthenticated requester", approver: "bob"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, stateDir := newTestQueue(t)
+
+			req, err := q.Add(Request{Method: "SetHostname", Requester: tt.requester, Principal: tt.principal})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = q.Approve(ctx, req.ID, "jwt:"+tt.approver, tt.approver, func(context.Context, Request) error {
+				return tt.runErr
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Approve() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got := len(q.List()); (got != 0) != tt.wantKept {
+				t.Fatalf("pending requests = %d, want kept %t", got, tt.wantKept)
+			}
+
+			// The persisted queue agrees.
+			q.Stop()
+			restarted := New(stateDir, time.Hour, q.audit)
+			if err := restarted.Start(); err != nil {
+				t.Fatal(err)
+			}
+			defer restarted.Stop()
+			if got := len(restarted.List()); (got != 0) != tt.wantKept {
+				t.Fatalf("persisted requests = %d, want kept %t", got, tt.wantKept)
+			}
+		})
+	}
+}
+
+func TestApproveInProgress(t *testing.T) {
+	ctx := context.Background()
+	q, _ := newTestQueue(t)
+
+	req, err := q.Add(Request{Method: "SetHostname", Requester: "mtls:alice", Principal: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- q.Approve(ctx, req.ID, "jwt:bob", "bob", func(context.Context, Request) error {
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+	<-started
+
+	if err := q.Approve(ctx, req.ID, "jwt:carol", "carol", func(context.Context, Request) error {
+		t.Error("request ran twice")
+		return nil
+	}); !errors.Is(err, ErrInProgress) {
+		t.Errorf("second Approve() error = %v, want %v", err, ErrInProgress)
+	}
+	if err := q.Reject(req.ID, "jwt:carol", ""); !errors.Is(err, ErrInProgress) {
+		t.Errorf("Reject() error = %v, want %v", err, ErrInProgress)
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("Approve() error = %v", err)
+	}
+	if got := len(q.List()); got != 0 {
+		t.Fatalf("pending requests = %d, want 0", got)
+	}
+}
@@ -0,0 +1,55 @@
+package audit
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog"
+)
+
+// Record describes a change made to the host.
+type Record struct {
+	// Actor is who made the change, e.g. an identity or a background job.
+	Actor    string
+	Action   string
+	Resource string
+	Target   string
+	Err      error
+}
+
+// Logger appends audit records as JSON lines to a file.
+type Logger struct {
+	file *os.File
+	log  zerolog.Logger
+}
+
+func New(path string) (*Logger, error) {
+	const op = "audit.New"
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+	if err != nil {
+		return nil, fmt.Errorf("op: %s, failed to open audit log %s: %w", op, path, err)
+	}
+
+	return &Logger{file: file, log: zerolog.New(file).With().Timestamp().Logger()}, nil
+}
+
+func (a *Logger) Record(r Record) {
+	e := a.log.Log().
+		Str("actor", r.Actor).
+		Str("action", r.Action).
+		Str("resource", r.Resource).
+		Str("target", r.Target)
+
+	if r.Err != nil {
+		e = e.Err(r.Err).Bool("success", false)
+	} else {
+		e = e.Bool("success", true)
+	}
+
+	e.Send()
+}
+
+func (a *Logger) Close() error {
+	return a.file.Close()
+}
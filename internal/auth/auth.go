@@ -0,0 +1,153 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"hostManager/internal/grpcutil"
+)
+
+// Authentication methods an Identity can come from.
+const (
+	MethodMTLS = "mtls"
+	MethodJWT  = "jwt"
+	MethodUnix = "unix"
+)
+
+// Identity is the authenticated caller of an RPC.
+type Identity struct {
+	// Method is how the caller authenticated.
+	Method string `json:"method"`
+	// Subject names the caller, e.g. the common name of a client certificate
+	// or the uid of a process on a Unix socket.
+	Subject string `json:"subject"`
+	// Groups lists the groups the caller belongs to, e.g. the organizational
+	// units of a client certificate or the groups claim of a token.
+	Groups []string `json:"groups,omitempty"`
+	// Claims holds all claims of a bearer token, or the uid, gid and pid of a
+	// process on a Unix socket.
+	Claims map[string]any `json:"claims,omitempty"`
+}
+
+// String names the identity as "<method>:<subject>", e.g. "mtls:alice".
+func (id Identity) String() string {
+	return id.Method + ":" + id.Subject
+}
+
+// Principal names the caller independently of how they authenticated, so that
+// the same person is recognized over mTLS and with a bearer token.
+func (id Identity) Principal() string {
+	return id.Subject
+}
+
+type identityKey struct{}
+
+// WithIdentity returns a copy of ctx carrying id.
+func WithIdentity(ctx context.Context, id Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// WithoutIdentity returns a copy of ctx carrying no identity, as for an
+// unauthenticated caller.
+func WithoutIdentity(ctx context.Context) context.Context {
+	return context.WithValue(ctx, identityKey{}, nil)
+}
+
+// FromContext returns the identity of the caller, if one was authenticated.
+func FromContext(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(Identity)
+	return id, ok
+}
+
+// UnaryServerInterceptor puts the identity of the caller into the request
+// context. A bearer token takes precedence over the client certificate, so
+// callers behind the REST gateway are identified by their own token. jwt may
+// be nil to disable token authentication.
+func UnaryServerInterceptor(jwt *JWTVerifier) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authenticate(ctx, jwt)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of UnaryServerInterceptor.
+func StreamServerInterceptor(jwt *JWTVerifier) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), jwt)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, grpcutil.WithContext(ss, ctx))
+	}
+}
+
+func authenticate(ctx context.Context, jwt *JWTVerifier) (context.Context, error) {
+	token, ok := bearerToken(ctx)
+	if !ok {
+		return withPeerIdentity(ctx), nil
+	}
+
+	if jwt == nil {
+		return nil, status.Error(codes.Unauthenticated, "token authentication is not enabled")
+	}
+
+	id, err := jwt.Verify(token)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid bearer token: %v", err)
+	}
+
+	return WithIdentity(ctx, id), nil
+}
+
+// bearerToken reads the token from the authorization metadata, which the REST
+// gateway fills from the Authorization header.
+func bearerToken(ctx context.Context) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+
+	for _, value := range md.Get("authorization") {
+		scheme, token, found := strings.Cut(value, " ")
+		if found && strings.EqualFold(scheme, "bearer") {
+			return strings.TrimSpace(token), true
+		}
+	}
+
+	return "", false
+}
+
+func withPeerIdentity(ctx context.Context) context.Context {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ctx
+	}
+
+	if unixInfo, ok := p.AuthInfo.(UnixAuthInfo); ok {
+		return WithIdentity(ctx, unixIdentity(unixInfo.Cred))
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return ctx
+	}
+
+	cert := tlsInfo.State.VerifiedChains[0][0]
+	return WithIdentity(ctx, Identity{
+		Method:  MethodMTLS,
+		Subject: cert.Subject.CommonName,
+		Groups:  cert.Subject.OrganizationalUnit,
+	})
+}
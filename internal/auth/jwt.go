@@ -0,0 +1,184 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/rs/zerolog/log"
+
+	"hostManager/internal/config"
+)
+
+// clockSkew is the leeway allowed when checking token times.
+const clockSkew = time.Minute
+
+var signatureAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.PS256, jose.PS384, jose.PS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.EdDSA,
+}
+
+// JWTVerifier verifies bearer tokens against a JWKS loaded from a file or
+// URL. The key set is refreshed periodically so key rotation needs no restart.
+type JWTVerifier struct {
+	cfg    config.JWTConfig
+	keys   atomic.Pointer[jose.JSONWebKeySet]
+	client *http.Client
+	stop   chan struct{}
+}
+
+func NewJWTVerifier(cfg config.JWTConfig) (*JWTVerifier, error) {
+	const op = "auth.NewJWTVerifier"
+
+	if (cfg.JWKSFile == "") == (cfg.JWKSURL == "") {
+		return nil, fmt.Errorf("%s: set exactly one of jwks_file and jwks_url", op)
+	}
+	// Without both, any token signed by a key in the set would do, whoever it
+	// was issued by or for.
+	if cfg.Issuer == "" || cfg.Audience == "" {
+		return nil, fmt.Errorf("%s: issuer and audience are required", op)
+	}
+	if cfg.RefreshInterval <= 0 {
+		return nil, fmt.Errorf("%s: refresh_interval must be positive, got %s", op, cfg.RefreshInterval)
+	}
+
+	v := &JWTVerifier{cfg: cfg, client: &http.Client{Timeout: 10 * time.Second}, stop: make(chan struct{})}
+	if err := v.refresh(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	go v.refreshLoop()
+
+	return v, nil
+}
+
+func (v *JWTVerifier) Close() {
+	close(v.stop)
+}
+
+// Verify checks the signature, issuer, audience and expiry of a token and
+// returns the identity it carries.
+func (v *JWTVerifier) Verify(raw string) (Identity, error) {
+	tok, err := jwt.ParseSigned(raw, signatureAlgorithms)
+	if err != nil {
+		return Identity{}, fmt.Errorf("malformed token: %w", err)
+	}
+
+	var (
+		claims jwt.Claims
+		all    map[string]any
+	)
+	if err := tok.Claims(v.keys.Load(), &claims, &all); err != nil {
+		return Identity{}, fmt.Errorf("invalid token signature: %w", err)
+	}
+
+	if claims.Expiry == nil {
+		return Identity{}, errors.New("token has no expiry")
+	}
+
+	expected := jwt.Expected{Issuer: v.cfg.Issuer, AnyAudience: jwt.Audience{v.cfg.Audience}, Time: time.Now()}
+	if err := claims.ValidateWithLeeway(expected, clockSkew); err != nil {
+		return Identity{}, err
+	}
+
+	return Identity{
+		Method:  MethodJWT,
+		Subject: claims.Subject,
+		Groups:  stringsClaim(all[v.cfg.GroupsClaim]),
+		Claims:  all,
+	}, nil
+}
+
+func (v *JWTVerifier) refreshLoop() {
+	ticker := time.NewTicker(v.cfg.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := v.refresh(); err != nil {
+				log.Error().Str("op", "auth.JWTVerifier").Err(err).Msg("Failed to refresh JWKS, keeping the previous keys")
+			}
+		case <-v.stop:
+			return
+		}
+	}
+}
+
+func (v *JWTVerifier) refresh() error {
+	var (
+		data []byte
+		err  error
+	)
+	if v.cfg.JWKSFile != "" {
+		data, err = os.ReadFile(v.cfg.JWKSFile)
+	} else {
+		data, err = v.fetch()
+	}
+	if err != nil {
+		return err
+	}
+
+	var keys jose.JSONWebKeySet
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return fmt.Errorf("failed to parse JWKS: %w", err)
+	}
+	if len(keys.Keys) == 0 {
+		return errors.New("JWKS contains no keys")
+	}
+
+	v.keys.Store(&keys)
+	return nil
+}
+
+func (v *JWTVerifier) fetch() ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), v.client.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.cfg.JWKSURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch JWKS: %s", resp.Status)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+}
+
+// stringsClaim reads a claim holding either a list of strings or a single
+// space-separated string, as used for groups and scopes.
+func stringsClaim(v any) []string {
+	switch v := v.(type) {
+	case string:
+		return strings.Fields(v)
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,338 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+
+	"hostManager/internal/config"
+)
+
+const (
+	testIssuer   = "https://issuer.example.com"
+	testAudience = "host-manager"
+)
+
+type testKey struct {
+	private *ecdsa.PrivateKey
+	id      string
+}
+
+func newTestKey(t *testing.T, id string) testKey {
+	t.Helper()
+
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return testKey{private: private, id: id}
+}
+
+func (k testKey) jwk() jose.JSONWebKey {
+	return jose.JSONWebKey{Key: &k.private.PublicKey, KeyID: k.id, Algorithm: string(jose.ES256), Use: "sig"}
+}
+
+// sign issues a token with the registered claims and the private claims in
+// extra.
+func (k testKey) sign(t *testing.T, claims jwt.Claims, extra map[string]any) string {
+	t.Helper()
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: jose.JSONWebKey{Key: k.private, KeyID: k.id}},
+		(&jose.SignerOptions{}).WithType("JWT"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := jwt.Signed(signer).Claims(claims).Claims(extra).Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return raw
+}
+
+func jwks(t *testing.T, keys ...testKey) []byte {
+	t.Helper()
+
+	var set jose.JSONWebKeySet
+	for _, k := range keys {
+		set.Keys = append(set.Keys, k.jwk())
+	}
+	data, err := json.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+func validClaims() jwt.Claims {
+	now := time.Now()
+	return jwt.Claims{
+		Issuer:   testIssuer,
+		Audience: jwt.Audience{testAudience},
+		Subject:  "alice",
+		IssuedAt: jwt.NewNumericDate(now),
+		Expiry:   jwt.NewNumericDate(now.Add(time.Hour)),
+	}
+}
+
+func newTestVerifier(t *testing.T, keys ...testKey) *JWTVerifier {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(file, jwks(t, keys...), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := NewJWTVerifier(config.JWTConfig{
+		JWKSFile:        file,
+		Issuer:          testIssuer,
+		Audience:        testAudience,
+		GroupsClaim:     "groups",
+		RefreshInterval: time.Hour,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(v.Close)
+
+	return v
+}
+
+func TestVerify(t *testing.T) {
+	key := newTestKey(t, "current")
+	// other claims the key ID of key, so only the signature tells them apart.
+	other := newTestKey(t, "current")
+	v := newTestVerifier(t, key)
+
+	tests := []struct {
+		name    string
+		token   func() string
+		wantErr bool
+	}{
+		{
+			name:  "valid",
+			token: func() string { return key.sign(t, validClaims(), nil) },
+		},
+		{
+			name:    "bad signature",
+			token:   func() string { return other.sign(t, validClaims(), nil) },
+			wantErr: true,
+		},
+		{
+			name: "wrong issuer",
+			token: func() string {
+				c := validClaims()
+				c.Issuer = "https://other.example.com"
+				return key.sign(t, c, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "wrong audience",
+			token: func() string {
+				c := validClaims()
+				c.Audience = jwt.Audience{"other-service"}
+				return key.sign(t, c, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "one of several audiences",
+			token: func() string {
+				c := validClaims()
+				c.Audience = jwt.Audience{"other-service", testAudience}
+				return key.sign(t, c, nil)
+			},
+		},
+		{
+			name: "expired",
+			token: func() string {
+				c := validClaims()
+				c.Expiry = jwt.NewNumericDate(time.Now().Add(-2 * clockSkew))
+				return key.sign(t, c, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "expired within the clock skew",
+			token: func() string {
+				c := validClaims()
+				c.Expiry = jwt.NewNumericDate(time.Now().Add(-clockSkew / 2))
+				return key.sign(t, c, nil)
+			},
+		},
+		{
+			name: "not valid yet",
+			token: func() string {
+				c := validClaims()
+				c.NotBefore = jwt.NewNumericDate(time.Now().Add(2 * clockSkew))
+				return key.sign(t, c, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "no expiry",
+			token: func() string {
+				c := validClaims()
+				c.Expiry = nil
+				return key.sign(t, c, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "malformed",
+			token:   func() string { return "not.a.token" },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := v.Verify(tt.token())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (id.Method != MethodJWT || id.Subject != "alice") {
+				t.Errorf("Verify() = %+v, want jwt:alice", id)
+			}
+		})
+	}
+}
+
+func TestVerifyGroups(t *testing.T) {
+	key := newTestKey(t, "current")
+	v := newTestVerifier(t, key)
+
+	tests := []struct {
+		name   string
+		groups any
+		want   []string
+	}{
+		{name: "list", groups: []string{"netops", "oncall"}, want: []string{"netops", "oncall"}},
+		{name: "space-separated", groups: "netops oncall", want: []string{"netops", "oncall"}},
+		{name: "missing", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			extra := map[string]any{}
+			if tt.groups != nil {
+				extra["groups"] = tt.groups
+			}
+
+			id, err := v.Verify(key.sign(t, validClaims(), extra))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(id.Groups, tt.want) {
+				t.Errorf("groups = %v, want %v", id.Groups, tt.want)
+			}
+		})
+	}
+}
+
+// TestKeyRotation checks that a key added to the JWKS is picked up without a
+// restart, and that tokens of a removed key are then rejected.
+func TestKeyRotation(t *testing.T) {
+	old := newTestKey(t, "old")
+	rotated := newTestKey(t, "new")
+
+	var keys atomic.Value
+	keys.Store(jwks(t, old))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(keys.Load().([]byte))
+	}))
+	defer srv.Close()
+
+	v, err := NewJWTVerifier(config.JWTConfig{
+		JWKSURL:         srv.URL,
+		Issuer:          testIssuer,
+		Audience:        testAudience,
+		RefreshInterval: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer v.Close()
+
+	oldToken := old.sign(t, validClaims(), nil)
+	newToken := rotated.sign(t, validClaims(), nil)
+	if _, err := v.Verify(oldToken); err != nil {
+		t.Fatalf("token of the current key: %v", err)
+	}
+	if _, err := v.Verify(newToken); err == nil {
+		t.Fatal("token of an unpublished key verified")
+	}
+
+	keys.Store(jwks(t, rotated))
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, err := v.Verify(newToken); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("rotated key was not picked up")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if _, err := v.Verify(oldToken); err == nil {
+		t.Error("token of a removed key verified")
+	}
+
+	// A broken key set keeps the previous keys.
+	keys.Store([]byte("{"))
+	time.Sleep(50 * time.Millisecond)
+	if _, err := v.Verify(newToken); err != nil {
+		t.Errorf("keys dropped after a failed refresh: %v", err)
+	}
+}
+
+func TestNewJWTVerifierConfig(t *testing.T) {
+	key := newTestKey(t, "current")
+	file := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(file, jwks(t, key), 0600); err != nil {
+		t.Fatal(err)
+	}
+	valid := config.JWTConfig{JWKSFile: file, Issuer: testIssuer, Audience: testAudience, RefreshInterval: time.Hour}
+
+	tests := []struct {
+		name string
+		edit func(*config.JWTConfig)
+	}{
+		{name: "no key source", edit: func(c *config.JWTConfig) { c.JWKSFile = "" }},
+		{name: "two key sources", edit: func(c *config.JWTConfig) { c.JWKSURL = "https://issuer.example.com/jwks" }},
+		{name: "no issuer", edit: func(c *config.JWTConfig) { c.Issuer = "" }},
+		{name: "no audience", edit: func(c *config.JWTConfig) { c.Audience = "" }},
+		{name: "zero refresh interval", edit: func(c *config.JWTConfig) { c.RefreshInterval = 0 }},
+		{name: "negative refresh interval", edit: func(c *config.JWTConfig) { c.RefreshInterval = -time.Minute }},
+		{name: "missing key file", edit: func(c *config.JWTConfig) { c.JWKSFile = filepath.Join(t.TempDir(), "none.json") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid
+			tt.edit(&cfg)
+
+			v, err := NewJWTVerifier(cfg)
+			if err == nil {
+				v.Close()
+				t.Fatal("NewJWTVerifier() succeeded, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package auth
+
+import (
+	"fmt"
+	"net"
+
+	"golang.org/x/sys/unix"
+)
+
+func peerCred(conn *net.UnixConn) (PeerCred, error) {
+	raw, err := conn.SyscallConn()
+	if err != nil {
+		return PeerCred{}, fmt.Errorf("failed to read peer credentials: %w", err)
+	}
+
+	var (
+		ucred  *unix.Ucred
+		sysErr error
+	)
+	err = raw.Control(func(fd uintptr) {
+		ucred, sysErr = unix.GetsockoptUcred(int(fd), unix.SOL_SOCKET, unix.SO_PEERCRED)
+	})
+	if err == nil {
+		err = sysErr
+	}
+	if err != nil {
+		return PeerCred{}, fmt.Errorf("failed to read peer credentials: %w", err)
+	}
+
+	return PeerCred{UID: int(ucred.Uid), GID: int(ucred.Gid), PID: int(ucred.Pid)}, nil
+}
@@ -0,0 +1,12 @@
+//go:build !linux
+
+package auth
+
+import (
+	"errors"
+	"net"
+)
+
+func peerCred(*net.UnixConn) (PeerCred, error) {
+	return PeerCred{}, errors.New("peer credentials are only supported on Linux")
+}
@@ -0,0 +1,218 @@
+package authz
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v3"
+
+	"hostManager/internal/auth"
+)
+
+// Anyone matches every caller, authenticated or not.
+const Anyone = "*"
+
+// publicMethods may be called by anyone whatever the policy says, so that load
+// balancers and probes can check health without credentials.
+var publicMethods = []string{"/grpc.health.v1.Health/*"}
+
+// Policy maps identities to roles and roles to the RPCs they may call.
+type Policy struct {
+	Roles    map[string]Role `yaml:"roles"`
+	Bindings []Binding       `yaml:"bindings"`
+}
+
+// Role lists full gRPC method names a role may call, such as
+// "/dns.DNSHostnameService/AddDNSServer". Patterns use path.Match syntax, so
+// "/dns.DNSHostnameService/List*" grants every List method.
+type Role struct {
+	Methods []string `yaml:"methods"`
+}
+
+// Binding grants a role to callers matching any of its subjects, groups or
+// claims. Subjects are written as "<method>:<subject>", e.g. "mtls:alice" or
+// "jwt:provisioner"; "<method>:*" matches every caller of that method and "*"
+// matches everyone. Claims match bearer tokens whose claim contains the given
+// value, either as a list or as a space-separated string like "scope".
+type Binding struct {
+	Role     string            `yaml:"role"`
+	Subjects []string          `yaml:"subjects"`
+	Groups   []string          `yaml:"groups"`
+	Claims   map[string]string `yaml:"claims"`
+}
+
+func Load(file string) (*Policy, error) {
+	const op = "authz.Load"
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to read %s: %w", op, file, err)
+	}
+
+	var p Policy
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("%s: failed to parse %s: %w", op, file, err)
+	}
+
+	if err := p.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, file, err)
+	}
+
+	return &p, nil
+}
+
+func (p *Policy) validate() error {
+	for name, role := range p.Roles {
+		for _, method := range role.Methods {
+			if _, err := path.Match(method, ""); err != nil {
+				return fmt.Errorf("role %s: bad method pattern %q: %w", name, method, err)
+			}
+		}
+	}
+
+	for i, b := range p.Bindings {
+		if _, ok := p.Roles[b.Role]; !ok {
+			return fmt.Errorf("binding %d: unknown role %q", i, b.Role)
+		}
+	}
+
+	return nil
+}
+
+// Allowed reports whether the caller may invoke fullMethod. id is nil for an
+// unauthenticated caller.
+func (p *Policy) Allowed(id *auth.Identity, fullMethod string) bool {
+	for _, b := range p.Bindings {
+		if !b.matches(id) {
+			continue
+		}
+
+		for _, pattern := range p.Roles[b.Role].Methods {
+			if ok, _ := path.Match(pattern, fullMethod); ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (b Binding) matches(id *auth.Identity) bool {
+	if slices.Contains(b.Subjects, Anyone) {
+		return true
+	}
+	if id == nil {
+		return false
+	}
+
+	if slices.Contains(b.Subjects, id.Method+":"+id.Subject) || slices.Contains(b.Subjects, id.Method+":*") {
+		return true
+	}
+
+	for _, group := range id.Groups {
+		if slices.Contains(b.Groups, group) {
+			return true
+		}
+	}
+
+	for name, want := range b.Claims {
+		switch got := id.Claims[name].(type) {
+		case string:
+			if slices.Contains(strings.Fields(got), want) {
+				return true
+			}
+		case []any:
+			if slices.Contains(got, any(want)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Authorizer enforces a policy that can be replaced while the server runs.
+// Without a policy every caller may call every RPC.
+type Authorizer struct {
+	policy atomic.Pointer[Policy]
+}
+
+func NewAuthorizer(p *Policy) *Authorizer {
+	a := &Authorizer{}
+	a.policy.Store(p)
+
+	return a
+}
+
+// SetPolicy replaces the policy for calls that have not been authorized yet.
+func (a *Authorizer) SetPolicy(p *Policy) {
+	a.policy.Store(p)
+}
+
+// Enabled reports whether a policy is being enforced.
+func (a *Authorizer) Enabled() bool {
+	return a.policy.Load() != nil
+}
+
+// Authorize checks the caller of ctx against fullMethod the way the
+// interceptors do, for a method one RPC runs on behalf of another.
+func (a *Authorizer) Authorize(ctx context.Context, fullMethod string) error {
+	return authorize(ctx, a.policy.Load(), fullMethod)
+}
+
+// UnaryServerInterceptor rejects calls the policy does not allow. It must run
+// after the interceptors that authenticate the caller.
+func (a *Authorizer) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := authorize(ctx, a.policy.Load(), info.FullMethod); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of UnaryServerInterceptor.
+func (a *Authorizer) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := authorize(ss.Context(), a.policy.Load(), info.FullMethod); err != nil {
+			return err
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+func authorize(ctx context.Context, p *Policy, fullMethod string) error {
+	if p == nil {
+		return nil
+	}
+
+	for _, pattern := range publicMethods {
+		if ok, _ := path.Match(pattern, fullMethod); ok {
+			return nil
+		}
+	}
+
+	id, ok := auth.FromContext(ctx)
+	if !ok {
+		if p.Allowed(nil, fullMethod) {
+			return nil
+		}
+		return status.Errorf(codes.Unauthenticated, "%s requires an authenticated caller", fullMethod)
+	}
+
+	if !p.Allowed(&id, fullMethod) {
+		return status.Errorf(codes.PermissionDenied, "%s:%s may not call %s", id.Method, id.Subject, fullMethod)
+	}
+
+	return nil
+}
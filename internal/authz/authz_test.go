@@ -0,0 +1,315 @@
+package authz
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"hostManager/internal/auth"
+)
+
+var testPolicy = &Policy{
+	Roles: map[string]Role{
+		"reader":       {Methods: []string{"/dns.DNSHostnameService/Get*", "/dns.DNSHostnameService/List*"}},
+		"netops":       {Methods: []string{"/dns.DNSHostnameService/AddDNSServer"}},
+		"provisioning": {Methods: []string{"/dns.DNSHostnameService/SetHostname"}},
+		"admin":        {Methods: []string{"/dns.AdminService/*"}},
+	},
+	Bindings: []Binding{
+		{Role: "reader", Subjects: []string{"jwt:*"}},
+		{Role: "netops", Groups: []string{"netops"}},
+		{Role: "provisioning", Claims: map[string]string{"scope": "hosts:provision", "roles": "provisioner"}},
+		{Role: "admin", Subjects: []string{"mtls:admin", "unix:0"}},
+	},
+}
+
+func TestAllowed(t *testing.T) {
+	tests := []struct {
+		name   string
+		id     *auth.Identity
+		method string
+		want   bool
+	}{
+		{
+			name:   "exact method",
+			id:     &auth.Identity{Method: "jwt", Subject: "carol", Groups: []string{"netops"}},
+			method: "/dns.DNSHostnameService/AddDNSServer",
+			want:   true,
+		},
+		{
+			name:   "method of another role",
+			id:     &auth.Identity{Method: "jwt", Subject: "carol", Groups: []string{"netops"}},
+			method: "/dns.DNSHostnameService/SetHostname",
+		},
+		{
+			name:   "method pattern",
+			id:     &auth.Identity{Method: "jwt", Subject: "carol"},
+			method: "/dns.DNSHostnameService/ListDNSServers",
+			want:   true,
+		},
+		{
+			name:   "pattern does not cross services",
+			id:     &auth.Identity{Method: "jwt", Subject: "carol"},
+			method: "/dns.v2.HostConfigService/GetHostname",
+		},
+		{
+			name:   "pattern does not match a service prefix",
+			id:     &auth.Identity{Method: "mtls", Subject: "admin"},
+			method: "/dns.AdminServiceX/GetStatus",
+		},
+		{
+			name:   "service wildcard",
+			id:     &auth.Identity{Method: "mtls", Subject: "admin"},
+			method: "/dns.AdminService/SetFreeze",
+			want:   true,
+		},
+		{
+			name:   "subject",
+			id:     &auth.Identity{Method: "unix", Subject: "0"},
+			method: "/dns.AdminService/GetStatus",
+			want:   true,
+		},
+		{
+			name:   "subject of another method",
+			id:     &auth.Identity{Method: "jwt", Subject: "admin"},
+			method: "/dns.AdminService/GetStatus",
+		},
+		{
+			name:   "method wildcard of another method",
+			id:     &auth.Identity{Method: "mtls", Subject: "alice"},
+			method: "/dns.DNSHostnameService/GetHostname",
+		},
+		{
+			name:   "space-separated claim",
+			id:     &auth.Identity{Method: "jwt", Subject: "ci", Claims: map[string]any{"scope": "hosts:read hosts:provision"}},
+			method: "/dns.DNSHostnameService/SetHostname",
+			want:   true,
+		},
+		{
+			name:   "claim substring",
+			id:     &auth.Identity{Method: "jwt", Subject: "ci", Claims: map[string]any{"scope": "hosts:provisioner"}},
+			method: "/dns.DNSHostnameService/SetHostname",
+		},
+		{
+			name:   "list claim",
+			id:     &auth.Identity{Method: "jwt", Subject: "ci", Claims: map[string]any{"roles": []any{"viewer", "provisioner"}}},
+			method: "/dns.DNSHostnameService/SetHostname",
+			want:   true,
+		},
+		{
+			name:   "claim of another name",
+			id:     &auth.Identity{Method: "jwt", Subject: "ci", Claims: map[string]any{"aud": "hosts:provision"}},
+			method: "/dns.DNSHostnameService/SetHostname",
+		},
+		{
+			name:   "group not bound",
+			id:     &auth.Identity{Method: "jwt", Subject: "dave", Groups: []string{"provisioning"}},
+			method: "/dns.DNSHostnameService/SetHostname",
+		},
+		{
+			name:   "unauthenticated",
+			method: "/dns.DNSHostnameService/GetHostname",
+		},
+		{
+			name:   "unknown method",
+			id:     &auth.Identity{Method: "mtls", Subject: "admin"},
+			method: "/dns.DNSHostnameService/Unknown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := testPolicy.Allowed(tt.id, tt.method); got != tt.want {
+				t.Errorf("Allowed(%v, %s) = %v, want %v", tt.id, tt.method, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllowedAnyone(t *testing.T) {
+	p := &Policy{
+		Roles:    map[string]Role{"reader": {Methods: []string{"/dns.DNSHostnameService/Get*"}}},
+		Bindings: []Binding{{Role: "reader", Subjects: []string{Anyone}}},
+	}
+
+	if !p.Allowed(nil, "/dns.DNSHostnameService/GetHostname") {
+		t.Error("unauthenticated caller denied a method granted to anyone")
+	}
+	if p.Allowed(nil, "/dns.DNSHostnameService/SetHostname") {
+		t.Error("unauthenticated caller allowed a method granted to no one")
+	}
+}
+
+func TestInterceptors(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   *Policy
+		id       *auth.Identity
+		method   string
+		wantCode codes.Code
+	}{
+		{name: "no policy", method: "/dns.AdminService/SetFreeze", wantCode: codes.OK},
+		{
+			name:     "allowed",
+			policy:   testPolicy,
+			id:       &auth.Identity{Method: "mtls", Subject: "admin"},
+			method:   "/dns.AdminService/SetFreeze",
+			wantCode: codes.OK,
+		},
+		{
+			name:     "denied",
+			policy:   testPolicy,
+			id:       &auth.Identity{Method: "jwt", Subject: "carol"},
+			method:   "/dns.AdminService/SetFreeze",
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "unauthenticated",
+			policy:   testPolicy,
+			method:   "/dns.DNSHostnameService/GetHostname",
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "public method",
+			policy:   testPolicy,
+			method:   "/grpc.health.v1.Health/Check",
+			wantCode: codes.OK,
+		},
+		{
+			name:     "empty policy denies",
+			policy:   &Policy{},
+			id:       &auth.Identity{Method: "mtls", Subject: "admin"},
+			method:   "/dns.DNSHostnameService/GetHostname",
+			wantCode: codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAuthorizer(tt.policy)
+			ctx := context.Background()
+			if tt.id != nil {
+				ctx = auth.WithIdentity(ctx, *tt.id)
+			}
+
+			var called bool
+			_, err := a.UnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method},
+				func(context.Context, any) (any, error) {
+					called = true
+					return nil, nil
+				})
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("unary error = %v, want %v", err, tt.wantCode)
+			}
+			if called != (tt.wantCode == codes.OK) {
+				t.Errorf("unary handler called = %v", called)
+			}
+
+			called = false
+			err = a.StreamServerInterceptor()(nil, &testStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: tt.method},
+				func(any, grpc.ServerStream) error {
+					called = true
+					return nil
+				})
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("stream error = %v, want %v", err, tt.wantCode)
+			}
+			if called != (tt.wantCode == codes.OK) {
+				t.Errorf("stream handler called = %v", called)
+			}
+
+			if err := a.Authorize(ctx, tt.method); status.Code(err) != tt.wantCode {
+				t.Errorf("Authorize() error = %v, want %v", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestSetPolicy(t *testing.T) {
+	a := NewAuthorizer(nil)
+	if a.Enabled() {
+		t.Fatal("authorizer without a policy is enabled")
+	}
+
+	a.SetPolicy(&Policy{})
+	if !a.Enabled() {
+		t.Fatal("authorizer with a policy is disabled")
+	}
+	if err := a.Authorize(context.Background(), "/dns.DNSHostnameService/GetHostname"); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Authorize() error = %v, want Unauthenticated", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  string
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			policy: `
+roles:
+  reader:
+    methods: [/dns.DNSHostnameService/Get*]
+bindings:
+  - role: reader
+    subjects: ["*"]
+`,
+		},
+		{
+			name: "unknown role",
+			policy: `
+roles:
+  reader:
+    methods: [/dns.DNSHostnameService/Get*]
+bindings:
+  - role: writer
+    subjects: ["*"]
+`,
+			wantErr: true,
+		},
+		{
+			name: "bad pattern",
+			policy: `
+roles:
+  reader:
+    methods: ["/dns.DNSHostnameService/[Get"]
+`,
+			wantErr: true,
+		},
+		{name: "malformed", policy: "roles: [", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "rbac.yaml")
+			if err := os.WriteFile(file, []byte(tt.policy), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := Load(file)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := Load("../../config/rbac.yaml"); err != nil {
+		t.Errorf("example policy: %v", err)
+	}
+}
+
+type testStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,239 @@
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog"
+
+	"hostManager/internal/config"
+)
+
+// Reloader serves a server TLS configuration built from files on disk and
+// rebuilds it whenever one of the files changes, so certificates can be
+// rotated without a restart.
+type Reloader struct {
+	current atomic.Pointer[tls.Config]
+	watcher *fsnotify.Watcher
+	log     zerolog.Logger
+
+	mu   sync.Mutex
+	cfg  config.TLSConfig
+	dirs map[string]struct{}
+}
+
+func NewReloader(cfg config.TLSConfig, log zerolog.Logger) (*Reloader, error) {
+	const op = "certs.NewReloader"
+
+	r := &Reloader{cfg: cfg, log: log.With().Str("op", "certs.Reloader").Logger()}
+	if err := r.reload(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to create watcher: %w", op, err)
+	}
+
+	r.dirs = watchDirs(cfg)
+	for dir := range r.dirs {
+		if err := watcher.Add(dir); err != nil {
+			_ = watcher.Close()
+			return nil, fmt.Errorf("%s: failed to watch %s: %w", op, dir, err)
+		}
+	}
+
+	r.watcher = watcher
+	go r.watch()
+
+	return r, nil
+}
+
+// SetConfig switches to the files of cfg, from which ServerConfig built
+// tlsCfg.
+func (r *Reloader) SetConfig(cfg config.TLSConfig, tlsCfg *tls.Config) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cfg = cfg
+	r.current.Store(tlsCfg)
+
+	dirs := watchDirs(cfg)
+	for dir := range dirs {
+		if _, ok := r.dirs[dir]; ok {
+			continue
+		}
+		if err := r.watcher.Add(dir); err != nil {
+			r.log.Error().Err(err).Str("dir", dir).Msg("Failed to watch TLS certificates, rotations will not be picked up")
+		}
+	}
+	for dir := range r.dirs {
+		if _, ok := dirs[dir]; !ok {
+			_ = r.watcher.Remove(dir)
+		}
+	}
+	r.dirs = dirs
+}
+
+// watchDirs returns the directories of the files of cfg. They are watched
+// rather than the files, as rotation tools usually replace files by renaming,
+// which drops watches on the old inode.
+func watchDirs(cfg config.TLSConfig) map[string]struct{} {
+	dirs := make(map[string]struct{})
+	for _, file := range []string{cfg.CertFile, cfg.KeyFile, cfg.ClientCAFile} {
+		if file != "" {
+			dirs[filepath.Dir(file)] = struct{}{}
+		}
+	}
+
+	return dirs
+}
+
+// TLSConfig returns a server configuration that always uses the latest
+// certificates and offers nextProtos, if any, through ALPN.
+func (r *Reloader) TLSConfig(nextProtos ...string) *tls.Config {
+	return &tls.Config{
+		NextProtos: nextProtos,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			current := r.current.Load()
+			if len(nextProtos) == 0 {
+				return current, nil
+			}
+
+			current = current.Clone()
+			current.NextProtos = nextProtos
+			return current, nil
+		},
+	}
+}
+
+func (r *Reloader) Close() error {
+	return r.watcher.Close()
+}
+
+func (r *Reloader) watch() {
+	for {
+		select {
+		case event, ok := <-r.watcher.Events:
+			if !ok {
+				return
+			}
+			if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) && !event.Has(fsnotify.Rename) {
+				continue
+			}
+			if err := r.reload(); err != nil {
+				r.log.Error().Err(err).Msg("Failed to reload TLS certificates, keeping the previous ones")
+				continue
+			}
+			r.log.Info().Str("file", event.Name).Msg("TLS certificates reloaded")
+		case err, ok := <-r.watcher.Errors:
+			if !ok {
+				return
+			}
+			r.log.Error().Err(err).Msg("TLS certificate watcher failed")
+		}
+	}
+}
+
+func (r *Reloader) reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tlsCfg, err := ServerConfig(r.cfg)
+	if err != nil {
+		return err
+	}
+
+	r.current.Store(tlsCfg)
+	return nil
+}
+
+// ServerConfig builds a server TLS configuration from files. Client
+// certificates are required and verified when a client CA is configured.
+func ServerConfig(cfg config.TLSConfig) (*tls.Config, error) {
+	minVersion, err := ParseVersion(cfg.MinVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load key pair: %w", err)
+	}
+
+	tlsCfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   minVersion,
+	}
+
+	if cfg.ClientCAFile != "" {
+		pool, err := loadPool(cfg.ClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsCfg.ClientCAs = pool
+		tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsCfg, nil
+}
+
+// ClientConfig builds a client TLS configuration from files. Without a CA
+// file the system roots are used.
+func ClientConfig(cfg config.ClientTLSConfig) (*tls.Config, error) {
+	tlsCfg := &tls.Config{
+		ServerName: cfg.ServerName,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if cfg.CAFile != "" {
+		pool, err := loadPool(cfg.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsCfg.RootCAs = pool
+	}
+
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load key pair: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsCfg, nil
+}
+
+// ParseVersion converts a version such as "1.2" into its crypto/tls constant.
+func ParseVersion(version string) (uint16, error) {
+	switch version {
+	case "", "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("unsupported TLS version %q", version)
+	}
+}
+
+func loadPool(file string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, errors.New("no certificates found in " + file)
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,277 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"hostManager/internal/config"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// newCert issues a certificate for name, signed by parent or self-signed if
+// parent is nil.
+func newCert(t *testing.T, name string, serial int64, parent *testCert) *testCert {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testCert{cert: cert, key: key}
+}
+
+// write stores the certificate and key as name.crt and name.key in dir,
+// replacing existing files by renaming like rotation tools do.
+func (c *testCert) write(t *testing.T, dir, name string) (certFile, keyFile string) {
+	t.Helper()
+
+	keyDER, err := x509.MarshalECPrivateKey(c.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile = filepath.Join(dir, name+".crt")
+	keyFile = filepath.Join(dir, name+".key")
+	writeRenamed(t, keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	writeRenamed(t, certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw}))
+
+	return certFile, keyFile
+}
+
+func writeRenamed(t *testing.T, path string, data []byte) {
+	t.Helper()
+
+	tmp := filepath.Join(t.TempDir(), filepath.Base(path))
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// handshake connects a client with clientCfg to a server with serverCfg over
+// loopback TCP. It returns the certificate the server presented and the error
+// of either side.
+func handshake(t *testing.T, serverCfg, clientCfg *tls.Config) (*x509.Certificate, error) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			serverErr <- err
+			return
+		}
+		defer conn.Close()
+
+		serverErr <- tls.Server(conn, serverCfg).Handshake()
+	}()
+
+	conn, err := tls.Dial("tcp", ln.Addr().String(), clientCfg)
+	if err != nil {
+		<-serverErr
+		return nil, err
+	}
+	// With TLS 1.3 the client finishes before the server has checked its
+	// certificate, so the server's verdict decides.
+	peer := conn.ConnectionState().PeerCertificates[0]
+	if err := <-serverErr; err != nil {
+		conn.Close()
+		return nil, err
+	}
+	conn.Close()
+
+	return peer, nil
+}
+
+func TestReloaderHotReload(t *testing.T) {
+	dir := t.TempDir()
+	ca := newCert(t, "ca", 1, nil)
+	certFile, keyFile := newCert(t, "server", 10, ca).write(t, dir, "server")
+
+	r, err := NewReloader(config.TLSConfig{CertFile: certFile, KeyFile: keyFile}, zerolog.Nop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	clientCfg := &tls.Config{RootCAs: roots, ServerName: "server"}
+
+	served := func() int64 {
+		t.Helper()
+
+		cert, err := handshake(t, r.TLSConfig(), clientCfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cert.SerialNumber.Int64()
+	}
+
+	if got := served(); got != 10 {
+		t.Fatalf("served serial %d, want 10", got)
+	}
+
+	newCert(t, "server", 11, ca).write(t, dir, "server")
+	deadline := time.Now().Add(5 * time.Second)
+	for served() != 11 {
+		if time.Now().After(deadline) {
+			t.Fatal("rotated certificate was not picked up")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// A broken key pair keeps the previous certificate.
+	writeRenamed(t, keyFile, []byte("not a key"))
+	time.Sleep(100 * time.Millisecond)
+	if got := served(); got != 11 {
+		t.Errorf("served serial %d after a failed reload, want 11", got)
+	}
+}
+
+func TestReloaderALPN(t *testing.T) {
+	dir := t.TempDir()
+	ca := newCert(t, "ca", 1, nil)
+	certFile, keyFile := newCert(t, "server", 10, ca).write(t, dir, "server")
+
+	r, err := NewReloader(config.TLSConfig{CertFile: certFile, KeyFile: keyFile}, zerolog.Nop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	cfg, err := r.TLSConfig("h2").GetConfigForClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.NextProtos) != 1 || cfg.NextProtos[0] != "h2" {
+		t.Errorf("NextProtos = %v, want [h2]", cfg.NextProtos)
+	}
+	if plain, _ := r.TLSConfig().GetConfigForClient(nil); len(plain.NextProtos) != 0 {
+		t.Errorf("NextProtos leaked into the current config: %v", plain.NextProtos)
+	}
+}
+
+func TestClientCAVerification(t *testing.T) {
+	dir := t.TempDir()
+	ca := newCert(t, "ca", 1, nil)
+	otherCA := newCert(t, "other-ca", 2, nil)
+
+	certFile, keyFile := newCert(t, "server", 10, ca).write(t, dir, "server")
+	caFile, _ := ca.write(t, dir, "ca")
+	serverCfg, err := ServerConfig(config.TLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: caFile, MinVersion: "1.3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientCertFile, clientKeyFile := newCert(t, "alice", 20, ca).write(t, dir, "alice")
+	strangerCertFile, strangerKeyFile := newCert(t, "mallory", 21, otherCA).write(t, dir, "mallory")
+
+	tests := []struct {
+		name    string
+		client  config.ClientTLSConfig
+		wantErr bool
+	}{
+		{
+			name:   "certificate of the client CA",
+			client: config.ClientTLSConfig{CAFile: caFile, CertFile: clientCertFile, KeyFile: clientKeyFile, ServerName: "server"},
+		},
+		{
+			name:    "certificate of another CA",
+			client:  config.ClientTLSConfig{CAFile: caFile, CertFile: strangerCertFile, KeyFile: strangerKeyFile, ServerName: "server"},
+			wantErr: true,
+		},
+		{
+			name:    "no certificate",
+			client:  config.ClientTLSConfig{CAFile: caFile, ServerName: "server"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientCfg, err := ClientConfig(tt.client)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = handshake(t, serverCfg, clientCfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("handshake error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    uint16
+		wantErr bool
+	}{
+		{version: "", want: tls.VersionTLS12},
+		{version: "1.2", want: tls.VersionTLS12},
+		{version: "1.3", want: tls.VersionTLS13},
+		{version: "1.1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseVersion(tt.version)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseVersion(%q) = %v, %v, want %v, wantErr %v", tt.version, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
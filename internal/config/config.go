@@ -5,35 +5,251 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
 type Config struct {
-	GRPCConfig   GRPCConfig   `yaml:"grpc" env-required:"true"`
-	HTTPConfig   HTTPConfig   `yaml:"http" env-required:"true"`
-	BackupConfig BackupConfig `yaml:"backup"`
-	LogConfig    LogConfig    `yaml:"log" env-required:"true"`
+	GRPCConfig      GRPCConfig      `yaml:"grpc"`
+	HTTPConfig      HTTPConfig      `yaml:"http"`
+	MuxConfig       MuxConfig       `yaml:"mux"`
+	HostFilesConfig HostFilesConfig `yaml:"host_files"`
+	BackupConfig    BackupConfig    `yaml:"backup"`
+	StateConfig     StateConfig     `yaml:"state"`
+	AuditConfig     AuditConfig     `yaml:"audit"`
+	FreezeConfig    FreezeConfig    `yaml:"freeze"`
+	AuthzConfig     AuthzConfig     `yaml:"authz"`
+	AdmissionConfig AdmissionConfig `yaml:"admission"`
+	ApprovalConfig  ApprovalConfig  `yaml:"approval"`
+	EventsConfig    EventsConfig    `yaml:"events"`
+	RateLimitConfig RateLimitConfig `yaml:"rate_limit"`
+	JWTConfig       JWTConfig       `yaml:"jwt"`
+	TracingConfig   TracingConfig   `yaml:"tracing"`
+	LogConfig       LogConfig       `yaml:"log"`
+	ReloadConfig    ReloadConfig    `yaml:"reload"`
+
+	// path is the file the config was read from.
+	path string
 }
 
 type HTTPConfig struct {
-	Port               string `yaml:"port" env-required:"true"`
-	GRPCServerEndpoint string `yaml:"grpc_server_endpoint" env-required:"true"`
+	Port string `yaml:"port"`
+	// GRPCServerEndpoint is the gRPC server the gateway dials. Empty connects
+	// the gateway to the server in-process, through the same interceptors.
+	GRPCServerEndpoint string `yaml:"grpc_server_endpoint"`
+	// GRPCClientTLS is used by the gateway to dial a gRPC server that requires TLS.
+	// With a client certificate, REST calls must carry a bearer token.
+	GRPCClientTLS ClientTLSConfig `yaml:"grpc_client_tls"`
+	// MaxBodyBytes limits the size of REST request bodies.
+	MaxBodyBytes int64      `yaml:"max_body_bytes" env-default:"1048576"`
+	CORS         CORSConfig `yaml:"cors"`
+}
+
+// CORSConfig lets browser-based tools served from AllowedOrigins call the
+// REST API, gRPC-Web and Connect. Origins must match exactly; "*" allows any origin,
+// but not together with AllowCredentials.
+type CORSConfig struct {
+	AllowedOrigins   []string      `yaml:"allowed_origins"`
+	AllowCredentials bool          `yaml:"allow_credentials"`
+	MaxAge           time.Duration `yaml:"max_age" env-default:"10m"`
+}
+
+// OriginAllowed reports whether browsers on origin may call the API.
+func (c CORSConfig) OriginAllowed(origin string) bool {
+	for _, allowed := range c.AllowedOrigins {
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+	}
+
+	return false
 }
 
 type GRPCConfig struct {
-	Port int `yaml:"port" env-required:"true"`
+	Port int              `yaml:"port"`
+	TLS  TLSConfig        `yaml:"tls"`
+	Unix UnixSocketConfig `yaml:"unix"`
+}
+
+// MuxConfig serves gRPC, gRPC-Web, Connect and the REST API together on one
+// TLS port when Port is set, in place of the separate grpc and http ports. The
+// Unix socket is still served.
+type MuxConfig struct {
+	Port string    `yaml:"port"`
+	TLS  TLSConfig `yaml:"tls"`
+}
+
+// UnixSocketConfig makes the gRPC server also listen on a Unix socket when
+// Path is set. Owner and Group accept names or numeric ids; empty keeps the
+// ones of the server process.
+type UnixSocketConfig struct {
+	Path  string `yaml:"path"`
+	Owner string `yaml:"owner"`
+	Group string `yaml:"group"`
+	Mode  string `yaml:"mode" env-default:"0660"`
+}
+
+// TLSConfig enables TLS when CertFile is set, and mutual TLS when ClientCAFile
+// is set as well. The files are reloaded when they change on disk.
+type TLSConfig struct {
+	CertFile     string `yaml:"cert_file"`
+	KeyFile      string `yaml:"key_file"`
+	ClientCAFile string `yaml:"client_ca_file"`
+	MinVersion   string `yaml:"min_version" env-default:"1.2"`
+}
+
+// validate rejects key and client CA files without a certificate, which would
+// otherwise leave the server on plaintext despite the intent to use TLS.
+func (c TLSConfig) validate() error {
+	if c.CertFile == "" && (c.KeyFile != "" || c.ClientCAFile != "") {
+		return errors.New("key_file and client_ca_file require cert_file")
+	}
+
+	return nil
+}
+
+// ClientTLSConfig enables TLS towards a server when CAFile or CertFile is set.
+type ClientTLSConfig struct {
+	CAFile     string `yaml:"ca_file"`
+	CertFile   string `yaml:"cert_file"`
+	KeyFile    string `yaml:"key_file"`
+	ServerName string `yaml:"server_name"`
+}
+
+// HostFilesConfig points to the files the server manages, for hosts whose
+// configuration lives outside /etc, such as containers with the host's /etc
+// mounted elsewhere.
+type HostFilesConfig struct {
+	Hostname   string `yaml:"hostname" env-default:"/etc/hostname"`
+	ResolvConf string `yaml:"resolv_conf" env-default:"/etc/resolv.conf"`
+	Hosts      string `yaml:"hosts" env-default:"/etc/hosts"`
 }
 
 type BackupConfig struct {
 	BackupHostnameFilePath string `yaml:"backup_hostname_file_path" env-default:"/etc/backup/hostname/"`
 	BackupDNSFilePath      string `yaml:"backup_dns_file_path" env-default:"/etc/backup/dns/"`
+	BackupHostsFilePath    string `yaml:"backup_hosts_file_path" env-default:"/etc/backup/hosts/"`
+}
+
+// StateConfig points to the directory where the server persists pending work
+// such as DNS server expiries.
+type StateConfig struct {
+	Dir string `yaml:"dir" env-default:"/var/lib/host-manager/"`
+}
+
+type AuditConfig struct {
+	Path string `yaml:"path" env-default:"/var/log/host-manager/audit.json"`
+}
+
+// FreezeConfig rejects all mutations from startup, e.g. during a change freeze.
+// The freeze can also be set and lifted at runtime through the admin API.
+type FreezeConfig struct {
+	Enabled bool      `yaml:"enabled"`
+	Reason  string    `yaml:"reason" env-default:"change freeze"`
+	Until   time.Time `yaml:"until"`
+}
+
+// AuthzConfig points to the RBAC policy file. Without one every caller may
+// call every RPC.
+type AuthzConfig struct {
+	PolicyFile string `yaml:"policy_file"`
+}
+
+// AdmissionConfig points to the CEL rules every change must pass. Without one
+// only RBAC applies.
+type AdmissionConfig struct {
+	PolicyFile string `yaml:"policy_file"`
+}
+
+// ApprovalConfig sets how long a change waits for approval before it expires.
+type ApprovalConfig struct {
+	TTL time.Duration `yaml:"ttl" env-default:"24h"`
+}
+
+// EventsConfig sets how many change events are kept for clients resuming a
+// change stream.
+type EventsConfig struct {
+	BufferSize int `yaml:"buffer_size" env-default:"256"`
+}
+
+// RateLimitConfig limits each client, keyed by identity or peer address, to
+// the given requests per second with bursts, separately for read and write
+// RPCs. Zero rates and caps disable the respective limit.
+type RateLimitConfig struct {
+	ReadRate             float64 `yaml:"read_rate"`
+	ReadBurst            int     `yaml:"read_burst"`
+	WriteRate            float64 `yaml:"write_rate"`
+	WriteBurst           int     `yaml:"write_burst"`
+	MaxInFlightMutations int     `yaml:"max_in_flight_mutations"`
+}
+
+// JWTConfig enables bearer token authentication when a JWKS file or URL is set.
+// Issuer and Audience are then required.
+type JWTConfig struct {
+	JWKSFile        string        `yaml:"jwks_file"`
+	JWKSURL         string        `yaml:"jwks_url"`
+	Issuer          string        `yaml:"issuer"`
+	Audience        string        `yaml:"audience"`
+	GroupsClaim     string        `yaml:"groups_claim" env-default:"groups"`
+	RefreshInterval time.Duration `yaml:"refresh_interval" env-default:"1h"`
 }
 
+// TracingConfig enables OpenTelemetry tracing. Exporter is otlp, stdout or
+// file; empty disables exporting while trace context is still propagated.
+type TracingConfig struct {
+	Exporter    string  `yaml:"exporter"`
+	Endpoint    string  `yaml:"endpoint" env-default:"localhost:4317"`
+	Insecure    bool    `yaml:"insecure"`
+	File        string  `yaml:"file" env-default:"./traces.json"`
+	ServiceName string  `yaml:"service_name" env-default:"host-manager"`
+	SampleRatio float64 `yaml:"sample_ratio" env-default:"1"`
+}
+
+// LogConfig sends log entries to Sinks. Level applies to sinks without a level
+// of their own and can be changed at runtime through the admin API. Without
+// sinks, entries go to the file at Path, or to stdout as JSON if Path is empty.
 type LogConfig struct {
 	Level      string           `yaml:"level" env-default:"INFO"`
-	Path       string           `yaml:"path" env-required:"true"`
-	Lumberjack LumberjackConfig `yaml:"lumberjack" env-required:"true"`
+	Sinks      []LogSinkConfig  `yaml:"sinks"`
+	Path       string           `yaml:"path"`
+	Lumberjack LumberjackConfig `yaml:"lumberjack"`
+}
+
+// LogSinkConfig is one destination for log entries. Type is console, file,
+// journald or syslog; the remaining fields apply to the types noted.
+type LogSinkConfig struct {
+	Type  string `yaml:"type"`
+	Level string `yaml:"level"`
+
+	// Console: Format is json or pretty, Output stdout or stderr. Defaults to
+	// JSON on stdout.
+	Format string `yaml:"format"`
+	Output string `yaml:"output"`
+
+	// File: rotated with lumberjack.
+	Path       string           `yaml:"path"`
+	Lumberjack LumberjackConfig `yaml:"lumberjack"`
+
+	// Journald: Socket defaults to /run/systemd/journal/socket.
+	Socket string `yaml:"socket"`
+
+	// Syslog: Network is unix, unixgram, udp or tcp to send to Address, empty
+	// for the local syslog daemon. Tag, also used by journald, defaults to
+	// host-manager and Facility to daemon.
+	Network  string `yaml:"network"`
+	Address  string `yaml:"address"`
+	Tag      string `yaml:"tag"`
+	Facility string `yaml:"facility"`
+}
+
+// ReloadConfig makes the server reload its config file whenever it changes,
+// not only on SIGHUP.
+type ReloadConfig struct {
+	WatchFile bool `yaml:"watch_file"`
 }
 
 type LumberjackConfig struct {
@@ -50,6 +266,20 @@ func Load() (*Config, error) {
 		return nil, errors.New("config file not exist")
 	}
 
+	return read(path)
+}
+
+// Path returns the file the config was read from.
+func (c *Config) Path() string {
+	return c.path
+}
+
+// Reload reads the file the config was read from again.
+func (c *Config) Reload() (*Config, error) {
+	return read(c.path)
+}
+
+func read(path string) (*Config, error) {
 	if _, err := os.Stat(path); os.IsNotExist(err) {
 		return nil, fmt.Errorf("config path does not exist: %s", path)
 	}
@@ -60,9 +290,59 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("failed to read config: %w", err)
 	}
 
+	if cfg.MuxConfig.Port == "" && (cfg.GRPCConfig.Port == 0 || cfg.HTTPConfig.Port == "") {
+		return nil, errors.New("grpc.port and http.port are required unless mux.port is set")
+	}
+	if cfg.MuxConfig.Port != "" && cfg.MuxConfig.TLS.CertFile == "" {
+		return nil, errors.New("mux.tls is required with mux.port")
+	}
+	if cfg.HTTPConfig.CORS.AllowCredentials && slices.Contains(cfg.HTTPConfig.CORS.AllowedOrigins, "*") {
+		return nil, errors.New(`http.cors: allow_credentials cannot be used with the "*" origin`)
+	}
+	if err := cfg.GRPCConfig.TLS.validate(); err != nil {
+		return nil, fmt.Errorf("grpc.tls: %w", err)
+	}
+	if err := cfg.MuxConfig.TLS.validate(); err != nil {
+		return nil, fmt.Errorf("mux.tls: %w", err)
+	}
+	cfg.path = path
+
 	return &cfg, nil
 }
 
+// Diff returns the fields that differ between a and b, named by their YAML
+// paths such as "grpc.tls.cert_file".
+func Diff(a, b *Config) []string {
+	return diff("", reflect.ValueOf(*a), reflect.ValueOf(*b))
+}
+
+func diff(prefix string, a, b reflect.Value) []string {
+	var fields []string
+	for i := 0; i < a.NumField(); i++ {
+		field := a.Type().Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		name = prefix + name
+
+		// Recurse into the sections of this package; anything else, like
+		// lists and times, is compared as a whole.
+		if field.Type.Kind() == reflect.Struct && field.Type.PkgPath() == a.Type().PkgPath() {
+			fields = append(fields, diff(name+".", a.Field(i), b.Field(i))...)
+			continue
+		}
+		if !reflect.DeepEqual(a.Field(i).Interface(), b.Field(i).Interface()) {
+			fields = append(fields, name)
+		}
+	}
+
+	return fields
+}
+
 func fetchConfig() string {
 	var res string
 
@@ -0,0 +1,23 @@
+// Package fsutil holds the file helpers shared by the packages that persist
+// state.
+package fsutil
+
+import (
+	"fmt"
+	"os"
+)
+
+// WriteFileAtomic replaces path with data so that readers never see a partial
+// file. The file is only readable by its owner.
+func WriteFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", tmp, err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to rename %s: %w", tmp, err)
+	}
+
+	return nil
+}
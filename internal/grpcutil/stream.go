@@ -0,0 +1,22 @@
+package grpcutil
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// WithContext returns ss with its context replaced by ctx, so stream
+// interceptors can pass values on to the handler.
+func WithContext(ss grpc.ServerStream, ctx context.Context) grpc.ServerStream {
+	return &serverStream{ServerStream: ss, ctx: ctx}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,137 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+// journalWriter sends entries to journald over its native protocol, one
+// datagram per entry, with the JSON fields as journal fields. Entries larger
+// than the socket accepts in one datagram fail to be written.
+type journalWriter struct {
+	conn *net.UnixConn
+	tag  string
+}
+
+func dialJournal(socket, tag string) (*journalWriter, error) {
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to journald: %w", err)
+	}
+
+	return &journalWriter{conn: conn, tag: tag}, nil
+}
+
+func (j *journalWriter) Write(p []byte) (int, error) {
+	return j.WriteLevel(zerolog.NoLevel, p)
+}
+
+func (j *journalWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	var buf bytes.Buffer
+	writeJournalField(&buf, "PRIORITY", journalPriority(level))
+	writeJournalField(&buf, "SYSLOG_IDENTIFIER", j.tag)
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(p, &fields); err != nil {
+		writeJournalField(&buf, "MESSAGE", string(bytes.TrimSpace(p)))
+	} else {
+		writeJournalField(&buf, "MESSAGE", fieldString(fields[zerolog.MessageFieldName]))
+
+		keys := make([]string, 0, len(fields))
+		for key := range fields {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+
+		for _, key := range keys {
+			// The journal has its own level and timestamp.
+			switch key {
+			case zerolog.MessageFieldName, zerolog.LevelFieldName, zerolog.TimestampFieldName:
+				continue
+			}
+			if name := journalFieldName(key); name != "" {
+				writeJournalField(&buf, name, fieldString(fields[key]))
+			}
+		}
+	}
+
+	if _, err := j.conn.Write(buf.Bytes()); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+func (j *journalWriter) Close() error {
+	return j.conn.Close()
+}
+
+// writeJournalField writes NAME=value, or the length-prefixed form for values
+// spanning lines.
+func writeJournalField(buf *bytes.Buffer, name, value string) {
+	buf.WriteString(name)
+	if !strings.Contains(value, "\n") {
+		buf.WriteByte('=')
+		buf.WriteString(value)
+		buf.WriteByte('\n')
+		return
+	}
+
+	buf.WriteByte('\n')
+	_ = binary.Write(buf, binary.LittleEndian, uint64(len(value)))
+	buf.WriteString(value)
+	buf.WriteByte('\n')
+}
+
+// journalFieldName maps a JSON key to a journal field name, which may only
+// hold upper case letters, digits and underscores and must not start with an
+// underscore or a digit.
+func journalFieldName(key string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+	name = strings.TrimLeft(name, "_0123456789")
+
+	return name[:min(len(name), 64)]
+}
+
+// fieldString returns JSON strings unquoted and other values as JSON.
+func fieldString(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+
+	return string(raw)
+}
+
+func journalPriority(level zerolog.Level) string {
+	switch level {
+	case zerolog.TraceLevel, zerolog.DebugLevel:
+		return "7"
+	case zerolog.WarnLevel:
+		return "4"
+	case zerolog.ErrorLevel:
+		return "3"
+	case zerolog.FatalLevel:
+		return "2"
+	case zerolog.PanicLevel:
+		return "0"
+	default:
+		return "6"
+	}
+}
@@ -0,0 +1,284 @@
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// tailBuffer is how many entries a tail may fall behind before it is dropped.
+const tailBuffer = 256
+
+// Entry is a log entry as written, a JSON object.
+type Entry struct {
+	Level zerolog.Level
+	Data  []byte
+}
+
+// State is the current log level and its override, if any.
+type State struct {
+	Level        zerolog.Level
+	DefaultLevel zerolog.Level
+	// RevertAt is when Level falls back to DefaultLevel, zero for never.
+	RevertAt time.Time
+}
+
+// Logs writes entries to its sinks, lets the log level change at runtime and
+// keeps recent entries for tailing. Loggers write to Logs itself and log at
+// every level; Logs sets the global zerolog level to what the sinks and the
+// open tails need.
+type Logs struct {
+	sinksMu sync.RWMutex
+	sinks   []Sink
+
+	mu           sync.Mutex
+	defaultLevel zerolog.Level
+	level        zerolog.Level
+	revertAt     time.Time
+	revert       *time.Timer
+	// generation counts level changes, so a revert timer that fired just as
+	// the level changed again leaves the new level alone.
+	generation uint64
+	recent     []Entry
+	size       int
+	tails      map[*tail]struct{}
+}
+
+type tail struct {
+	level zerolog.Level
+	ch    chan Entry
+}
+
+// New creates Logs at level writing to sinks and keeping the last size entries.
+func New(level zerolog.Level, size int, sinks []Sink) *Logs {
+	l := &Logs{sinks: sinks, defaultLevel: level, level: level, size: size, tails: make(map[*tail]struct{})}
+	l.updateGlobalLevel()
+
+	return l
+}
+
+// SetLevel changes the log level. A positive ttl reverts it to the default
+// level afterwards.
+func (l *Logs) SetLevel(level zerolog.Level, ttl time.Duration) State {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.revert != nil {
+		l.revert.Stop()
+		l.revert = nil
+	}
+
+	l.generation++
+	l.level = level
+	l.revertAt = time.Time{}
+	if ttl > 0 {
+		generation := l.generation
+		l.revertAt = time.Now().Add(ttl)
+		l.revert = time.AfterFunc(ttl, func() { l.revertLevel(generation) })
+	}
+	l.updateGlobalLevel()
+
+	return l.state()
+}
+
+// Reconfigure replaces the sinks, closing the previous ones, and the default
+// level. A changed default level also replaces the level set at runtime.
+func (l *Logs) Reconfigure(level zerolog.Level, sinks []Sink) error {
+	l.sinksMu.Lock()
+	previous := l.sinks
+	l.sinks = sinks
+	l.sinksMu.Unlock()
+
+	l.mu.Lock()
+	if level != l.defaultLevel {
+		if l.revert != nil {
+			l.revert.Stop()
+			l.revert = nil
+		}
+		l.generation++
+		l.defaultLevel = level
+		l.level = level
+		l.revertAt = time.Time{}
+	}
+	l.updateGlobalLevel()
+	l.mu.Unlock()
+
+	return closeSinks(previous)
+}
+
+// Close flushes and closes the sinks. Entries logged afterwards are dropped.
+func (l *Logs) Close() error {
+	l.mu.Lock()
+	if l.revert != nil {
+		l.revert.Stop()
+		l.revert = nil
+	}
+	l.mu.Unlock()
+
+	l.sinksMu.Lock()
+	sinks := l.sinks
+	l.sinks = nil
+	l.sinksMu.Unlock()
+
+	return closeSinks(sinks)
+}
+
+func (l *Logs) State() State {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.state()
+}
+
+func (l *Logs) state() State {
+	return State{Level: l.level, DefaultLevel: l.defaultLevel, RevertAt: l.revertAt}
+}
+
+func (l *Logs) revertLevel(generation uint64) {
+	l.mu.Lock()
+	if generation != l.generation {
+		l.mu.Unlock()
+		return
+	}
+	level := l.defaultLevel
+	l.level = level
+	l.revertAt = time.Time{}
+	l.revert = nil
+	l.updateGlobalLevel()
+	l.mu.Unlock()
+
+	log.Info().Str("op", "logging.revertLevel").Str("logLevel", level.String()).Msg("Log level reverted")
+}
+
+// Tail returns up to lines recent entries at or above level and, if follow is
+// set, a channel of new ones and a function that ends the tail. The channel
+// is closed when the tail falls too far behind or CloseTails is called.
+func (l *Logs) Tail(level zerolog.Level, lines int, follow bool) ([]Entry, <-chan Entry, func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var recent []Entry
+	for i := len(l.recent) - 1; i >= 0 && len(recent) < lines; i-- {
+		if l.recent[i].Level >= level {
+			recent = append(recent, l.recent[i])
+		}
+	}
+	for i, j := 0, len(recent)-1; i < j; i, j = i+1, j-1 {
+		recent[i], recent[j] = recent[j], recent[i]
+	}
+
+	if !follow {
+		return recent, nil, func() {}
+	}
+
+	t := &tail{level: level, ch: make(chan Entry, tailBuffer)}
+	l.tails[t] = struct{}{}
+	l.updateGlobalLevel()
+
+	cancel := func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		l.removeTail(t)
+	}
+
+	return recent, t.ch, cancel
+}
+
+// CloseTails ends all tails, e.g. before the server stops.
+func (l *Logs) CloseTails() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for t := range l.tails {
+		l.removeTail(t)
+	}
+}
+
+func (l *Logs) removeTail(t *tail) {
+	if _, ok := l.tails[t]; !ok {
+		return
+	}
+
+	delete(l.tails, t)
+	close(t.ch)
+	l.updateGlobalLevel()
+}
+
+// updateGlobalLevel makes zerolog produce entries for the most verbose sink
+// and tail.
+func (l *Logs) updateGlobalLevel() {
+	level := l.level
+	l.sinksMu.RLock()
+	for _, s := range l.sinks {
+		if s.level != nil {
+			level = min(level, *s.level)
+		}
+	}
+	l.sinksMu.RUnlock()
+	for t := range l.tails {
+		level = min(level, t.level)
+	}
+
+	zerolog.SetGlobalLevel(level)
+}
+
+// publish keeps the entry for tails and returns the current log level.
+func (l *Logs) publish(level zerolog.Level, p []byte) zerolog.Level {
+	entry := Entry{Level: level, Data: append([]byte(nil), p...)}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.size > 0 {
+		l.recent = append(l.recent, entry)
+		if len(l.recent) > l.size {
+			l.recent = l.recent[len(l.recent)-l.size:]
+		}
+	}
+
+	for t := range l.tails {
+		if level < t.level {
+			continue
+		}
+		select {
+		case t.ch <- entry:
+		default:
+			l.removeTail(t)
+		}
+	}
+
+	return l.level
+}
+
+func (l *Logs) Write(p []byte) (int, error) {
+	return l.WriteLevel(zerolog.NoLevel, p)
+}
+
+// WriteLevel writes the entry to every sink whose level it meets.
+func (l *Logs) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	logLevel := l.publish(level, p)
+
+	l.sinksMu.RLock()
+	defer l.sinksMu.RUnlock()
+
+	var errs []error
+	for _, s := range l.sinks {
+		threshold := logLevel
+		if s.level != nil {
+			threshold = *s.level
+		}
+		if level < threshold {
+			continue
+		}
+		if _, err := s.w.WriteLevel(level, p); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", s.name, err))
+		}
+	}
+
+	return len(p), errors.Join(errs...)
+}
@@ -0,0 +1,181 @@
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/syslog"
+	"os"
+
+	"github.com/rs/zerolog"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"hostManager/internal/config"
+)
+
+const (
+	defaultJournalSocket = "/run/systemd/journal/socket"
+	defaultTag           = "host-manager"
+)
+
+// Sink is a destination for log entries.
+type Sink struct {
+	name string
+	w    zerolog.LevelWriter
+	// level is nil for sinks following the log level.
+	level  *zerolog.Level
+	closer io.Closer
+}
+
+// OpenSinks opens the sinks listed in cfg, or the file or stdout sink used
+// when none are listed.
+func OpenSinks(cfg config.LogConfig) ([]Sink, error) {
+	sinkConfigs := cfg.Sinks
+	if len(sinkConfigs) == 0 {
+		if cfg.Path != "" {
+			sinkConfigs = []config.LogSinkConfig{{Type: "file", Path: cfg.Path, Lumberjack: cfg.Lumberjack}}
+		} else {
+			sinkConfigs = []config.LogSinkConfig{{Type: "console"}}
+		}
+	}
+
+	sinks := make([]Sink, 0, len(sinkConfigs))
+	for i, sinkConfig := range sinkConfigs {
+		sink, err := openSink(sinkConfig)
+		if err != nil {
+			_ = closeSinks(sinks)
+			return nil, fmt.Errorf("log sink %d (%s): %w", i, sinkConfig.Type, err)
+		}
+		sinks = append(sinks, sink)
+	}
+
+	return sinks, nil
+}
+
+func openSink(cfg config.LogSinkConfig) (Sink, error) {
+	sink := Sink{name: cfg.Type}
+
+	if cfg.Level != "" {
+		level, err := zerolog.ParseLevel(cfg.Level)
+		if err != nil {
+			return Sink{}, fmt.Errorf("invalid level %q", cfg.Level)
+		}
+		sink.level = &level
+	}
+
+	tag := cfg.Tag
+	if tag == "" {
+		tag = defaultTag
+	}
+
+	switch cfg.Type {
+	case "console":
+		out := os.Stdout
+		switch cfg.Output {
+		case "", "stdout":
+		case "stderr":
+			out = os.Stderr
+		default:
+			return Sink{}, fmt.Errorf("invalid output %q", cfg.Output)
+		}
+
+		switch cfg.Format {
+		case "", "json":
+			sink.w = zerolog.LevelWriterAdapter{Writer: out}
+		case "pretty":
+			sink.w = zerolog.LevelWriterAdapter{Writer: zerolog.ConsoleWriter{Out: out}}
+		default:
+			return Sink{}, fmt.Errorf("invalid format %q", cfg.Format)
+		}
+	case "file":
+		if cfg.Path == "" {
+			return Sink{}, errors.New("path is required")
+		}
+		lr := &lumberjack.Logger{
+			Filename:   cfg.Path,
+			MaxSize:    int(cfg.Lumberjack.MaxSize),
+			MaxAge:     int(cfg.Lumberjack.MaxAge),
+			MaxBackups: int(cfg.Lumberjack.MaxBackups),
+			LocalTime:  cfg.Lumberjack.LocalTime,
+			Compress:   cfg.Lumberjack.Compress,
+		}
+		sink.w, sink.closer = zerolog.LevelWriterAdapter{Writer: lr}, lr
+	case "journald":
+		socket := cfg.Socket
+		if socket == "" {
+			socket = defaultJournalSocket
+		}
+		journal, err := dialJournal(socket, tag)
+		if err != nil {
+			return Sink{}, err
+		}
+		sink.w, sink.closer = journal, journal
+	case "syslog":
+		facility, err := syslogFacility(cfg.Facility)
+		if err != nil {
+			return Sink{}, err
+		}
+		if (cfg.Network == "") != (cfg.Address == "") {
+			return Sink{}, errors.New("network and address must be set together")
+		}
+		w, err := syslog.Dial(cfg.Network, cfg.Address, facility|syslog.LOG_INFO, tag)
+		if err != nil {
+			return Sink{}, fmt.Errorf("failed to connect to syslog: %w", err)
+		}
+		sink.w, sink.closer = zerolog.SyslogLevelWriter(w), w
+	default:
+		return Sink{}, fmt.Errorf("unknown sink type %q", cfg.Type)
+	}
+
+	return sink, nil
+}
+
+func closeSinks(sinks []Sink) error {
+	var errs []error
+	for _, sink := range sinks {
+		if sink.closer == nil {
+			continue
+		}
+		if err := sink.closer.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sink.name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+var syslogFacilities = map[string]syslog.Priority{
+	"kern":     syslog.LOG_KERN,
+	"user":     syslog.LOG_USER,
+	"mail":     syslog.LOG_MAIL,
+	"daemon":   syslog.LOG_DAEMON,
+	"auth":     syslog.LOG_AUTH,
+	"syslog":   syslog.LOG_SYSLOG,
+	"lpr":      syslog.LOG_LPR,
+	"news":     syslog.LOG_NEWS,
+	"uucp":     syslog.LOG_UUCP,
+	"cron":     syslog.LOG_CRON,
+	"authpriv": syslog.LOG_AUTHPRIV,
+	"ftp":      syslog.LOG_FTP,
+	"local0":   syslog.LOG_LOCAL0,
+	"local1":   syslog.LOG_LOCAL1,
+	"local2":   syslog.LOG_LOCAL2,
+	"local3":   syslog.LOG_LOCAL3,
+	"local4":   syslog.LOG_LOCAL4,
+	"local5":   syslog.LOG_LOCAL5,
+	"local6":   syslog.LOG_LOCAL6,
+	"local7":   syslog.LOG_LOCAL7,
+}
+
+func syslogFacility(name string) (syslog.Priority, error) {
+	if name == "" {
+		return syslog.LOG_DAEMON, nil
+	}
+
+	facility, ok := syslogFacilities[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown syslog facility %q", name)
+	}
+
+	return facility, nil
+}
@@ -0,0 +1,208 @@
+package metrics
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const namespace = "host_manager"
+
+var (
+	requests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grpc_requests_total",
+		Help:      "gRPC requests handled, by method and status code.",
+	}, []string{"method", "code"})
+
+	requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "grpc_request_duration_seconds",
+		Help:      "gRPC request latency, by method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	mutations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "mutations_total",
+		Help:      "Changes applied to the host, by action.",
+	}, []string{"action"})
+
+	rollbacks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rollbacks_total",
+		Help:      "Rollbacks of unconfirmed changes and of changes that failed part way, by resource and result.",
+	}, []string{"resource", "result"})
+
+	backups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "backups_total",
+		Help:      "Backup files created, by resource.",
+	}, []string{"resource"})
+
+	expiries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "dns_server_expiries_total",
+		Help:      "Attempts to remove expired temporary DNS servers, by result.",
+	}, []string{"result"})
+
+	drifts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "drift_events_total",
+		Help:      "Changes of host files made outside the server, by resource.",
+	}, []string{"resource"})
+)
+
+// MutationApplied counts a change applied to the host.
+func MutationApplied(action string) {
+	mutations.WithLabelValues(action).Inc()
+}
+
+// RollbackDone counts a rollback, labelled failure when err is set.
+func RollbackDone(resource string, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	rollbacks.WithLabelValues(resource, result).Inc()
+}
+
+// RollbackSkipped counts a rollback left out because the file changed again
+// after the unconfirmed change.
+func RollbackSkipped(resource string) {
+	rollbacks.WithLabelValues(resource, "skipped").Inc()
+}
+
+// ExpiryDone counts an attempt to remove an expired DNS server, labelled
+// failure when err is set.
+func ExpiryDone(err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	expiries.WithLabelValues(result).Inc()
+}
+
+// BackupCreated counts a backup file written before a change.
+func BackupCreated(resource string) {
+	backups.WithLabelValues(resource).Inc()
+}
+
+// DriftDetected counts a change of a host file the server did not make.
+func DriftDetected(resource string) {
+	drifts.WithLabelValues(resource).Inc()
+}
+
+// UnaryServerInterceptor records the count and latency of every call.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		observe(info.FullMethod, start, err)
+
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of UnaryServerInterceptor.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		observe(info.FullMethod, start, err)
+
+		return err
+	}
+}
+
+func observe(method string, start time.Time, err error) {
+	code := status.Code(err).String()
+	requests.WithLabelValues(method, code).Inc()
+	requestDuration.WithLabelValues(method, code).Observe(time.Since(start).Seconds())
+}
+
+// HostState is the part of the host manager the host collector reads.
+type HostState interface {
+	GetHostname(ctx context.Context) (hostname, version string, err error)
+	ListDNSServers(ctx context.Context) (servers []string, version string, err error)
+}
+
+// hostCollector reports the current host configuration and the backups on
+// disk at scrape time.
+type hostCollector struct {
+	host       HostState
+	backupDirs func() map[string]string
+
+	hostname    *prometheus.Desc
+	dnsServers  *prometheus.Desc
+	backupFiles *prometheus.Desc
+	backupBytes *prometheus.Desc
+}
+
+// RegisterHostCollector registers gauges for the hostname, the nameserver
+// count and the backups in the directories backupDirs returns by resource.
+func RegisterHostCollector(host HostState, backupDirs func() map[string]string) error {
+	return prometheus.Register(&hostCollector{
+		host:       host,
+		backupDirs: backupDirs,
+		hostname: prometheus.NewDesc(namespace+"_hostname_info",
+			"Current hostname as a label, always 1.", []string{"hostname"}, nil),
+		dnsServers: prometheus.NewDesc(namespace+"_dns_servers",
+			"Number of nameservers in resolv.conf.", nil, nil),
+		backupFiles: prometheus.NewDesc(namespace+"_backup_files",
+			"Backup files on disk, by resource.", []string{"resource"}, nil),
+		backupBytes: prometheus.NewDesc(namespace+"_backup_bytes",
+			"Disk space used by backup files, by resource.", []string{"resource"}, nil),
+	})
+}
+
+func (c *hostCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.hostname
+	ch <- c.dnsServers
+	ch <- c.backupFiles
+	ch <- c.backupBytes
+}
+
+func (c *hostCollector) Collect(ch chan<- prometheus.Metric) {
+	const op = "metrics.Collect"
+	ctx := context.Background()
+
+	if hostname, _, err := c.host.GetHostname(ctx); err == nil {
+		ch <- prometheus.MustNewConstMetric(c.hostname, prometheus.GaugeValue, 1, hostname)
+	} else {
+		log.Warn().Str("op", op).Err(err).Msg("Failed to read hostname")
+	}
+
+	if servers, _, err := c.host.ListDNSServers(ctx); err == nil {
+		ch <- prometheus.MustNewConstMetric(c.dnsServers, prometheus.GaugeValue, float64(len(servers)))
+	} else {
+		log.Warn().Str("op", op).Err(err).Msg("Failed to list DNS servers")
+	}
+
+	for resource, dir := range c.backupDirs() {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			log.Warn().Str("op", op).Err(err).Str("dir", dir).Msg("Failed to read backup dir")
+			continue
+		}
+
+		var files, size float64
+		for _, entry := range entries {
+			info, err := entry.Info()
+			if err != nil || !info.Mode().IsRegular() {
+				continue
+			}
+			files++
+			size += float64(info.Size())
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.backupFiles, prometheus.GaugeValue, files, resource)
+		ch <- prometheus.MustNewConstMetric(c.backupBytes, prometheus.GaugeValue, size, resource)
+	}
+}
@@ -0,0 +1,258 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"hostManager/internal/auth"
+	"hostManager/internal/config"
+)
+
+// RetryAfterKey is the metadata key that tells a rejected caller how many
+// seconds to wait. The REST gateway turns it into a Retry-After header.
+const RetryAfterKey = "retry-after"
+
+// idleTimeout is how long an unused client bucket is kept.
+const idleTimeout = 10 * time.Minute
+
+// Limiter enforces per-client token buckets, with separate budgets for read
+// and write RPCs, and a server-wide cap on mutations in flight.
+type Limiter struct {
+	mu        sync.Mutex
+	cfg       config.RateLimitConfig
+	inFlight  int
+	clients   map[string]*client
+	lastSweep time.Time
+}
+
+type client struct {
+	read     *rate.Limiter
+	write    *rate.Limiter
+	lastSeen time.Time
+}
+
+func New(cfg config.RateLimitConfig) *Limiter {
+	l := &Limiter{}
+	l.SetConfig(cfg)
+
+	return l
+}
+
+// SetConfig replaces the limits. Clients keep the tokens they have left, up
+// to the new burst, and mutations already in flight count towards the new cap.
+func (l *Limiter) SetConfig(cfg config.RateLimitConfig) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.clients == nil {
+		l.clients = make(map[string]*client)
+		l.lastSweep = time.Now()
+	}
+	if cfg == l.cfg {
+		return
+	}
+
+	l.cfg = cfg
+	for _, c := range l.clients {
+		c.read = updateBucket(c.read, cfg.ReadRate, cfg.ReadBurst)
+		c.write = updateBucket(c.write, cfg.WriteRate, cfg.WriteBurst)
+	}
+}
+
+// UnaryServerInterceptor rejects calls over budget with ResourceExhausted. It
+// must run after the interceptors that authenticate the caller.
+func (l *Limiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		release, err := l.acquire(ctx, info.FullMethod, grpc.SetHeader)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of UnaryServerInterceptor.
+func (l *Limiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		setHeader := func(_ context.Context, md metadata.MD) error { return ss.SetHeader(md) }
+		release, err := l.acquire(ss.Context(), info.FullMethod, setHeader)
+		if err != nil {
+			return err
+		}
+		defer release()
+
+		return handler(srv, ss)
+	}
+}
+
+func (l *Limiter) acquire(ctx context.Context, fullMethod string, setHeader func(context.Context, metadata.MD) error) (func(), error) {
+	write := !isRead(fullMethod)
+
+	delay, ok := l.allow(clientKey(ctx), write)
+	if !ok {
+		return nil, exhausted(ctx, setHeader, delay, "rate limit exceeded for %s", fullMethod)
+	}
+
+	if !write {
+		return func() {}, nil
+	}
+	if !l.enter() {
+		return nil, exhausted(ctx, setHeader, time.Second, "too many changes in flight")
+	}
+
+	return l.leave, nil
+}
+
+// enter counts a mutation in flight, unless the cap is reached.
+func (l *Limiter) enter() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.cfg.MaxInFlightMutations > 0 && l.inFlight >= l.cfg.MaxInFlightMutations {
+		return false
+	}
+	l.inFlight++
+
+	return true
+}
+
+func (l *Limiter) leave() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inFlight--
+}
+
+// allow takes a token from the caller's bucket, or returns how long until one
+// is available.
+func (l *Limiter) allow(key string, write bool) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.cfg == (config.RateLimitConfig{}) {
+		return 0, true
+	}
+
+	now := time.Now()
+	if now.Sub(l.lastSweep) > time.Minute {
+		for k, c := range l.clients {
+			if now.Sub(c.lastSeen) > idleTimeout {
+				delete(l.clients, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	c, ok := l.clients[key]
+	if !ok {
+		c = &client{
+			read:  newBucket(l.cfg.ReadRate, l.cfg.ReadBurst),
+			write: newBucket(l.cfg.WriteRate, l.cfg.WriteBurst),
+		}
+		l.clients[key] = c
+	}
+	c.lastSeen = now
+
+	bucket := c.read
+	if write {
+		bucket = c.write
+	}
+
+	r := bucket.ReserveN(now, 1)
+	if !r.OK() {
+		return time.Second, false
+	}
+	if delay := r.DelayFrom(now); delay > 0 {
+		r.CancelAt(now)
+		return delay, false
+	}
+
+	return 0, true
+}
+
+// newBucket returns a bucket refilled at perSecond tokens; zero disables the limit.
+func newBucket(perSecond float64, burst int) *rate.Limiter {
+	if perSecond <= 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+
+	return rate.NewLimiter(rate.Limit(perSecond), max(burst, 1))
+}
+
+// updateBucket applies new limits to b, keeping its tokens. A bucket that was
+// unlimited holds no tokens, so it is replaced by a full one.
+func updateBucket(b *rate.Limiter, perSecond float64, burst int) *rate.Limiter {
+	if perSecond <= 0 || b.Limit() == rate.Inf {
+		return newBucket(perSecond, burst)
+	}
+
+	b.SetLimit(rate.Limit(perSecond))
+	b.SetBurst(max(burst, 1))
+
+	return b
+}
+
+func exhausted(ctx context.Context, setHeader func(context.Context, metadata.MD) error, delay time.Duration, format string, args ...any) error {
+	seconds := strconv.Itoa(int(math.Ceil(delay.Seconds())))
+	_ = setHeader(ctx, metadata.Pairs(RetryAfterKey, seconds))
+
+	return status.Errorf(codes.ResourceExhausted, format+", retry after %ss", append(args, seconds)...)
+}
+
+// clientKey identifies the caller by identity, or by peer address for
+// unauthenticated callers, which for REST calls is the HTTP client's address.
+func clientKey(ctx context.Context) string {
+	if id, ok := auth.FromContext(ctx); ok {
+		return id.String()
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	// REST calls all arrive over the gateway's in-process connection. The
+	// gateway appends the address it got the request from to
+	// x-forwarded-for; earlier entries come from the client and can be forged.
+	if _, ok := p.AuthInfo.(auth.InProcessAuthInfo); ok {
+		if xff := metadata.ValueFromIncomingContext(ctx, "x-forwarded-for"); len(xff) > 0 {
+			addrs := strings.Split(xff[len(xff)-1], ",")
+			return strings.TrimSpace(addrs[len(addrs)-1])
+		}
+	}
+
+	addr := p.Addr.String()
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+
+	return addr
+}
+
+// isRead reports whether a method only reads state, going by the Get, List,
+// Watch and Tail naming convention of the API. Health checks and reflection never
+// change anything.
+func isRead(fullMethod string) bool {
+	if strings.HasPrefix(fullMethod, "/grpc.health.") || strings.HasPrefix(fullMethod, "/grpc.reflection.") {
+		return true
+	}
+
+	name := path.Base(fullMethod)
+	return strings.HasPrefix(name, "Get") || strings.HasPrefix(name, "List") ||
+		strings.HasPrefix(name, "Watch") || strings.HasPrefix(name, "Tail")
+}
@@ -0,0 +1,246 @@
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"hostManager/internal/auth"
+	"hostManager/internal/config"
+)
+
+const (
+	readMethod  = "/dns.DNSHostnameService/GetHostname"
+	writeMethod = "/dns.DNSHostnameService/SetHostname"
+)
+
+// testTransportStream records the headers a unary handler sets.
+type testTransportStream struct {
+	header metadata.MD
+}
+
+func (s *testTransportStream) Method() string { return "" }
+
+func (s *testTransportStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *testTransportStream) SendHeader(md metadata.MD) error { return s.SetHeader(md) }
+
+func (s *testTransportStream) SetTrailer(metadata.MD) error { return nil }
+
+// call runs a unary call of method as subject through l, and returns the
+// Retry-After header and its error.
+func call(l *Limiter, subject, method string, handler grpc.UnaryHandler) (string, error) {
+	stream := &testTransportStream{}
+	ctx := auth.WithIdentity(context.Background(), auth.Identity{Method: "jwt", Subject: subject})
+	ctx = grpc.NewContextWithServerTransportStream(ctx, stream)
+	if handler == nil {
+		handler = func(context.Context, any) (any, error) { return nil, nil }
+	}
+
+	_, err := l.UnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+
+	var retryAfter string
+	if values := stream.header.Get(RetryAfterKey); len(values) > 0 {
+		retryAfter = values[0]
+	}
+	return retryAfter, err
+}
+
+func TestTokenBucket(t *testing.T) {
+	l := New(config.RateLimitConfig{ReadRate: 0.5, ReadBurst: 2, WriteRate: 0.25, WriteBurst: 1})
+
+	for i := 0; i < 2; i++ {
+		if _, err := call(l, "alice", readMethod, nil); err != nil {
+			t.Fatalf("read %d within the burst: %v", i, err)
+		}
+	}
+	retryAfter, err := call(l, "alice", readMethod, nil)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("read over the burst: error = %v, want ResourceExhausted", err)
+	}
+	if retryAfter != "2" {
+		t.Errorf("read Retry-After = %q, want 2", retryAfter)
+	}
+
+	// Writes have their own budget.
+	if _, err := call(l, "alice", writeMethod, nil); err != nil {
+		t.Fatalf("write with an exhausted read budget: %v", err)
+	}
+	retryAfter, err = call(l, "alice", writeMethod, nil)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("write over the burst: error = %v, want ResourceExhausted", err)
+	}
+	if retryAfter != "4" {
+		t.Errorf("write Retry-After = %q, want 4", retryAfter)
+	}
+
+	// Every client has its own buckets.
+	if _, err := call(l, "bob", writeMethod, nil); err != nil {
+		t.Errorf("write of another client: %v", err)
+	}
+}
+
+func TestTokenBucketRefill(t *testing.T) {
+	l := New(config.RateLimitConfig{ReadRate: 20, ReadBurst: 1})
+
+	if _, err := call(l, "alice", readMethod, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := call(l, "alice", readMethod, nil); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("error = %v, want ResourceExhausted", err)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if _, err := call(l, "alice", readMethod, nil); err != nil {
+		t.Errorf("read after the bucket refilled: %v", err)
+	}
+}
+
+func TestInFlightCap(t *testing.T) {
+	l := New(config.RateLimitConfig{MaxInFlightMutations: 1})
+
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		_, err := call(l, "alice", writeMethod, func(context.Context, any) (any, error) {
+			close(entered)
+			<-release
+			return nil, nil
+		})
+		done <- err
+	}()
+	<-entered
+
+	retryAfter, err := call(l, "bob", writeMethod, nil)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("write over the cap: error = %v, want ResourceExhausted", err)
+	}
+	if retryAfter != "1" {
+		t.Errorf("Retry-After = %q, want 1", retryAfter)
+	}
+	if _, err := call(l, "bob", readMethod, nil); err != nil {
+		t.Errorf("read while the cap is reached: %v", err)
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := call(l, "bob", writeMethod, nil); err != nil {
+		t.Errorf("write after the cap freed up: %v", err)
+	}
+}
+
+func TestStreamRetryAfter(t *testing.T) {
+	l := New(config.RateLimitConfig{ReadRate: 1, ReadBurst: 1})
+	ctx := auth.WithIdentity(context.Background(), auth.Identity{Method: "jwt", Subject: "alice"})
+	info := &grpc.StreamServerInfo{FullMethod: "/dns.DNSHostnameService/WatchChanges"}
+	handler := func(any, grpc.ServerStream) error { return nil }
+
+	if err := l.StreamServerInterceptor()(nil, &testStream{ctx: ctx}, info, handler); err != nil {
+		t.Fatal(err)
+	}
+
+	stream := &testStream{ctx: ctx}
+	err := l.StreamServerInterceptor()(nil, stream, info, handler)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("error = %v, want ResourceExhausted", err)
+	}
+	if got := stream.header.Get(RetryAfterKey); len(got) != 1 || got[0] != "1" {
+		t.Errorf("Retry-After = %v, want 1", got)
+	}
+}
+
+func TestSetConfig(t *testing.T) {
+	l := New(config.RateLimitConfig{})
+
+	// Without limits nothing is rejected.
+	for i := 0; i < 10; i++ {
+		if _, err := call(l, "alice", writeMethod, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	l.SetConfig(config.RateLimitConfig{WriteRate: 0.25, WriteBurst: 1})
+	if _, err := call(l, "alice", writeMethod, nil); err != nil {
+		t.Fatalf("first write after enabling the limit: %v", err)
+	}
+	if _, err := call(l, "alice", writeMethod, nil); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("error = %v, want ResourceExhausted", err)
+	}
+
+	// A larger burst does not refill the bucket.
+	l.SetConfig(config.RateLimitConfig{WriteRate: 0.25, WriteBurst: 5})
+	if _, err := call(l, "alice", writeMethod, nil); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("error after raising the burst = %v, want ResourceExhausted", err)
+	}
+}
+
+func TestClientKey(t *testing.T) {
+	tcp := &net.TCPAddr{IP: net.IPv4(192, 0, 2, 1), Port: 4242}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{
+			name: "identity",
+			ctx:  auth.WithIdentity(peer.NewContext(context.Background(), &peer.Peer{Addr: tcp}), auth.Identity{Method: "mtls", Subject: "alice"}),
+			want: "mtls:alice",
+		},
+		{
+			name: "peer address",
+			ctx:  peer.NewContext(context.Background(), &peer.Peer{Addr: tcp}),
+			want: "192.0.2.1",
+		},
+		{
+			name: "forwarded address of a gateway call",
+			ctx: metadata.NewIncomingContext(
+				peer.NewContext(context.Background(), &peer.Peer{Addr: tcp, AuthInfo: auth.InProcessAuthInfo{}}),
+				metadata.Pairs("x-forwarded-for", "203.0.113.9, 198.51.100.7")),
+			want: "198.51.100.7",
+		},
+		{
+			name: "forwarded address of a direct call",
+			ctx: metadata.NewIncomingContext(
+				peer.NewContext(context.Background(), &peer.Peer{Addr: tcp}),
+				metadata.Pairs("x-forwarded-for", "198.51.100.7")),
+			want: "192.0.2.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := clientKey(tt.ctx); got != tt.want {
+				t.Errorf("clientKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+type testStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	header metadata.MD
+}
+
+func (s *testStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
@@ -0,0 +1,311 @@
+package scheduler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/rs/zerolog/log"
+
+	"hostManager/internal/audit"
+	"hostManager/internal/auth"
+	"hostManager/internal/fsutil"
+)
+
+const jobsFileName = "scheduled-changes.json"
+
+var (
+	ErrJobNotFound     = errors.New("scheduled change not found")
+	ErrInvalidSchedule = errors.New("invalid schedule")
+)
+
+// Job is a change queued to run once at RunAt or on every match of Cron.
+type Job struct {
+	ID string `json:"id"`
+	// Method is the mutation the job runs and Payload its JSON-encoded request.
+	Method  string          `json:"method"`
+	Payload json.RawMessage `json:"payload"`
+	// Requester is who scheduled the job. The job runs as them.
+	Requester *auth.Identity `json:"requester,omitempty"`
+	RunAt     time.Time      `json:"run_at,omitempty"`
+	Cron      string         `json:"cron,omitempty"`
+	NextRun   time.Time      `json:"next_run"`
+	LastRun   time.Time      `json:"last_run,omitempty"`
+	LastError string         `json:"last_error,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// Executor runs the change described by a job.
+type Executor func(ctx context.Context, job Job) error
+
+// Scheduler runs queued changes at their time. Jobs are persisted in the
+// state directory so they survive restarts.
+type Scheduler struct {
+	exec  Executor
+	audit *audit.Logger
+	path  string
+
+	mu     sync.Mutex
+	jobs   map[string]*Job
+	timers map[string]*time.Timer
+}
+
+func New(stateDir string, exec Executor, audit *audit.Logger) *Scheduler {
+	return &Scheduler{
+		exec:   exec,
+		audit:  audit,
+		path:   filepath.Join(stateDir, jobsFileName),
+		jobs:   make(map[string]*Job),
+		timers: make(map[string]*time.Timer),
+	}
+}
+
+// Start loads persisted jobs and arms their timers. One-shot jobs missed while
+// the process was down run right away; cron jobs resume at their next match.
+func (s *Scheduler) Start() error {
+	const op = "scheduler.Start"
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0750); err != nil {
+		return fmt.Errorf("op: %s, failed to create state dir: %w", op, err)
+	}
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("op: %s, failed to read %s: %w", op, s.path, err)
+	}
+
+	var jobs []*Job
+	if err := json.Unmarshal(data, &jobs); err != nil {
+		return fmt.Errorf("op: %s, failed to parse %s: %w", op, s.path, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for _, job := range jobs {
+		if job.Cron != "" && job.NextRun.Before(now) {
+			next, err := nextRun(job.Cron, now)
+			if err != nil {
+				return fmt.Errorf("op: %s, job %s: %w", op, job.ID, err)
+			}
+			job.NextRun = next
+		}
+		s.arm(job)
+	}
+
+	log.Info().Str("op", op).Int("count", len(jobs)).Msg("Loaded scheduled changes")
+	return nil
+}
+
+// Stop disarms all timers. Jobs stay persisted.
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, timer := range s.timers {
+		timer.Stop()
+	}
+}
+
+// Add queues job. Exactly one of job.RunAt and job.Cron must be set.
+func (s *Scheduler) Add(job Job) (Job, error) {
+	const op = "scheduler.Add"
+
+	now := time.Now()
+	switch {
+	case job.Cron != "" && !job.RunAt.IsZero():
+		return Job{}, fmt.Errorf("op: %s, %w: set either a run time or a cron expression", op, ErrInvalidSchedule)
+	case job.Cron != "":
+		next, err := nextRun(job.Cron, now)
+		if err != nil {
+			return Job{}, fmt.Errorf("op: %s: %w", op, err)
+		}
+		job.NextRun = next
+	case !job.RunAt.IsZero():
+		if !job.RunAt.After(now) {
+			return Job{}, fmt.Errorf("op: %s, %w: run time is in the past", op, ErrInvalidSchedule)
+		}
+		job.NextRun = job.RunAt
+	default:
+		return Job{}, fmt.Errorf("op: %s, %w: run time or cron expression is required", op, ErrInvalidSchedule)
+	}
+
+	id, err := newJobID()
+	if err != nil {
+		return Job{}, fmt.Errorf("op: %s, failed to generate id: %w", op, err)
+	}
+	job.ID = id
+	job.CreatedAt = now
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.arm(&job)
+	if err := s.save(); err != nil {
+		s.timers[job.ID].Stop()
+		delete(s.timers, job.ID)
+		delete(s.jobs, job.ID)
+		return Job{}, fmt.Errorf("op: %s: %w", op, err)
+	}
+
+	log.Info().Str("op", op).Str("jobID", job.ID).Str("method", job.Method).
+		Time("nextRun", job.NextRun).Msg("Change scheduled")
+	return job, nil
+}
+
+// List returns the queued jobs ordered by their next run.
+func (s *Scheduler) List() []Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	jobs := make([]Job, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		jobs = append(jobs, *job)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].NextRun.Before(jobs[j].NextRun) })
+
+	return jobs
+}
+
+// Cancel removes a queued job.
+func (s *Scheduler) Cancel(id string) error {
+	const op = "scheduler.Cancel"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.jobs[id]; !ok {
+		return fmt.Errorf("op: %s, %s: %w", op, id, ErrJobNotFound)
+	}
+	s.timers[id].Stop()
+	delete(s.timers, id)
+	delete(s.jobs, id)
+
+	if err := s.save(); err != nil {
+		return fmt.Errorf("op: %s: %w", op, err)
+	}
+
+	log.Info().Str("op", op).Str("jobID", id).Msg("Scheduled change cancelled")
+	return nil
+}
+
+func (s *Scheduler) arm(job *Job) {
+	s.jobs[job.ID] = job
+	id, at := job.ID, job.NextRun
+	s.timers[job.ID] = time.AfterFunc(time.Until(at), func() { s.run(id, at) })
+}
+
+func (s *Scheduler) run(id string, at time.Time) {
+	const op = "scheduler.run"
+	l := log.With().Str("op", op).Str("jobID", id).Logger()
+
+	s.mu.Lock()
+	job, ok := s.jobs[id]
+	// The job was cancelled after this timer fired.
+	if !ok || !job.NextRun.Equal(at) {
+		s.mu.Unlock()
+		return
+	}
+	s.mu.Unlock()
+
+	l.Info().Str("method", job.Method).Msg("Running scheduled change")
+	err := s.exec(context.Background(), *job)
+
+	actor := "scheduler"
+	if job.Requester != nil {
+		actor = job.Requester.String()
+	}
+	s.audit.Record(audit.Record{
+		Actor:    actor,
+		Action:   job.Method,
+		Resource: "scheduled-change",
+		Target:   id,
+		Err:      err,
+	})
+	if err != nil {
+		l.Error().Err(err).Msg("Scheduled change failed")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The job may have been cancelled while it ran.
+	if _, ok := s.jobs[id]; !ok {
+		return
+	}
+
+	job.LastRun = time.Now()
+	job.LastError = ""
+	if err != nil {
+		job.LastError = err.Error()
+	}
+
+	if job.Cron == "" {
+		delete(s.timers, id)
+		delete(s.jobs, id)
+	} else {
+		next, err := nextRun(job.Cron, time.Now())
+		if err != nil {
+			l.Error().Err(err).Msg("Failed to compute next run")
+			return
+		}
+		job.NextRun = next
+		s.arm(job)
+	}
+
+	if err := s.save(); err != nil {
+		l.Error().Err(err).Msg("Failed to persist scheduled changes")
+	}
+}
+
+// save persists the jobs. The caller must hold s.mu.
+func (s *Scheduler) save() error {
+	const op = "scheduler.save"
+
+	jobs := make([]*Job, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		jobs = append(jobs, job)
+	}
+
+	data, err := json.Marshal(jobs)
+	if err != nil {
+		return fmt.Errorf("op: %s, failed to encode jobs: %w", op, err)
+	}
+
+	if err := fsutil.WriteFileAtomic(s.path, data); err != nil {
+		return fmt.Errorf("op: %s: %w", op, err)
+	}
+
+	return nil
+}
+
+func nextRun(expr string, after time.Time) (time.Time, error) {
+	schedule, err := cron.ParseStandard(expr)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %w", ErrInvalidSchedule, err)
+	}
+
+	return schedule.Next(after), nil
+}
+
+func newJobID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
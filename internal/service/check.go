@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// HostState is the host as a change finds it.
+type HostState struct {
+	Hostname   string
+	DNSServers []string
+}
+
+type checkKey struct{}
+
+// WithCheck makes changes made with ctx call check with the state of the host
+// before they write, under the same lock as the write, and fail with its
+// error. Reverts are never checked.
+func WithCheck(ctx context.Context, check func(HostState) error) context.Context {
+	return context.WithValue(ctx, checkKey{}, check)
+}
+
+// Check runs the check WithCheck added to ctx, if any, on state. HostManager
+// implementations call it before they write.
+func Check(ctx context.Context, state HostState) error {
+	check, ok := ctx.Value(checkKey{}).(func(HostState) error)
+	if !ok {
+		return nil
+	}
+
+	return check(state)
+}
+
+type dryRunKey struct{}
+
+// WithDryRun makes changes made with ctx stop after their checks, including
+// the one of WithCheck, without writing anything. They then return a change
+// with the current version of the file.
+func WithDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, true)
+}
+
+func dryRun(ctx context.Context) bool {
+	dry, _ := ctx.Value(dryRunKey{}).(bool)
+	return dry
+}
+
+// unchanged returns the change a dry run of a change to the file of resource
+// at path reports.
+func unchanged(resource Resource, path string) (Change, error) {
+	version, err := fileVersion(path)
+	if err != nil {
+		return Change{}, err
+	}
+
+	return Change{Resource: resource, Version: version}, nil
+}
+
+// check runs the check of ctx on the current state. m.mu must be held.
+func (m *FileSystemHostManager) check(ctx context.Context) error {
+	const op = "check"
+
+	if _, ok := ctx.Value(checkKey{}).(func(HostState) error); !ok {
+		return nil
+	}
+
+	hostname, _, err := m.GetHostname(ctx)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(m.files.ResolvConf)
+	if err != nil {
+		return fmt.Errorf("op: %s, failed to read %s: %w", op, m.files.ResolvConf, err)
+	}
+	lines, err := readLines(data)
+	if err != nil {
+		return fmt.Errorf("op: %s, error reading %s: %w", op, m.files.ResolvConf, err)
+	}
+
+	return Check(ctx, HostState{Hostname: hostname, DNSServers: parseResolverConfig(lines).Nameservers})
+}
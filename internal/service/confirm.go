@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"hostManager/internal/audit"
+	"hostManager/internal/fsutil"
+	"hostManager/internal/metrics"
+)
+
+const confirmationFileName = "confirmations.json"
+
+// Confirmer rolls changes back unless they are confirmed in time, in the
+// spirit of "commit confirmed". Pending confirmations are persisted in the
+// state directory so they survive restarts.
+type Confirmer struct {
+	manager HostManager
+	audit   *audit.Logger
+	path    string
+
+	mu      sync.Mutex
+	pending map[string]confirmation
+	timers  map[string]*time.Timer
+}
+
+// confirmation is a change awaiting confirmation until Deadline.
+type confirmation struct {
+	Change   Change    `json:"change"`
+	Deadline time.Time `json:"deadline"`
+}
+
+func NewConfirmer(manager HostManager, audit *audit.Logger, stateDir string) *Confirmer {
+	return &Confirmer{
+		manager: manager,
+		audit:   audit,
+		path:    filepath.Join(stateDir, confirmationFileName),
+		pending: make(map[string]confirmation),
+		timers:  make(map[string]*time.Timer),
+	}
+}
+
+// Start loads persisted confirmations and arms their rollback timers. Changes
+// whose deadline passed while the process was down are rolled back right away.
+func (c *Confirmer) Start() error {
+	const op = "Confirmer.Start"
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0750); err != nil {
+		return fmt.Errorf("op: %s, failed to create state dir: %w", op, err)
+	}
+
+	data, err := os.ReadFile(c.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("op: %s, failed to read %s: %w", op, c.path, err)
+	}
+
+	pending := make(map[string]confirmation)
+	if err := json.Unmarshal(data, &pending); err != nil {
+		return fmt.Errorf("op: %s, failed to parse %s: %w", op, c.path, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for id, p := range pending {
+		c.arm(id, p)
+	}
+
+	log.Info().Str("op", op).Int("count", len(pending)).Msg("Loaded pending confirmations")
+	return nil
+}
+
+// Stop disarms all timers. Pending confirmations stay persisted.
+func (c *Confirmer) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, timer := range c.timers {
+		timer.Stop()
+	}
+}
+
+// Track arms a rollback timer for change and returns the id to confirm it with.
+func (c *Confirmer) Track(change Change, within time.Duration) (string, error) {
+	const op = "Track"
+
+	id, err := newChangeID()
+	if err != nil {
+		return "", fmt.Errorf("op: %s, failed to generate change id: %w", op, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.arm(id, confirmation{Change: change, Deadline: time.Now().Add(within)})
+	if err := c.save(); err != nil {
+		c.timers[id].Stop()
+		delete(c.timers, id)
+		delete(c.pending, id)
+		return "", fmt.Errorf("op: %s, %w", op, err)
+	}
+
+	log.Info().Str("op", op).Str("changeID", id).Str("resource", string(change.Resource)).
+		Dur("within", within).Msg("Change awaits confirmation")
+	return id, nil
+}
+
+// Confirm keeps the change with the given id and stops its rollback timer.
+func (c *Confirmer) Confirm(id string) error {
+	const op = "Confirm"
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	timer, ok := c.timers[id]
+	if !ok || !timer.Stop() {
+		return fmt.Errorf("op: %s, change %s: %w", op, id, ErrChangeNotFound)
+	}
+	delete(c.timers, id)
+	delete(c.pending, id)
+	if err := c.save(); err != nil {
+		log.Error().Str("op", op).Err(err).Msg("Failed to persist pending confirmations")
+	}
+
+	log.Info().Str("op", op).Str("changeID", id).Msg("Change confirmed")
+	return nil
+}
+
+func (c *Confirmer) arm(id string, p confirmation) {
+	c.pending[id] = p
+	c.timers[id] = time.AfterFunc(time.Until(p.Deadline), func() { c.rollback(id, p.Change) })
+}
+
+// rollback restores the backup of an unconfirmed change, unless the file was
+// changed again since, as restoring it would undo the later change too. The
+// change stays persisted until then, so a rollback cut short by a restart is
+// made again.
+func (c *Confirmer) rollback(id string, change Change) {
+	const op = "rollback"
+	l := log.With().Str("op", op).Str("changeID", id).Logger()
+
+	defer func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		delete(c.timers, id)
+		delete(c.pending, id)
+		if err := c.save(); err != nil {
+			l.Error().Err(err).Msg("Failed to persist pending confirmations")
+		}
+	}()
+
+	l.Warn().Str("resource", string(change.Resource)).Msg("Change was not confirmed in time, rolling back")
+
+	err := c.manager.Revert(context.Background(), change)
+	c.audit.Record(audit.Record{
+		Actor:    "confirmer",
+		Action:   "Rollback",
+		Resource: string(change.Resource),
+		Target:   id,
+		Err:      err,
+	})
+	if errors.Is(err, ErrVersionMismatch) {
+		metrics.RollbackSkipped(string(change.Resource))
+		l.Error().Err(err).Msg("Changed again since the unconfirmed change, not rolling back")
+		return
+	}
+	metrics.RollbackDone(string(change.Resource), err)
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to roll back unconfirmed change")
+		return
+	}
+
+	l.Info().Str("backupFileName", change.Backup).Msg("Unconfirmed change rolled back")
+}
+
+// save persists the pending confirmations. The caller must hold c.mu.
+func (c *Confirmer) save() error {
+	const op = "Confirmer.save"
+
+	data, err := json.Marshal(c.pending)
+	if err != nil {
+		return fmt.Errorf("op: %s, failed to encode confirmations: %w", op, err)
+	}
+
+	if err := fsutil.WriteFileAtomic(c.path, data); err != nil {
+		return fmt.Errorf("op: %s: %w", op, err)
+	}
+
+	return nil
+}
+
+func newChangeID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
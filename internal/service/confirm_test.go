@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"hostManager/internal/audit"
+	"hostManager/internal/config"
+)
+
+func newTestManager(t *testing.T) (*FileSystemHostManager, *audit.Logger, string) {
+	t.Helper()
+	dir := t.TempDir()
+
+	files := config.HostFilesConfig{
+		Hostname:   filepath.Join(dir, "hostname"),
+		ResolvConf: filepath.Join(dir, "resolv.conf"),
+		Hosts:      filepath.Join(dir, "hosts"),
+	}
+	backup := config.BackupConfig{
+		BackupHostnameFilePath: filepath.Join(dir, "backup") + "/",
+		BackupDNSFilePath:      filepath.Join(dir, "backup") + "/",
+		BackupHostsFilePath:    filepath.Join(dir, "backup") + "/",
+	}
+	if err := os.Mkdir(filepath.Join(dir, "backup"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// Replace the hostname command by one that does nothing.
+	bin := filepath.Join(dir, "bin")
+	if err := os.Mkdir(bin, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(bin, "hostname"), []byte("#!/bin/sh\nexit 0\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	for path, data := range map[string]string{
+		files.Hostname:   "host\n",
+		files.ResolvConf: "nameserver 192.0.2.1\n",
+		files.Hosts:      "",
+	} {
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	auditLog, err := audit.New(filepath.Join(dir, "audit.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = auditLog.Close() })
+
+	return NewFileSystemHostManager(files, backup), auditLog, filepath.Join(dir, "state")
+}
+
+func TestConfirmerRollback(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		// after runs once the change is tracked.
+		after func(t *testing.T, m *FileSystemHostManager)
+		want  string
+	}{
+		{
+			name:  "unconfirmed change is rolled back",
+			after: func(*testing.T, *FileSystemHostManager) {},
+			want:  "nameserver 192.0.2.1\n",
+		},
+		{
+			name: "later change is kept",
+			after: func(t *testing.T, m *FileSystemHostManager) {
+				if _, err := m.AddDNSServer(ctx, "192.0.2.3", ""); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: "nameserver 192.0.2.1\nnameserver 192.0.2.2\nnameserver 192.0.2.3\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, auditLog, stateDir := newTestManager(t)
+			c := NewConfirmer(m, auditLog, stateDir)
+			if err := c.Start(); err != nil {
+				t.Fatal(err)
+			}
+			defer c.Stop()
+
+			change, err := m.AddDNSServer(ctx, "192.0.2.2", "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := c.Track(change, 50*time.Millisecond); err != nil {
+				t.Fatal(err)
+			}
+			tt.after(t, m)
+
+			waitForRollback(t, c)
+			if got := readTestFile(t, m.files.ResolvConf); got != tt.want {
+				t.Fatalf("resolv.conf = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfirmerPersists(t *testing.T) {
+	ctx := context.Background()
+	m, auditLog, stateDir := newTestManager(t)
+
+	c := NewConfirmer(m, auditLog, stateDir)
+	if err := c.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	confirmed, err := m.AddDNSServer(ctx, "192.0.2.2", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	confirmedID, err := c.Track(confirmed, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unconfirmed, err := m.SetHostname(ctx, "other", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Track(unconfirmed, 100*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	c.Stop()
+
+	// The restarted confirmer still knows both changes, and rolls back the
+	// unconfirmed one although the first confirmer was stopped.
+	c = NewConfirmer(m, auditLog, stateDir)
+	if err := c.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer c.Stop()
+
+	if err := c.Confirm(confirmedID); err != nil {
+		t.Fatalf("Confirm() after restart error = %v", err)
+	}
+	waitForRollback(t, c)
+
+	if got, want := readTestFile(t, m.files.ResolvConf), "nameserver 192.0.2.1\nnameserver 192.0.2.2\n"; got != want {
+		t.Errorf("resolv.conf = %q, want %q", got, want)
+	}
+	if got := readTestFile(t, m.files.Hostname); got != "host\n" {
+		t.Errorf("hostname = %q, want host", got)
+	}
+}
+
+// waitForRollback waits until c has no pending confirmations left.
+func waitForRollback(t *testing.T, c *Confirmer) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		c.mu.Lock()
+		n := len(c.pending)
+		c.mu.Unlock()
+		if n == 0 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("changes were not rolled back in time")
+}
+
+func readTestFile(t *testing.T, path string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(data)
+}
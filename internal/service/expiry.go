@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"hostManager/internal/audit"
+	"hostManager/internal/fsutil"
+	"hostManager/internal/metrics"
+)
+
+const expiryFileName = "dns-expiry.json"
+
+// Failed removals are retried after a delay doubling from expiryRetryMin up to
+// expiryRetryMax.
+const (
+	expiryRetryMin = 5 * time.Second
+	expiryRetryMax = 10 * time.Minute
+)
+
+// Expirer removes temporary DNS servers once they expire. Pending expiries are
+// persisted in the state directory so they survive restarts.
+type Expirer struct {
+	manager HostManager
+	audit   *audit.Logger
+	path    string
+
+	mu      sync.Mutex
+	expires map[string]time.Time
+	timers  map[string]*time.Timer
+	// retries counts the failed removals of each expired server.
+	retries map[string]int
+}
+
+func NewExpirer(manager HostManager, audit *audit.Logger, stateDir string) *Expirer {
+	return &Expirer{
+		manager: manager,
+		audit:   audit,
+		path:    filepath.Join(stateDir, expiryFileName),
+		expires: make(map[string]time.Time),
+		timers:  make(map[string]*time.Timer),
+		retries: make(map[string]int),
+	}
+}
+
+// Start loads persisted expiries and arms their timers. Servers that expired
+// while the process was down are removed right away.
+func (e *Expirer) Start() error {
+	const op = "Expirer.Start"
+
+	if err := os.MkdirAll(filepath.Dir(e.path), 0750); err != nil {
+		return fmt.Errorf("op: %s, failed to create state dir: %w", op, err)
+	}
+
+	data, err := os.ReadFile(e.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("op: %s, failed to read %s: %w", op, e.path, err)
+	}
+
+	expires := make(map[string]time.Time)
+	if err := json.Unmarshal(data, &expires); err != nil {
+		return fmt.Errorf("op: %s, failed to parse %s: %w", op, e.path, err)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for server, at := range expires {
+		e.arm(server, at)
+	}
+
+	log.Info().Str("op", op).Int("count", len(expires)).Msg("Loaded pending DNS server expiries")
+	return nil
+}
+
+// Stop disarms all timers. Pending expiries stay persisted.
+func (e *Expirer) Stop() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, timer := range e.timers {
+		timer.Stop()
+	}
+}
+
+// Schedule removes server at the given time. If the schedule cannot be
+// persisted, the previous one is kept.
+func (e *Expirer) Schedule(server string, at time.Time) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	previous, scheduled := e.expires[server]
+	if timer, ok := e.timers[server]; ok {
+		timer.Stop()
+	}
+	e.arm(server, at)
+
+	if err := e.save(); err != nil {
+		e.timers[server].Stop()
+		delete(e.timers, server)
+		delete(e.expires, server)
+		if scheduled {
+			e.arm(server, previous)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Cancel drops the pending expiry of server, if any. If that cannot be
+// persisted, the expiry is kept.
+func (e *Expirer) Cancel(server string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	at, ok := e.expires[server]
+	if !ok {
+		return nil
+	}
+	e.timers[server].Stop()
+	delete(e.timers, server)
+	delete(e.expires, server)
+	delete(e.retries, server)
+
+	if err := e.save(); err != nil {
+		e.arm(server, at)
+		return err
+	}
+
+	return nil
+}
+
+// Pending returns the pending expiry time of each temporary server.
+func (e *Expirer) Pending() map[string]time.Time {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	pending := make(map[string]time.Time, len(e.expires))
+	for server, at := range e.expires {
+		pending[server] = at
+	}
+
+	return pending
+}
+
+func (e *Expirer) arm(server string, at time.Time) {
+	e.expires[server] = at
+	delete(e.retries, server)
+	e.timers[server] = time.AfterFunc(time.Until(at), func() { e.expire(server, at) })
+}
+
+func (e *Expirer) expire(server string, at time.Time) {
+	const op = "Expirer.expire"
+	l := log.With().Str("op", op).Str("server", server).Logger()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	// The server was rescheduled or cancelled after this timer fired.
+	if current, ok := e.expires[server]; !ok || !current.Equal(at) {
+		return
+	}
+
+	_, err := e.manager.RemoveDNSServer(context.Background(), server, "")
+	if errors.Is(err, ErrDNSServerNotFound) {
+		l.Info().Msg("Expired DNS server is already gone")
+		err = nil
+	}
+
+	e.audit.Record(audit.Record{
+		Actor:    "expirer",
+		Action:   "RemoveDNSServer",
+		Resource: string(ResourceResolvConf),
+		Target:   server,
+		Err:      err,
+	})
+
+	metrics.ExpiryDone(err)
+
+	if err != nil {
+		e.retries[server]++
+		delay := expiryRetryDelay(e.retries[server])
+		e.timers[server] = time.AfterFunc(delay, func() { e.expire(server, at) })
+		l.Error().Err(err).Dur("retryIn", delay).Msg("Failed to remove expired DNS server")
+		return
+	}
+
+	delete(e.timers, server)
+	delete(e.expires, server)
+	delete(e.retries, server)
+	if err := e.save(); err != nil {
+		l.Error().Err(err).Msg("Failed to persist DNS server expiries")
+	}
+
+	l.Info().Msg("Expired DNS server removed")
+}
+
+// expiryRetryDelay returns the delay before retrying a removal that failed
+// failures times in a row.
+func expiryRetryDelay(failures int) time.Duration {
+	delay := expiryRetryMin
+	for i := 1; i < failures && delay < expiryRetryMax; i++ {
+		delay *= 2
+	}
+
+	return min(delay, expiryRetryMax)
+}
+
+// save persists the pending expiries. The caller must hold e.mu.
+func (e *Expirer) save() error {
+	const op = "Expirer.save"
+
+	data, err := json.Marshal(e.expires)
+	if err != nil {
+		return fmt.Errorf("op: %s, failed to encode expiries: %w", op, err)
+	}
+
+	if err := fsutil.WriteFileAtomic(e.path, data); err != nil {
+		return fmt.Errorf("op: %s: %w", op, err)
+	}
+
+	return nil
+}
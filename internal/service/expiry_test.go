@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestExpiryRetryDelay(t *testing.T) {
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{failures: 1, want: 5 * time.Second},
+		{failures: 2, want: 10 * time.Second},
+		{failures: 3, want: 20 * time.Second},
+		{failures: 8, want: 10 * time.Minute},
+		{failures: 1000, want: 10 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := expiryRetryDelay(tt.failures); got != tt.want {
+			t.Errorf("expiryRetryDelay(%d) = %s, want %s", tt.failures, got, tt.want)
+		}
+	}
+}
+
+// failingHostManager fails to remove DNS servers.
+type failingHostManager struct {
+	HostManager
+}
+
+func (failingHostManager) RemoveDNSServer(context.Context, string, string) (Change, error) {
+	return Change{}, errors.New("read-only file system")
+}
+
+func TestExpirerRetriesFailedRemovals(t *testing.T) {
+	_, auditLog, stateDir := newTestManager(t)
+
+	e := NewExpirer(failingHostManager{}, auditLog, stateDir)
+	if err := e.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer e.Stop()
+
+	at := time.Now()
+	e.mu.Lock()
+	e.expires["192.0.2.1"] = at
+	e.mu.Unlock()
+	e.expire("192.0.2.1", at)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if _, ok := e.expires["192.0.2.1"]; !ok {
+		t.Fatal("failed expiry was dropped")
+	}
+	if e.retries["192.0.2.1"] != 1 || e.timers["192.0.2.1"] == nil {
+		t.Fatalf("failed expiry was not re-armed: %d failures, timer %v", e.retries["192.0.2.1"], e.timers["192.0.2.1"])
+	}
+}
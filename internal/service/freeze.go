@@ -0,0 +1,85 @@
+package service
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"hostManager/internal/config"
+)
+
+// FreezeState describes the server-wide change freeze.
+type FreezeState struct {
+	Frozen bool
+	Reason string
+	Since  time.Time
+	// Until is when the freeze lifts itself; zero means it lasts until lifted.
+	Until time.Time
+}
+
+// Freeze is a server-wide switch that rejects mutations during change freezes
+// and incidents. Runtime changes are not persisted: after a restart the
+// freeze starts again from config.
+type Freeze struct {
+	mu    sync.RWMutex
+	state FreezeState
+}
+
+func NewFreeze(cfg config.FreezeConfig) *Freeze {
+	f := &Freeze{}
+	if cfg.Enabled {
+		f.state = FreezeState{Frozen: true, Reason: cfg.Reason, Since: time.Now(), Until: cfg.Until}
+	}
+
+	return f
+}
+
+// Set freezes the server until the given time, or indefinitely for a zero time.
+func (f *Freeze) Set(reason string, until time.Time) FreezeState {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.state = FreezeState{Frozen: true, Reason: reason, Since: time.Now(), Until: until}
+
+	log.Warn().Str("op", "Freeze.Set").Str("reason", reason).Time("until", until).Msg("Server frozen")
+	return f.state
+}
+
+// Lift ends the freeze.
+func (f *Freeze) Lift() FreezeState {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.state = FreezeState{}
+
+	log.Info().Str("op", "Freeze.Lift").Msg("Server freeze lifted")
+	return f.state
+}
+
+// State returns the current freeze, treating an expired one as lifted.
+func (f *Freeze) State() FreezeState {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if f.state.Frozen && !f.state.Until.IsZero() && time.Now().After(f.state.Until) {
+		return FreezeState{}
+	}
+
+	return f.state
+}
+
+// Check returns ErrFrozen while the server is frozen.
+func (f *Freeze) Check() error {
+	state := f.State()
+	if !state.Frozen {
+		return nil
+	}
+
+	if state.Until.IsZero() {
+		return fmt.Errorf("%w: %s", ErrFrozen, state.Reason)
+	}
+
+	return fmt.Errorf("%w until %s: %s", ErrFrozen, state.Until.Format(time.RFC3339), state.Reason)
+}
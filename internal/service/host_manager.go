@@ -1,10 +1,62 @@
 package service
 
-import "context"
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrVersionMismatch is returned by mutations whose expected version no longer
+	// matches the content of the managed file.
+	ErrVersionMismatch = errors.New("resource version mismatch")
+	// ErrChangeNotFound is returned when a change id is unknown or already settled.
+	ErrChangeNotFound = errors.New("change not found")
+
+	// ErrFrozen is returned for mutations while the server is frozen.
+	ErrFrozen = errors.New("server is frozen")
+
+	ErrDNSServerExists   = errors.New("DNS server already exists")
+	ErrDNSServerNotFound = errors.New("DNS server does not exist")
+)
+
+type Resource string
+
+const (
+	ResourceHostname   Resource = "hostname"
+	ResourceResolvConf Resource = "resolv.conf"
+	ResourceHosts      Resource = "hosts"
+)
+
+// Change describes a mutation applied to a managed file.
+type Change struct {
+	Resource Resource `json:"resource"`
+	// Version is the content version of the file after the change.
+	Version string `json:"version"`
+	// Backup is the backup file holding the content before the change.
+	Backup string `json:"backup"`
+}
+
+// ResolverConfig is the resolver configuration held in /etc/resolv.conf.
+type ResolverConfig struct {
+	Nameservers []string
+	Search      []string
+	Options     []string
+}
 
 type HostManager interface {
-	SetHostname(ctx context.Context, hostname string) error
-	ListDNSServers(ctx context.Context) ([]string, error)
-	AddDNSServer(ctx context.Context, server string) error
-	RemoveDNSServer(ctx context.Context, server string) error
+	GetHostname(ctx context.Context) (hostname string, version string, err error)
+	SetHostname(ctx context.Context, hostname string, expectedVersion string) (Change, error)
+	ListDNSServers(ctx context.Context) (servers []string, version string, err error)
+	AddDNSServer(ctx context.Context, server string, expectedVersion string) (Change, error)
+	RemoveDNSServer(ctx context.Context, server string, expectedVersion string) (Change, error)
+	GetResolverConfig(ctx context.Context) (cfg ResolverConfig, version string, err error)
+	// UpdateResolverConfig replaces the nameserver, search and options lines of
+	// /etc/resolv.conf with cfg in a single write.
+	UpdateResolverConfig(ctx context.Context, cfg ResolverConfig, expectedVersion string) (Change, error)
+	GetHosts(ctx context.Context) (content string, version string, err error)
+	// SetHosts replaces the content of /etc/hosts.
+	SetHosts(ctx context.Context, content string, expectedVersion string) (Change, error)
+	// Revert restores the content a change replaced. It fails with
+	// ErrVersionMismatch if the file changed after the change.
+	Revert(ctx context.Context, change Change) error
 }
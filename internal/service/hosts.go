@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"hostManager/internal/metrics"
+	"hostManager/internal/tracing"
+)
+
+func (m *FileSystemHostManager) GetHosts(ctx context.Context) (string, string, error) {
+	const op = "GetHosts"
+
+	data, err := os.ReadFile(m.files.Hosts)
+	if err != nil {
+		return "", "", fmt.Errorf("op: %s, failed to read %s: %w", op, m.files.Hosts, err)
+	}
+
+	return string(data), contentVersion(data), nil
+}
+
+func (m *FileSystemHostManager) SetHosts(ctx context.Context, content string, expectedVersion string) (Change, error) {
+	const op = "SetHosts"
+	l := zerolog.Ctx(ctx).With().Str("op", op).Logger()
+
+	l.Info().Int("bytes", len(content)).Msg("Setting hosts")
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := checkVersion(m.files.Hosts, expectedVersion); err != nil {
+		return Change{}, err
+	}
+
+	backupFileName, err := m.backupHosts(ctx)
+	if err != nil {
+		return Change{}, err
+	}
+
+	_, span := tracing.Start(ctx, "write "+m.files.Hosts)
+	err = os.WriteFile(m.files.Hosts, []byte(content), 0644)
+	tracing.End(span, err)
+	if err != nil {
+		revertErr := m.revertHosts(ctx, backupFileName)
+		metrics.RollbackDone(string(ResourceHosts), revertErr)
+		if revertErr != nil {
+			l.Error().Err(revertErr).Msg("Failed to revert hosts")
+		}
+		return Change{}, fmt.Errorf("op: %s, failed to write to %s: %w", op, m.files.Hosts, err)
+	}
+
+	m.wrote(ResourceHosts, m.files.Hosts)
+	l.Info().Msg("Hosts set successfully")
+	version, err := fileVersion(m.files.Hosts)
+	if err != nil {
+		return Change{}, err
+	}
+
+	metrics.MutationApplied("set-hosts")
+	return Change{Resource: ResourceHosts, Version: version, Backup: backupFileName}, nil
+}
+
+func (m *FileSystemHostManager) backupHosts(ctx context.Context) (_ string, err error) {
+	const op = "backupHosts"
+	l := log.With().Str("op", op).Logger()
+
+	_, span := tracing.Start(ctx, "backup "+m.files.Hosts)
+	defer func() { tracing.End(span, err) }()
+
+	hosts, err := os.ReadFile(m.files.Hosts)
+	if err != nil {
+		return "", fmt.Errorf("op: %s, failed to read %s for backup: %w", op, m.files.Hosts, err)
+	}
+
+	output, err := createBackup(m.BackupConfig().BackupHostsFilePath, "hosts")
+	if err != nil {
+		return "", fmt.Errorf("op: %s, failed to create backup file: %w", op, err)
+	}
+	defer closeFile(output)
+	backupFileName := output.Name()
+
+	_, err = output.Write(hosts)
+	if err != nil {
+		return "", fmt.Errorf("op: %s, failed to write backup file: %w", op, err)
+	}
+
+	metrics.BackupCreated(string(ResourceHosts))
+	l.Info().Str("backupFileName", backupFileName).Msg("Hosts backup created successfully")
+	return backupFileName, nil
+}
+
+func (m *FileSystemHostManager) revertHosts(ctx context.Context, backupFileName string) (err error) {
+	const op = "revertHosts"
+	l := log.With().Str("op", op).Logger()
+
+	_, span := tracing.Start(ctx, "revert "+m.files.Hosts)
+	defer func() { tracing.End(span, err) }()
+
+	backup, err := os.ReadFile(backupFileName)
+	if err != nil {
+		return fmt.Errorf("op: %s, failed to read backup file: %w", op, err)
+	}
+
+	err = os.WriteFile(m.files.Hosts, backup, 0644)
+	if err != nil {
+		return fmt.Errorf("op: %s, failed to restore backup to %s: %w", op, m.files.Hosts, err)
+	}
+	m.wrote(ResourceHosts, m.files.Hosts)
+
+	l.Info().Str("backupFileName", backupFileName).Msg("Hosts reverted successfully")
+	return nil
+}
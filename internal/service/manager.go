@@ -2,70 +2,125 @@ package service
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 
 	"hostManager/internal/config"
-)
-
-const (
-	resolvConfPath   = "/etc/resolv.conf"
-	hostnameFilePath = "/etc/hostname"
+	"hostManager/internal/metrics"
+	"hostManager/internal/tracing"
 )
 
 type FileSystemHostManager struct {
-	log zerolog.Logger
-	cfg config.BackupConfig
+	// mu serializes mutations so that a version check and the following write
+	// are not interleaved with another change.
+	mu     sync.Mutex
+	files  config.HostFilesConfig
+	backup atomic.Pointer[config.BackupConfig]
+	// onWrite, if set, is called with the version of every file written.
+	onWrite func(Resource, string)
+}
+
+func NewFileSystemHostManager(files config.HostFilesConfig, cfg config.BackupConfig) *FileSystemHostManager {
+	m := &FileSystemHostManager{files: files}
+	m.backup.Store(&cfg)
+
+	return m
+}
+
+func (m *FileSystemHostManager) BackupConfig() config.BackupConfig {
+	return *m.backup.Load()
 }
 
-func NewFileSystemHostManager(cfg config.BackupConfig) *FileSystemHostManager {
-	return &FileSystemHostManager{cfg: cfg}
+// SetBackupConfig makes later changes back up into the directories of cfg,
+// which should pass CheckBackupDirs.
+func (m *FileSystemHostManager) SetBackupConfig(cfg config.BackupConfig) {
+	m.backup.Store(&cfg)
+}
+
+// OnWrite sets a function called with the new version of every host file the
+// manager writes, so that a Watcher can tell them from external changes. It
+// must be set before the first change.
+func (m *FileSystemHostManager) OnWrite(fn func(Resource, string)) {
+	m.onWrite = fn
+}
+
+// wrote reports the current version of the file of resource to onWrite.
+func (m *FileSystemHostManager) wrote(resource Resource, path string) {
+	const op = "wrote"
+
+	if m.onWrite == nil {
+		return
+	}
+
+	version, err := fileVersion(path)
+	if err != nil {
+		log.Warn().Str("op", op).Err(err).Msg("Failed to read written file")
+		return
+	}
+	m.onWrite(resource, version)
 }
 
-func (m *FileSystemHostManager) backupHostname() (string, error) {
+func (m *FileSystemHostManager) backupHostname(ctx context.Context) (_ string, err error) {
 	const op = "backupHostname"
 	l := log.With().Str("op", op).Logger()
 
-	hostname, err := os.ReadFile(hostnameFilePath)
+	_, span := tracing.Start(ctx, "backup "+m.files.Hostname)
+	defer func() { tracing.End(span, err) }()
+
+	hostname, err := os.ReadFile(m.files.Hostname)
 	if err != nil {
-		return "", fmt.Errorf("op: %s, failed to read %s for backup: %w", op, hostnameFilePath, err)
+		return "", fmt.Errorf("op: %s, failed to read %s for backup: %w", op, m.files.Hostname, err)
 	}
 
-	backupFileName := fmt.Sprintf("%s-%d", m.cfg.BackupHostnameFilePath+"hostname", time.Now().Unix())
-	fmt.Println(m.cfg.BackupHostnameFilePath + "hostname")
-
-	err = os.WriteFile(backupFileName, hostname, 0644)
+	output, err := createBackup(m.BackupConfig().BackupHostnameFilePath, "hostname")
 	if err != nil {
 		return "", fmt.Errorf("op: %s, failed to create backup file: %w", op, err)
 	}
+	defer closeFile(output)
+	backupFileName := output.Name()
+
+	_, err = output.Write(hostname)
+	if err != nil {
+		return "", fmt.Errorf("op: %s, failed to write backup file: %w", op, err)
+	}
 
+	metrics.BackupCreated(string(ResourceHostname))
 	l.Info().Str("backupFileName", backupFileName).Msg("Hostname backup created successfully")
 	return backupFileName, nil
 }
 
-func revertHostname(backupFileName string) error {
+func (m *FileSystemHostManager) revertHostname(ctx context.Context, backupFileName string) (err error) {
 	const op = "revertHostname"
 	l := log.With().Str("op", op).Logger()
 
+	ctx, span := tracing.Start(ctx, "revert "+m.files.Hostname)
+	defer func() { tracing.End(span, err) }()
+
 	backup, err := os.ReadFile(backupFileName)
 	if err != nil {
 		return fmt.Errorf("op: %s, failed to read backup file: %w", op, err)
 	}
 
-	err = os.WriteFile(hostnameFilePath, backup, 0644)
+	err = os.WriteFile(m.files.Hostname, backup, 0644)
 	if err != nil {
-		return fmt.Errorf("op: %s, failed to restore backup to %s: %w", op, hostnameFilePath, err)
+		return fmt.Errorf("op: %s, failed to restore backup to %s: %w", op, m.files.Hostname, err)
 	}
+	m.wrote(ResourceHostname, m.files.Hostname)
 
+	_, execSpan := tracing.Start(ctx, "exec hostname")
 	err = exec.Command("hostname", strings.TrimSpace(string(backup))).Run()
+	tracing.End(execSpan, err)
 	if err != nil {
 		return fmt.Errorf("op: %s, failed to set hostname: %w", op, err)
 	}
@@ -74,13 +129,16 @@ func revertHostname(backupFileName string) error {
 	return nil
 }
 
-func (m *FileSystemHostManager) backupResolvConf() (string, error) {
+func (m *FileSystemHostManager) backupResolvConf(ctx context.Context) (_ string, err error) {
 	const op = "backupResolvConf"
 	l := log.With().Str("op", op).Logger()
 
-	input, err := os.Open(resolvConfPath)
+	_, span := tracing.Start(ctx, "backup "+m.files.ResolvConf)
+	defer func() { tracing.End(span, err) }()
+
+	input, err := os.Open(m.files.ResolvConf)
 	if err != nil {
-		return "", fmt.Errorf("op: %s, failed to open %s for backup: %w", op, resolvConfPath, err)
+		return "", fmt.Errorf("op: %s, failed to open %s for backup: %w", op, m.files.ResolvConf, err)
 	}
 	defer func() {
 		if err := input.Close(); err != nil {
@@ -88,35 +146,39 @@ func (m *FileSystemHostManager) backupResolvConf() (string, error) {
 		}
 	}()
 
-	backupFileName := fmt.Sprintf("%s-%d", m.cfg.BackupDNSFilePath+"resolv.conf", time.Now().Unix())
-	output, err := os.Create(backupFileName)
+	output, err := createBackup(m.BackupConfig().BackupDNSFilePath, "resolv.conf")
 	if err != nil {
 		return "", fmt.Errorf("op: %s, failed to create backup file: %w", op, err)
 	}
 	defer closeFile(output)
+	backupFileName := output.Name()
 
 	_, err = io.Copy(output, input)
 	if err != nil {
 		return "", fmt.Errorf("failed to copy contents to backup file: %w", err)
 	}
 
+	metrics.BackupCreated(string(ResourceResolvConf))
 	l.Info().Str("backupFileName", backupFileName).Msg("Backup created successfully")
 	return backupFileName, nil
 }
 
-func (m *FileSystemHostManager) revertResolvConf(backupFileName string) error {
+func (m *FileSystemHostManager) revertResolvConf(ctx context.Context, backupFileName string) (err error) {
 	const op = "revertResolvConf"
 	l := log.With().Str("op", op).Logger()
 
+	_, span := tracing.Start(ctx, "revert "+m.files.ResolvConf)
+	defer func() { tracing.End(span, err) }()
+
 	input, err := os.Open(backupFileName)
 	if err != nil {
 		return fmt.Errorf("op: %s, failed to open backup file: %w", op, err)
 	}
 	defer closeFile(input)
 
-	output, err := os.Create(resolvConfPath)
+	output, err := os.Create(m.files.ResolvConf)
 	if err != nil {
-		return fmt.Errorf("op: %s, failed to open %s for writing: %w", op, resolvConfPath, err)
+		return fmt.Errorf("op: %s, failed to open %s for writing: %w", op, m.files.ResolvConf, err)
 	}
 	defer closeFile(output)
 
@@ -124,40 +186,116 @@ func (m *FileSystemHostManager) revertResolvConf(backupFileName string) error {
 	if err != nil {
 		return fmt.Errorf("op: %s, failed to copy contents from backup file: %w", op, err)
 	}
+	m.wrote(ResourceResolvConf, m.files.ResolvConf)
 
 	l.Info().Str("backupFileName", backupFileName).Msg("Reverted to backup successfully")
 	return nil
 }
 
-func (m *FileSystemHostManager) SetHostname(ctx context.Context, hostname string) error {
+func (m *FileSystemHostManager) GetHostname(ctx context.Context) (string, string, error) {
+	const op = "GetHostname"
+
+	data, err := os.ReadFile(m.files.Hostname)
+	if err != nil {
+		return "", "", fmt.Errorf("op: %s, failed to read %s: %w", op, m.files.Hostname, err)
+	}
+
+	return strings.TrimSpace(string(data)), contentVersion(data), nil
+}
+
+func (m *FileSystemHostManager) SetHostname(ctx context.Context, hostname string, expectedVersion string) (Change, error) {
 	const op = "SetHostname"
-	l := m.log.With().Str("op", op).Logger()
+	l := zerolog.Ctx(ctx).With().Str("op", op).Logger()
 
 	l.Info().Str("hostname", hostname).Msg("Setting hostname")
 
-	backupFileName, err := m.backupHostname()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := checkVersion(m.files.Hostname, expectedVersion); err != nil {
+		return Change{}, err
+	}
+
+	if err := m.check(ctx); err != nil {
+		return Change{}, err
+	}
+
+	if dryRun(ctx) {
+		return unchanged(ResourceHostname, m.files.Hostname)
+	}
+
+	backupFileName, err := m.backupHostname(ctx)
 	if err != nil {
-		return err
+		return Change{}, err
 	}
 
+	_, span := tracing.Start(ctx, "exec hostname")
 	err = exec.CommandContext(ctx, "hostname", hostname).Run()
+	tracing.End(span, err)
 	if err != nil {
-		if revertErr := revertHostname(backupFileName); revertErr != nil {
+		revertErr := m.revertHostname(ctx, backupFileName)
+		metrics.RollbackDone(string(ResourceHostname), revertErr)
+		if revertErr != nil {
 			l.Error().Err(revertErr).Msg("Failed to revert hostname")
 		}
-		return fmt.Errorf("op: %s, failed to set hostname: %w", op, err)
+		return Change{}, fmt.Errorf("op: %s, failed to set hostname: %w", op, err)
 	}
 
-	err = os.WriteFile(hostnameFilePath, []byte(hostname+"\n"), 0644)
+	_, span = tracing.Start(ctx, "write "+m.files.Hostname)
+	err = os.WriteFile(m.files.Hostname, []byte(hostname+"\n"), 0644)
+	tracing.End(span, err)
 	if err != nil {
-		if revertErr := revertHostname(backupFileName); revertErr != nil {
+		revertErr := m.revertHostname(ctx, backupFileName)
+		metrics.RollbackDone(string(ResourceHostname), revertErr)
+		if revertErr != nil {
 			l.Error().Err(revertErr).Msg("Failed to revert hostname")
 		}
-		return fmt.Errorf("op: %s, failed to write to %s: %w", op, hostnameFilePath, err)
+		return Change{}, fmt.Errorf("op: %s, failed to write to %s: %w", op, m.files.Hostname, err)
 	}
 
+	m.wrote(ResourceHostname, m.files.Hostname)
 	l.Info().Str("hostname", hostname).Msg("Hostname set successfully")
-	return nil
+	version, err := fileVersion(m.files.Hostname)
+	if err != nil {
+		return Change{}, err
+	}
+
+	metrics.MutationApplied("set-hostname")
+	return Change{Resource: ResourceHostname, Version: version, Backup: backupFileName}, nil
+}
+
+func (m *FileSystemHostManager) Revert(ctx context.Context, change Change) error {
+	const op = "Revert"
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var path string
+	switch change.Resource {
+	case ResourceHostname:
+		path = m.files.Hostname
+	case ResourceResolvConf:
+		path = m.files.ResolvConf
+	case ResourceHosts:
+		path = m.files.Hosts
+	default:
+		return fmt.Errorf("op: %s, unknown resource %q", op, change.Resource)
+	}
+
+	if err := checkVersion(path, change.Version); err != nil {
+		return fmt.Errorf("op: %s, %s changed after the change being reverted: %w", op, change.Resource, err)
+	}
+
+	switch change.Resource {
+	case ResourceHostname:
+		return m.revertHostname(ctx, change.Backup)
+	case ResourceResolvConf:
+		return m.revertResolvConf(ctx, change.Backup)
+	case ResourceHosts:
+		return m.revertHosts(ctx, change.Backup)
+	default:
+		return fmt.Errorf("op: %s, unknown resource %q", op, change.Resource)
+	}
 }
 
 func (m *FileSystemHostManager) findDNSServer(file *os.File, server string) error {
@@ -169,7 +307,7 @@ func (m *FileSystemHostManager) findDNSServer(file *os.File, server string) erro
 		if strings.HasPrefix(line, "nameserver") {
 			fields := strings.Fields(line)
 			if len(fields) >= 2 && fields[1] == server {
-				return fmt.Errorf("op: %s, DNS server %s already exists", op, server)
+				return fmt.Errorf("op: %s, %s: %w", op, server, ErrDNSServerExists)
 			}
 		}
 	}
@@ -181,58 +319,91 @@ func (m *FileSystemHostManager) findDNSServer(file *os.File, server string) erro
 	return nil
 }
 
-func (m *FileSystemHostManager) AddDNSServer(ctx context.Context, server string) error {
+func (m *FileSystemHostManager) AddDNSServer(ctx context.Context, server string, expectedVersion string) (Change, error) {
 	const op = "AddDNSServer"
-	l := log.With().Str("op", op).Logger()
+	l := zerolog.Ctx(ctx).With().Str("op", op).Logger()
 
 	l.Info().Str("server", server).Msg("Adding DNS server")
 
-	backupFileName, err := m.backupResolvConf()
-	if err != nil {
-		return err
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := checkVersion(m.files.ResolvConf, expectedVersion); err != nil {
+		return Change{}, err
 	}
 
-	file, err := os.Open(resolvConfPath)
+	if err := m.check(ctx); err != nil {
+		return Change{}, err
+	}
+
+	file, err := os.Open(m.files.ResolvConf)
 	if err != nil {
-		return fmt.Errorf("op: %s, failed to open %s: %w", op, resolvConfPath, err)
+		return Change{}, fmt.Errorf("op: %s, failed to open %s: %w", op, m.files.ResolvConf, err)
 	}
 	defer closeFile(file)
 
 	if err = m.findDNSServer(file, server); err != nil {
-		return err
+		return Change{}, err
+	}
+
+	if dryRun(ctx) {
+		return unchanged(ResourceResolvConf, m.files.ResolvConf)
+	}
+
+	backupFileName, err := m.backupResolvConf(ctx)
+	if err != nil {
+		return Change{}, err
 	}
 
-	file, err = os.OpenFile(resolvConfPath, os.O_APPEND|os.O_WRONLY, 0644)
+	file, err = os.OpenFile(m.files.ResolvConf, os.O_APPEND|os.O_WRONLY, 0644)
 	if err != nil {
-		return fmt.Errorf("op: %s, failed to open %s: %w", op, resolvConfPath, err)
+		return Change{}, fmt.Errorf("op: %s, failed to open %s: %w", op, m.files.ResolvConf, err)
 	}
 	defer closeFile(file)
 
-	if _, err := file.WriteString(fmt.Sprintf("nameserver %s\n", server)); err != nil {
-		if revertErr := m.revertResolvConf(backupFileName); revertErr != nil {
+	_, span := tracing.Start(ctx, "write "+m.files.ResolvConf)
+	_, err = file.WriteString(fmt.Sprintf("nameserver %s\n", server))
+	tracing.End(span, err)
+	if err != nil {
+		revertErr := m.revertResolvConf(ctx, backupFileName)
+		metrics.RollbackDone(string(ResourceResolvConf), revertErr)
+		if revertErr != nil {
 			l.Error().Err(revertErr).Msg("Failed to revert")
 		}
-		return fmt.Errorf("op: %s, failed to write to %s: %w", op, resolvConfPath, err)
+		return Change{}, fmt.Errorf("op: %s, failed to write to %s: %w", op, m.files.ResolvConf, err)
 	}
 
+	m.wrote(ResourceResolvConf, m.files.ResolvConf)
 	l.Info().Str("server", server).Msg("DNS server added successfully")
-	return nil
+	version, err := fileVersion(m.files.ResolvConf)
+	if err != nil {
+		return Change{}, err
+	}
+
+	metrics.MutationApplied("add-dns-server")
+	return Change{Resource: ResourceResolvConf, Version: version, Backup: backupFileName}, nil
 }
 
-func (m *FileSystemHostManager) RemoveDNSServer(ctx context.Context, server string) error {
+func (m *FileSystemHostManager) RemoveDNSServer(ctx context.Context, server string, expectedVersion string) (Change, error) {
 	const op = "RemoveDNSServer"
-	l := log.With().Str("op", op).Logger()
+	l := zerolog.Ctx(ctx).With().Str("op", op).Logger()
 
 	l.Info().Str("server", server).Msg("Removing DNS server")
 
-	backupFileName, err := m.backupResolvConf()
-	if err != nil {
-		return err
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := checkVersion(m.files.ResolvConf, expectedVersion); err != nil {
+		return Change{}, err
+	}
+
+	if err := m.check(ctx); err != nil {
+		return Change{}, err
 	}
 
-	file, err := os.Open(resolvConfPath)
+	file, err := os.Open(m.files.ResolvConf)
 	if err != nil {
-		return fmt.Errorf("op: %s, failed to open %s: %w", op, resolvConfPath, err)
+		return Change{}, fmt.Errorf("op: %s, failed to open %s: %w", op, m.files.ResolvConf, err)
 	}
 	defer closeFile(file)
 
@@ -249,54 +420,58 @@ func (m *FileSystemHostManager) RemoveDNSServer(ctx context.Context, server stri
 	}
 
 	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("op: %s, error reading %s: %w", op, resolvConfPath, err)
+		return Change{}, fmt.Errorf("op: %s, error reading %s: %w", op, m.files.ResolvConf, err)
 	}
 
 	if !existServer {
-		return fmt.Errorf("op: %s, DNS server %s does not exist", op, server)
+		return Change{}, fmt.Errorf("op: %s, %s: %w", op, server, ErrDNSServerNotFound)
 	}
 
-	file, err = os.OpenFile(resolvConfPath, os.O_TRUNC|os.O_WRONLY, 0644)
-	if err != nil {
-		return fmt.Errorf("op: %s, failed to open %s for writing: %w", op, resolvConfPath, err)
+	if dryRun(ctx) {
+		return unchanged(ResourceResolvConf, m.files.ResolvConf)
 	}
-	defer closeFile(file)
 
-	writer := bufio.NewWriter(file)
-	for _, line := range lines {
-		if _, err := writer.WriteString(line + "\n"); err != nil {
-			if revertErr := m.revertResolvConf(backupFileName); revertErr != nil {
-				l.Error().Err(revertErr).Msg("Failed to revert")
-			}
-			return fmt.Errorf("op: %s, failed to write to %s: %w", op, resolvConfPath, err)
-		}
+	backupFileName, err := m.backupResolvConf(ctx)
+	if err != nil {
+		return Change{}, err
 	}
 
-	if err := writer.Flush(); err != nil {
-		if revertErr := m.revertResolvConf(backupFileName); revertErr != nil {
+	_, span := tracing.Start(ctx, "write "+m.files.ResolvConf)
+	err = writeLines(m.files.ResolvConf, lines)
+	tracing.End(span, err)
+	if err != nil {
+		revertErr := m.revertResolvConf(ctx, backupFileName)
+		metrics.RollbackDone(string(ResourceResolvConf), revertErr)
+		if revertErr != nil {
 			l.Error().Err(revertErr).Msg("Failed to revert")
 		}
-		return fmt.Errorf("op: %s, failed to flush writes to %s: %w", op, resolvConfPath, err)
+		return Change{}, fmt.Errorf("op: %s, %w", op, err)
 	}
 
+	m.wrote(ResourceResolvConf, m.files.ResolvConf)
 	l.Info().Str("server", server).Msg("DNS server removed successfully")
-	return nil
+	version, err := fileVersion(m.files.ResolvConf)
+	if err != nil {
+		return Change{}, err
+	}
+
+	metrics.MutationApplied("remove-dns-server")
+	return Change{Resource: ResourceResolvConf, Version: version, Backup: backupFileName}, nil
 }
 
-func (m *FileSystemHostManager) ListDNSServers(ctx context.Context) ([]string, error) {
+func (m *FileSystemHostManager) ListDNSServers(ctx context.Context) ([]string, string, error) {
 	const op = "ListDNSServers"
-	l := log.With().Str("op", op).Logger()
+	l := zerolog.Ctx(ctx).With().Str("op", op).Logger()
 
 	l.Info().Msg("Listing DNS servers")
 
-	file, err := os.Open(resolvConfPath)
+	data, err := os.ReadFile(m.files.ResolvConf)
 	if err != nil {
-		return nil, fmt.Errorf("op: %s, failed to open %s: %w", op, resolvConfPath, err)
+		return nil, "", fmt.Errorf("op: %s, failed to read %s: %w", op, m.files.ResolvConf, err)
 	}
-	defer closeFile(file)
 
 	var dnsServers []string
-	scanner := bufio.NewScanner(file)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
 	for scanner.Scan() {
 		line := scanner.Text()
 		if strings.HasPrefix(line, "nameserver") {
@@ -308,11 +483,48 @@ func (m *FileSystemHostManager) ListDNSServers(ctx context.Context) ([]string, e
 	}
 
 	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("op: %s, error reading %s: %w", op, resolvConfPath, err)
+		return nil, "", fmt.Errorf("op: %s, error reading %s: %w", op, m.files.ResolvConf, err)
 	}
 
 	l.Info().Int("count", len(dnsServers)).Msg("Listed DNS servers successfully")
-	return dnsServers, nil
+	return dnsServers, contentVersion(data), nil
+}
+
+// writeLines replaces the contents of path with lines.
+func writeLines(path string, lines []string) error {
+	file, err := os.OpenFile(path, os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s for writing: %w", path, err)
+	}
+	defer closeFile(file)
+
+	writer := bufio.NewWriter(file)
+	for _, line := range lines {
+		if _, err := writer.WriteString(line + "\n"); err != nil {
+			return fmt.Errorf("failed to write to %s: %w", path, err)
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("failed to flush writes to %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// createBackup creates a new backup file for name in dir. The name carries the
+// time in nanoseconds and a random suffix, so concurrent changes never share a
+// backup file.
+func createBackup(dir, name string) (*os.File, error) {
+	f, err := os.CreateTemp(dir, fmt.Sprintf("%s-%d-*", name, time.Now().UnixNano()))
+	if err != nil {
+		return nil, err
+	}
+	if err := f.Chmod(0644); err != nil {
+		closeFile(f)
+		return nil, err
+	}
+	return f, nil
 }
 
 func closeFile(f *os.File) {
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// TestSetHostnameRevert checks that a change failing part way is reverted and
+// counted as a rollback.
+func TestSetHostnameRevert(t *testing.T) {
+	manager, _, _ := newTestManager(t)
+
+	// Make the hostname command fail for the new hostname only, after the
+	// backup was taken, so that restoring the old one works.
+	bin, err := exec.LookPath("hostname")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Dir(bin) == "/bin" || filepath.Dir(bin) == "/usr/bin" {
+		t.Fatalf("hostname resolves to the system command %s", bin)
+	}
+	if err := os.WriteFile(bin, []byte("#!/bin/sh\n[ \"$1\" = new-host ] && exit 1\nexit 0\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	labels := map[string]string{"resource": string(ResourceHostname), "result": "success"}
+	before := counterValue(t, "host_manager_rollbacks_total", labels)
+
+	if _, err := manager.SetHostname(context.Background(), "new-host", ""); err == nil {
+		t.Fatal("SetHostname() succeeded with a failing hostname command")
+	}
+
+	data, err := os.ReadFile(manager.files.Hostname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "host\n" {
+		t.Errorf("hostname file = %q, want the previous content", data)
+	}
+	if got := counterValue(t, "host_manager_rollbacks_total", labels); got != before+1 {
+		t.Errorf("rollbacks = %v, want %v", got, before+1)
+	}
+}
+
+// TestBackupNamesUnique checks that backups taken within the same second do
+// not overwrite each other.
+func TestBackupNamesUnique(t *testing.T) {
+	manager, _, _ := newTestManager(t)
+	ctx := context.Background()
+
+	backups := map[string]bool{}
+	for i := 0; i < 10; i++ {
+		for _, backup := range []func(context.Context) (string, error){manager.backupHostname, manager.backupResolvConf, manager.backupHosts} {
+			name, err := backup(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if backups[name] {
+				t.Fatalf("backup %s was taken twice", name)
+			}
+			backups[name] = true
+		}
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(manager.BackupConfig().BackupHostnameFilePath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != len(backups) {
+		t.Errorf("found %d backup files, want %d", len(entries), len(backups))
+	}
+}
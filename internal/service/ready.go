@@ -0,0 +1,44 @@
+package service
+
+import (
+	"fmt"
+	"os"
+
+	"hostManager/internal/config"
+)
+
+// Ready reports whether changes can be applied: the managed files must be
+// readable and writable, which fails on a read-only /etc, and the backup
+// directories must exist.
+func (m *FileSystemHostManager) Ready() error {
+	const op = "Ready"
+
+	for _, path := range []string{m.files.Hostname, m.files.ResolvConf} {
+		file, err := os.OpenFile(path, os.O_RDWR, 0)
+		if err != nil {
+			return fmt.Errorf("op: %s, %s is not writable: %w", op, path, err)
+		}
+		closeFile(file)
+	}
+
+	if err := CheckBackupDirs(m.BackupConfig()); err != nil {
+		return fmt.Errorf("op: %s, %w", op, err)
+	}
+
+	return nil
+}
+
+// CheckBackupDirs reports whether the backup directories of cfg exist.
+func CheckBackupDirs(cfg config.BackupConfig) error {
+	for _, dir := range []string{cfg.BackupHostnameFilePath, cfg.BackupDNSFilePath, cfg.BackupHostsFilePath} {
+		info, err := os.Stat(dir)
+		if err != nil {
+			return fmt.Errorf("backup dir: %w", err)
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("backup dir %s is not a directory", dir)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rs/zerolog"
+
+	"hostManager/internal/metrics"
+	"hostManager/internal/tracing"
+)
+
+func (m *FileSystemHostManager) GetResolverConfig(ctx context.Context) (ResolverConfig, string, error) {
+	const op = "GetResolverConfig"
+
+	data, err := os.ReadFile(m.files.ResolvConf)
+	if err != nil {
+		return ResolverConfig{}, "", fmt.Errorf("op: %s, failed to read %s: %w", op, m.files.ResolvConf, err)
+	}
+
+	lines, err := readLines(data)
+	if err != nil {
+		return ResolverConfig{}, "", fmt.Errorf("op: %s, error reading %s: %w", op, m.files.ResolvConf, err)
+	}
+
+	return parseResolverConfig(lines), contentVersion(data), nil
+}
+
+func (m *FileSystemHostManager) UpdateResolverConfig(ctx context.Context, cfg ResolverConfig, expectedVersion string) (Change, error) {
+	const op = "UpdateResolverConfig"
+	l := zerolog.Ctx(ctx).With().Str("op", op).Logger()
+
+	l.Info().Strs("nameservers", cfg.Nameservers).Strs("search", cfg.Search).Strs("options", cfg.Options).
+		Msg("Updating resolver config")
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := checkVersion(m.files.ResolvConf, expectedVersion); err != nil {
+		return Change{}, err
+	}
+
+	if err := m.check(ctx); err != nil {
+		return Change{}, err
+	}
+
+	data, err := os.ReadFile(m.files.ResolvConf)
+	if err != nil {
+		return Change{}, fmt.Errorf("op: %s, failed to read %s: %w", op, m.files.ResolvConf, err)
+	}
+
+	lines, err := readLines(data)
+	if err != nil {
+		return Change{}, fmt.Errorf("op: %s, error reading %s: %w", op, m.files.ResolvConf, err)
+	}
+
+	if dryRun(ctx) {
+		return unchanged(ResourceResolvConf, m.files.ResolvConf)
+	}
+
+	backupFileName, err := m.backupResolvConf(ctx)
+	if err != nil {
+		return Change{}, err
+	}
+
+	_, span := tracing.Start(ctx, "write "+m.files.ResolvConf)
+	err = writeLines(m.files.ResolvConf, replaceResolverConfig(lines, cfg))
+	tracing.End(span, err)
+	if err != nil {
+		revertErr := m.revertResolvConf(ctx, backupFileName)
+		metrics.RollbackDone(string(ResourceResolvConf), revertErr)
+		if revertErr != nil {
+			l.Error().Err(revertErr).Msg("Failed to revert")
+		}
+		return Change{}, fmt.Errorf("op: %s, %w", op, err)
+	}
+
+	m.wrote(ResourceResolvConf, m.files.ResolvConf)
+	l.Info().Msg("Resolver config updated successfully")
+	version, err := fileVersion(m.files.ResolvConf)
+	if err != nil {
+		return Change{}, err
+	}
+
+	metrics.MutationApplied("update-resolver-config")
+	return Change{Resource: ResourceResolvConf, Version: version, Backup: backupFileName}, nil
+}
+
+func readLines(data []byte) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	return lines, scanner.Err()
+}
+
+// resolverKeyword returns the keyword of a resolv.conf line managed through
+// ResolverConfig, or "" for any other line.
+func resolverKeyword(line string) string {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return ""
+	}
+
+	switch fields[0] {
+	case "nameserver", "search", "options":
+		return fields[0]
+	default:
+		return ""
+	}
+}
+
+// parseResolverConfig reads the resolver configuration the way the resolver
+// does: the last search line wins and options lines add up.
+func parseResolverConfig(lines []string) ResolverConfig {
+	var cfg ResolverConfig
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		switch resolverKeyword(line) {
+		case "nameserver":
+			if len(fields) >= 2 {
+				cfg.Nameservers = append(cfg.Nameservers, fields[1])
+			}
+		case "search":
+			cfg.Search = fields[1:]
+		case "options":
+			cfg.Options = append(cfg.Options, fields[1:]...)
+		}
+	}
+
+	return cfg
+}
+
+// Lines returns cfg as the lines of resolv.conf it is written as.
+func (cfg ResolverConfig) Lines() []string {
+	var lines []string
+	if len(cfg.Search) > 0 {
+		lines = append(lines, "search "+strings.Join(cfg.Search, " "))
+	}
+	for _, server := range cfg.Nameservers {
+		lines = append(lines, "nameserver "+server)
+	}
+	if len(cfg.Options) > 0 {
+		lines = append(lines, "options "+strings.Join(cfg.Options, " "))
+	}
+
+	return lines
+}
+
+// replaceResolverConfig writes cfg where the first managed line of lines was,
+// or at the end, and keeps all other lines as they are.
+func replaceResolverConfig(lines []string, cfg ResolverConfig) []string {
+	managed := cfg.Lines()
+
+	var (
+		out      []string
+		replaced bool
+	)
+	for _, line := range lines {
+		if resolverKeyword(line) == "" {
+			out = append(out, line)
+			continue
+		}
+		if !replaced {
+			out = append(out, managed...)
+			replaced = true
+		}
+	}
+	if !replaced {
+		out = append(out, managed...)
+	}
+
+	return out
+}
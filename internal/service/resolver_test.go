@@ -0,0 +1,115 @@
+package service
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseResolverConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		conf string
+		want ResolverConfig
+	}{
+		{name: "empty", conf: "", want: ResolverConfig{}},
+		{
+			name: "all keywords",
+			conf: "# comment\nsearch a.example b.example\nnameserver 192.0.2.1\nnameserver 2001:db8::1\noptions ndots:2 rotate\n",
+			want: ResolverConfig{
+				Nameservers: []string{"192.0.2.1", "2001:db8::1"},
+				Search:      []string{"a.example", "b.example"},
+				Options:     []string{"ndots:2", "rotate"},
+			},
+		},
+		{
+			name: "last search line wins",
+			conf: "search a.example\nsearch b.example c.example\n",
+			want: ResolverConfig{Search: []string{"b.example", "c.example"}},
+		},
+		{
+			name: "options lines add up",
+			conf: "options ndots:2\noptions timeout:1\n",
+			want: ResolverConfig{Options: []string{"ndots:2", "timeout:1"}},
+		},
+		{
+			name: "whitespace and incomplete lines",
+			conf: "  nameserver\t192.0.2.1  \nnameserver\ndomain example.com\nnameservers 192.0.2.9\n",
+			want: ResolverConfig{Nameservers: []string{"192.0.2.1"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, err := readLines([]byte(tt.conf))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := parseResolverConfig(lines)
+			if !slices.Equal(got.Nameservers, tt.want.Nameservers) || !slices.Equal(got.Search, tt.want.Search) ||
+				!slices.Equal(got.Options, tt.want.Options) {
+				t.Errorf("parseResolverConfig() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplaceResolverConfig(t *testing.T) {
+	cfg := ResolverConfig{
+		Nameservers: []string{"2001:db8::1", "192.0.2.2"},
+		Search:      []string{"example.org"},
+		Options:     []string{"rotate"},
+	}
+
+	tests := []struct {
+		name string
+		conf string
+		cfg  ResolverConfig
+		want string
+	}{
+		{
+			name: "replaces managed lines in place",
+			conf: "# head\nsearch example.com\nnameserver 192.0.2.1\n# tail\noptions ndots:2\n",
+			cfg:  cfg,
+			want: "# head\nsearch example.org\nnameserver 2001:db8::1\nnameserver 192.0.2.2\noptions rotate\n# tail\n",
+		},
+		{
+			name: "appends to a file without managed lines",
+			conf: "# only a comment\ndomain example.com\n",
+			cfg:  cfg,
+			want: "# only a comment\ndomain example.com\nsearch example.org\nnameserver 2001:db8::1\nnameserver 192.0.2.2\noptions rotate\n",
+		},
+		{
+			name: "empty config removes managed lines",
+			conf: "nameserver 192.0.2.1\n# kept\nsearch example.com\n",
+			cfg:  ResolverConfig{},
+			want: "# kept\n",
+		},
+		{
+			name: "omits empty search and options",
+			conf: "search example.com\noptions ndots:2\nnameserver 192.0.2.1\n",
+			cfg:  ResolverConfig{Nameservers: []string{"192.0.2.3"}},
+			want: "nameserver 192.0.2.3\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, err := readLines([]byte(tt.conf))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := replaceResolverConfig(lines, tt.cfg)
+			if got := strings.Join(got, "\n") + "\n"; got != tt.want {
+				t.Errorf("replaceResolverConfig() =\n%s\nwant\n%s", got, tt.want)
+			}
+
+			if parsed := parseResolverConfig(got); !slices.Equal(parsed.Nameservers, tt.cfg.Nameservers) ||
+				!slices.Equal(parsed.Search, tt.cfg.Search) || !slices.Equal(parsed.Options, tt.cfg.Options) {
+				t.Errorf("replaced config parses as %+v, want %+v", parsed, tt.cfg)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+)
+
+// fileVersion returns the content hash used as the version token of a managed file.
+func fileVersion(path string) (string, error) {
+	const op = "fileVersion"
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("op: %s, failed to read %s: %w", op, path, err)
+	}
+
+	return contentVersion(data), nil
+}
+
+func contentVersion(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// checkVersion compares the current version of path with the expected one.
+// An empty expected version disables the check.
+func checkVersion(path, expected string) error {
+	const op = "checkVersion"
+
+	if expected == "" {
+		return nil
+	}
+
+	current, err := fileVersion(path)
+	if err != nil {
+		return err
+	}
+
+	if current != expected {
+		return fmt.Errorf("op: %s, %s: %w: expected %s, current %s", op, path, ErrVersionMismatch, expected, current)
+	}
+
+	return nil
+}
@@ -0,0 +1,328 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog/log"
+
+	"hostManager/internal/config"
+	"hostManager/internal/metrics"
+)
+
+// settleDelay lets a change that takes several writes, like a truncate
+// followed by a write, finish before the file is read.
+const settleDelay = 100 * time.Millisecond
+
+// subscriberBuffer is how many events a subscriber may fall behind before it
+// is dropped. Dropped subscribers resume from the watcher's buffer.
+const subscriberBuffer = 64
+
+// HostEvent is a change of a watched file.
+type HostEvent struct {
+	ID       uint64
+	Resource Resource
+	// Version is the content version of the file after the change, empty if
+	// the file was removed.
+	Version string
+	Time    time.Time
+	// Hostname is set for ResourceHostname, DNSServers for ResourceResolvConf.
+	Hostname   string
+	DNSServers []string
+	// Snapshot events report the current state of a resource rather than a
+	// change. They are sent when a subscriber resumes from an event that is
+	// no longer buffered.
+	Snapshot bool
+}
+
+// Watcher publishes changes of the hostname, resolv.conf and hosts files,
+// whether made through the API or externally, and keeps the latest events so
+// subscribers can resume after a disconnect. External changes are counted as
+// drift.
+type Watcher struct {
+	files   map[string]Resource
+	size    int
+	watcher *fsnotify.Watcher
+
+	mu       sync.Mutex
+	events   []HostEvent
+	nextID   uint64
+	versions map[Resource]string
+	// expected holds the version the server last wrote to each resource.
+	// Other versions are external changes.
+	expected map[Resource]string
+	pending  map[Resource]*time.Timer
+	subs     map[chan HostEvent]struct{}
+	stopped  bool
+}
+
+// NewWatcher creates a watcher of files keeping the last size events.
+func NewWatcher(files config.HostFilesConfig, size int) *Watcher {
+	return &Watcher{
+		files: map[string]Resource{
+			filepath.Clean(files.Hostname):   ResourceHostname,
+			filepath.Clean(files.ResolvConf): ResourceResolvConf,
+			filepath.Clean(files.Hosts):      ResourceHosts,
+		},
+		size: max(size, 1),
+		// Event IDs start at the current time so that IDs from before a
+		// restart are never mistaken for new ones.
+		nextID:   uint64(time.Now().UnixMicro()),
+		versions: make(map[Resource]string),
+		expected: make(map[Resource]string),
+		pending:  make(map[Resource]*time.Timer),
+		subs:     make(map[chan HostEvent]struct{}),
+	}
+}
+
+func (w *Watcher) Start() error {
+	const op = "Watcher.Start"
+
+	for path, resource := range w.files {
+		event, err := readEvent(path, resource)
+		if err != nil {
+			return fmt.Errorf("op: %s, %w", op, err)
+		}
+		w.versions[resource] = event.Version
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("op: %s, failed to create watcher: %w", op, err)
+	}
+
+	// Watch the directories rather than the files, as editors and tools often
+	// replace files by renaming, which drops watches on the old inode.
+	dirs := make(map[string]struct{})
+	for path := range w.files {
+		dirs[filepath.Dir(path)] = struct{}{}
+	}
+	for dir := range dirs {
+		if err := watcher.Add(dir); err != nil {
+			_ = watcher.Close()
+			return fmt.Errorf("op: %s, failed to watch %s: %w", op, dir, err)
+		}
+	}
+
+	w.watcher = watcher
+	go w.watch()
+
+	return nil
+}
+
+// Stop stops watching and ends all subscriptions.
+func (w *Watcher) Stop() {
+	const op = "Watcher.Stop"
+
+	w.mu.Lock()
+	if w.stopped {
+		w.mu.Unlock()
+		return
+	}
+	w.stopped = true
+
+	for _, timer := range w.pending {
+		timer.Stop()
+	}
+	for ch := range w.subs {
+		close(ch)
+		delete(w.subs, ch)
+	}
+	w.mu.Unlock()
+
+	if w.watcher != nil {
+		if err := w.watcher.Close(); err != nil {
+			log.Warn().Str("op", op).Err(err).Msg("Failed to close file watcher")
+		}
+	}
+}
+
+// Expect records that the server wrote version to resource, so the change is
+// not counted as drift.
+func (w *Watcher) Expect(resource Resource, version string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.expected[resource] = version
+}
+
+// Subscribe returns the events after afterID followed by a channel of new
+// events, and a function that ends the subscription. An afterID of zero
+// subscribes to new events only. If events after afterID are no longer
+// buffered, the current state of every resource is returned as snapshot
+// events instead. The channel is closed when the subscriber falls too far
+// behind or the watcher stops.
+func (w *Watcher) Subscribe(afterID uint64) ([]HostEvent, <-chan HostEvent, func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	ch := make(chan HostEvent, subscriberBuffer)
+	if w.stopped {
+		close(ch)
+	} else {
+		w.subs[ch] = struct{}{}
+	}
+
+	cancel := func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+
+		if _, ok := w.subs[ch]; ok {
+			delete(w.subs, ch)
+			close(ch)
+		}
+	}
+
+	return w.replay(afterID), ch, cancel
+}
+
+func (w *Watcher) replay(afterID uint64) []HostEvent {
+	const op = "Watcher.replay"
+
+	last := w.nextID - 1
+	if afterID == 0 || afterID == last {
+		return nil
+	}
+
+	if len(w.events) > 0 && afterID >= w.events[0].ID-1 && afterID < last {
+		var events []HostEvent
+		for _, event := range w.events {
+			if event.ID > afterID {
+				events = append(events, event)
+			}
+		}
+		return events
+	}
+
+	var events []HostEvent
+	for path, resource := range w.files {
+		event, err := readEvent(path, resource)
+		if err != nil {
+			log.Error().Str("op", op).Err(err).Msg("Failed to read snapshot")
+			continue
+		}
+		event.ID = last
+		event.Snapshot = true
+		events = append(events, event)
+	}
+	slices.SortFunc(events, func(a, b HostEvent) int { return strings.Compare(string(a.Resource), string(b.Resource)) })
+
+	return events
+}
+
+func (w *Watcher) watch() {
+	const op = "Watcher.watch"
+	l := log.With().Str("op", op).Logger()
+
+	for {
+		select {
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			resource, ok := w.files[event.Name]
+			if !ok {
+				continue
+			}
+
+			w.mu.Lock()
+			if timer, ok := w.pending[resource]; ok {
+				timer.Reset(settleDelay)
+			} else if !w.stopped {
+				w.pending[resource] = time.AfterFunc(settleDelay, func() { w.publish(event.Name, resource) })
+			}
+			w.mu.Unlock()
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			l.Error().Err(err).Msg("File watcher failed")
+		}
+	}
+}
+
+// publish reads path and sends an event if its content changed.
+func (w *Watcher) publish(path string, resource Resource) {
+	const op = "Watcher.publish"
+	l := log.With().Str("op", op).Logger()
+
+	event, err := readEvent(path, resource)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	delete(w.pending, resource)
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to read changed file")
+		return
+	}
+	if w.stopped || w.versions[resource] == event.Version {
+		return
+	}
+	w.versions[resource] = event.Version
+
+	external := event.Version != w.expected[resource]
+	if external {
+		metrics.DriftDetected(string(resource))
+	}
+
+	event.ID = w.nextID
+	w.nextID++
+	w.events = append(w.events, event)
+	if len(w.events) > w.size {
+		w.events = w.events[len(w.events)-w.size:]
+	}
+
+	for ch := range w.subs {
+		select {
+		case ch <- event:
+		default:
+			delete(w.subs, ch)
+			close(ch)
+		}
+	}
+
+	if external {
+		l.Warn().Uint64("id", event.ID).Str("resource", string(resource)).Str("version", event.Version).
+			Msg("Host file changed externally")
+		return
+	}
+
+	l.Info().Uint64("id", event.ID).Str("resource", string(resource)).Str("version", event.Version).
+		Msg("Host file changed")
+}
+
+// readEvent reads the current state of a watched file. A missing file has an
+// empty version.
+func readEvent(path string, resource Resource) (HostEvent, error) {
+	event := HostEvent{Resource: resource, Time: time.Now()}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return event, nil
+	}
+	if err != nil {
+		return HostEvent{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	event.Version = contentVersion(data)
+
+	switch resource {
+	case ResourceHostname:
+		event.Hostname = strings.TrimSpace(string(data))
+	case ResourceResolvConf:
+		lines, err := readLines(data)
+		if err != nil {
+			return HostEvent{}, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		event.DNSServers = parseResolverConfig(lines).Nameservers
+	}
+
+	return event, nil
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestWatcherDrift(t *testing.T) {
+	manager, _, _ := newTestManager(t)
+
+	watcher := NewWatcher(manager.files, 10)
+	if err := watcher.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Stop()
+	manager.OnWrite(watcher.Expect)
+
+	_, events, cancel := watcher.Subscribe(0)
+	defer cancel()
+
+	// next waits for the event of resource, skipping intermediate states of
+	// the file.
+	next := func(resource Resource) {
+		t.Helper()
+
+		timeout := time.After(5 * time.Second)
+		for {
+			select {
+			case event := <-events:
+				if event.Resource == resource {
+					return
+				}
+			case <-timeout:
+				t.Fatalf("no event for %s", resource)
+			}
+		}
+	}
+
+	resolvDrifts := driftCount(t, ResourceResolvConf)
+	hostsDrifts := driftCount(t, ResourceHosts)
+
+	if _, err := manager.AddDNSServer(context.Background(), "192.0.2.2", ""); err != nil {
+		t.Fatal(err)
+	}
+	next(ResourceResolvConf)
+	if got := driftCount(t, ResourceResolvConf); got != resolvDrifts {
+		t.Errorf("drift after an API change = %v, want %v", got, resolvDrifts)
+	}
+
+	if err := os.WriteFile(manager.files.ResolvConf, []byte("nameserver 192.0.2.3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	next(ResourceResolvConf)
+	if got := driftCount(t, ResourceResolvConf); got != resolvDrifts+1 {
+		t.Errorf("drift after an external change = %v, want %v", got, resolvDrifts+1)
+	}
+
+	if err := os.WriteFile(manager.files.Hosts, []byte("192.0.2.4 host\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	next(ResourceHosts)
+	if got := driftCount(t, ResourceHosts); got != hostsDrifts+1 {
+		t.Errorf("hosts drift = %v, want %v", got, hostsDrifts+1)
+	}
+}
+
+func driftCount(t *testing.T, resource Resource) float64 {
+	t.Helper()
+	return counterValue(t, "host_manager_drift_events_total", map[string]string{"resource": string(resource)})
+}
+
+// counterValue reads the counter of the default registry with the given name
+// and labels, zero if it was never incremented.
+func counterValue(t *testing.T, name string, labels map[string]string) float64 {
+	t.Helper()
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			matched := 0
+			for _, label := range metric.GetLabel() {
+				if value, ok := labels[label.GetName()]; ok && value == label.GetValue() {
+					matched++
+				}
+			}
+			if matched == len(labels) {
+				return metric.GetCounter().GetValue()
+			}
+		}
+	}
+
+	return 0
+}
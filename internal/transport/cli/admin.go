@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"hostManager/internal/transport/client"
+)
+
+var (
+	freezeReason string
+	freezeTTL    time.Duration
+
+	logLevelTTL time.Duration
+	logsLevel   string
+	logsLines   int
+	logsFollow  bool
+	logsJSON    bool
+)
+
+var adminCmd = &cobra.Command{
+	Use:   "admin",
+	Short: "administer the host-manager server",
+}
+
+var adminFreeze = &cobra.Command{
+	Use:   "freeze",
+	Short: "reject all changes until unfrozen",
+	Run: func(cmd *cobra.Command, args []string) {
+		setFreeze(true)
+	},
+}
+
+var adminUnfreeze = &cobra.Command{
+	Use:   "unfreeze",
+	Short: "lift the change freeze",
+	Run: func(cmd *cobra.Command, args []string) {
+		setFreeze(false)
+	},
+}
+
+var adminStatus = &cobra.Command{
+	Use:   "status",
+	Short: "show server status",
+	Run: func(cmd *cobra.Command, args []string) {
+		defer func() {
+			if err := gRPCClient.Close(); err != nil {
+				log.Fatal().Msg("failed to close gRPC cli")
+			}
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), TTL)
+		defer cancel()
+
+		status, err := gRPCClient.GetStatus(ctx)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to get status")
+		}
+
+		printFreeze(status.Freeze)
+		printLogLevel(status.LogLevel)
+	},
+}
+
+var adminLogLevel = &cobra.Command{
+	Use:   "log-level [level]",
+	Short: "show or change the server log level",
+	Long: "Without a level, shows the server log level. With one of trace, debug, info, warn or error,\n" +
+		"changes it until changed again, or until --ttl has passed.",
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		defer func() {
+			if err := gRPCClient.Close(); err != nil {
+				log.Fatal().Msg("failed to close gRPC cli")
+			}
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), TTL)
+		defer cancel()
+
+		if len(args) == 0 {
+			status, err := gRPCClient.GetStatus(ctx)
+			if err != nil {
+				log.Fatal().Err(err).Msg("failed to get status")
+			}
+			printLogLevel(status.LogLevel)
+			return
+		}
+
+		level, err := gRPCClient.SetLogLevel(ctx, args[0], logLevelTTL)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to set log level")
+		}
+
+		printLogLevel(level)
+	},
+}
+
+var adminLogs = &cobra.Command{
+	Use:   "logs",
+	Short: "show recent server log entries",
+	Run: func(cmd *cobra.Command, args []string) {
+		defer func() {
+			if err := gRPCClient.Close(); err != nil {
+				log.Fatal().Msg("failed to close gRPC cli")
+			}
+		}()
+
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+		if !logsFollow {
+			ctx, cancel = context.WithTimeout(ctx, TTL)
+			defer cancel()
+		}
+
+		out := io.Writer(zerolog.ConsoleWriter{Out: os.Stdout, TimeFormat: time.RFC3339})
+		if logsJSON {
+			out = os.Stdout
+		}
+
+		err := gRPCClient.TailLogs(ctx, logsLevel, logsLines, logsFollow, func(entry client.LogEntry) error {
+			_, err := fmt.Fprintln(out, entry.JSON)
+			return err
+		})
+		if err != nil && ctx.Err() == nil {
+			log.Fatal().Err(err).Msg("failed to tail logs")
+		}
+	},
+}
+
+func setFreeze(frozen bool) {
+	defer func() {
+		if err := gRPCClient.Close(); err != nil {
+			log.Fatal().Msg("failed to close gRPC cli")
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), TTL)
+	defer cancel()
+
+	state, err := gRPCClient.SetFreeze(ctx, frozen, freezeReason, freezeTTL)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to set freeze")
+	}
+
+	printFreeze(state)
+}
+
+func printFreeze(state client.FreezeState) {
+	if !state.Frozen {
+		fmt.Println("not frozen")
+		return
+	}
+
+	fmt.Printf("frozen since %s: %s\n", state.Since.Local().Format(time.RFC3339), state.Reason)
+	if !state.Until.IsZero() {
+		fmt.Printf("lifts at %s\n", state.Until.Local().Format(time.RFC3339))
+	}
+}
+
+func printLogLevel(level client.LogLevel) {
+	fmt.Printf("log level %s", level.Level)
+	if level.Level != level.Default {
+		fmt.Printf(" (configured %s", level.Default)
+		if !level.RevertAt.IsZero() {
+			fmt.Printf(", reverts at %s", level.RevertAt.Local().Format(time.RFC3339))
+		}
+		fmt.Print(")")
+	}
+	fmt.Println()
+}
+
+func init() {
+	adminFreeze.Flags().StringVar(&freezeReason, "reason", "", "why changes are frozen")
+	adminFreeze.Flags().DurationVar(&freezeTTL, "ttl", 0, "lift the freeze after this time")
+	_ = adminFreeze.MarkFlagRequired("reason")
+
+	adminCmd.AddCommand(adminFreeze)
+	adminCmd.AddCommand(adminUnfreeze)
+	adminLogLevel.Flags().DurationVar(&logLevelTTL, "ttl", 0, "revert to the configured level after this time")
+
+	adminLogs.Flags().StringVar(&logsLevel, "level", "info", "show entries at or above this level")
+	adminLogs.Flags().IntVarP(&logsLines, "lines", "n", 20, "number of recent entries to show")
+	adminLogs.Flags().BoolVarP(&logsFollow, "follow", "f", false, "keep showing new entries")
+	adminLogs.Flags().BoolVar(&logsJSON, "json", false, "print entries as JSON")
+
+	adminCmd.AddCommand(adminStatus)
+	adminCmd.AddCommand(adminLogLevel)
+	adminCmd.AddCommand(adminLogs)
+}
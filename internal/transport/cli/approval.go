@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var rejectReason string
+
+var approvalCmd = &cobra.Command{
+	Use:   "approval",
+	Short: "review changes waiting for approval",
+}
+
+var approvalList = &cobra.Command{
+	Use:   "list",
+	Short: "show changes waiting for approval",
+	Run: func(cmd *cobra.Command, args []string) {
+		defer func() {
+			if err := gRPCClient.Close(); err != nil {
+				log.Fatal().Msg("failed to close gRPC cli")
+			}
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), TTL)
+		defer cancel()
+
+		changes, err := gRPCClient.ListPendingChanges(ctx)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to list pending changes")
+		}
+
+		for _, change := range changes {
+			requester := change.Requester
+			if requester == "" {
+				requester = "anonymous"
+			}
+			fmt.Printf("%s\t%s %s\tby %s\texpires %s\n", change.ID, change.Kind, change.Target,
+				requester, change.ExpiresAt.Local().Format(time.RFC3339))
+			for _, line := range strings.Split(strings.TrimSuffix(change.Diff, "\n"), "\n") {
+				fmt.Printf("\t%s\n", line)
+			}
+		}
+	},
+}
+
+var approvalApprove = &cobra.Command{
+	Use:   "approve <id>",
+	Short: "approve and run a pending change",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		defer func() {
+			if err := gRPCClient.Close(); err != nil {
+				log.Fatal().Msg("failed to close gRPC cli")
+			}
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), TTL)
+		defer cancel()
+
+		res, err := gRPCClient.ApproveChange(ctx, args[0])
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to approve change")
+		}
+
+		fmt.Printf("approved change %s (version %s)\n", args[0], res.Version)
+		if res.ChangeID != "" {
+			fmt.Printf("change %s is rolled back unless confirmed in time: host-manager confirm %s\n",
+				res.ChangeID, res.ChangeID)
+		}
+	},
+}
+
+var approvalReject = &cobra.Command{
+	Use:   "reject <id>",
+	Short: "reject a pending change",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		defer func() {
+			if err := gRPCClient.Close(); err != nil {
+				log.Fatal().Msg("failed to close gRPC cli")
+			}
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), TTL)
+		defer cancel()
+
+		if err := gRPCClient.RejectChange(ctx, args[0], rejectReason); err != nil {
+			log.Fatal().Err(err).Msg("failed to reject change")
+		}
+
+		fmt.Printf("rejected change %s\n", args[0])
+	},
+}
+
+func init() {
+	approvalReject.Flags().StringVar(&rejectReason, "reason", "", "why the change is rejected")
+
+	approvalCmd.AddCommand(approvalList)
+	approvalCmd.AddCommand(approvalApprove)
+	approvalCmd.AddCommand(approvalReject)
+}
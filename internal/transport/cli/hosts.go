@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var getHosts = &cobra.Command{
+	Use:   "get-hosts",
+	Short: "show /etc/hosts on machine",
+	Run: func(cmd *cobra.Command, args []string) {
+		defer func() {
+			if err := gRPCClient.Close(); err != nil {
+				log.Fatal().Msg("failed to close gRPC cli")
+			}
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), TTL)
+		defer cancel()
+
+		content, version, err := gRPCClient.GetHosts(ctx)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to get hosts")
+		}
+
+		fmt.Print(content)
+		if showVersion {
+			fmt.Printf("version %s\n", version)
+		}
+	},
+}
+
+var setHosts = &cobra.Command{
+	Use:   "set-hosts <file>",
+	Short: "replace /etc/hosts on machine with the content of file",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		defer func() {
+			if err := gRPCClient.Close(); err != nil {
+				log.Fatal().Msg("failed to close gRPC cli")
+			}
+		}()
+
+		content, err := os.ReadFile(args[0])
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to read hosts file")
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), TTL)
+		defer cancel()
+
+		res, err := gRPCClient.SetHosts(ctx, string(content), mutationOpts)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to set hosts")
+		}
+
+		fmt.Printf("set hosts (version %s)\n", res.Version)
+		printPendingConfirmation(res)
+	},
+}
+
+func init() {
+	setHosts.Flags().StringVar(&mutationOpts.ExpectedVersion, "expected-version", "", "fail if the resource version differs")
+	setHosts.Flags().DurationVar(&mutationOpts.ConfirmWithin, "confirm-timeout", 0, "roll the change back unless confirmed within this time")
+	getHosts.Flags().BoolVar(&showVersion, "show-version", false, "print the resource version")
+}
@@ -3,11 +3,15 @@ package main
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
+	"hostManager/internal/config"
+	"hostManager/internal/tracing"
 	"hostManager/internal/transport/client"
 )
 
@@ -19,6 +23,23 @@ const (
 var (
 	serverAddr = DefaultServerAddr
 	TTL        = DefaultTTL
+
+	mutationOpts client.MutationOptions
+	showVersion  bool
+	dnsExpiry    client.Expiry
+	expireAt     string
+)
+
+var (
+	useTLS    bool
+	clientTLS config.ClientTLSConfig
+	token     string
+	tokenFile string
+)
+
+var (
+	tracingCfg      = config.TracingConfig{ServiceName: "host-manager-cli", SampleRatio: 1}
+	shutdownTracing = func(context.Context) error { return nil }
 )
 
 var gRPCClient *client.GRPCClient
@@ -26,7 +47,32 @@ var gRPCClient *client.GRPCClient
 var rootCmd = &cobra.Command{
 	Use: "host-manager",
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
-		gRPCClient = client.NewGRPCClient(serverAddr)
+		var err error
+		shutdownTracing, err = tracing.Setup(context.Background(), tracingCfg)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to setup tracing")
+		}
+
+		var opts client.Options
+		if useTLS || clientTLS != (config.ClientTLSConfig{}) {
+			opts.TLS = &clientTLS
+		}
+
+		opts.Token = token
+		if tokenFile != "" {
+			data, err := os.ReadFile(tokenFile)
+			if err != nil {
+				log.Fatal().Err(err).Msg("failed to read token file")
+			}
+			opts.Token = strings.TrimSpace(string(data))
+		}
+
+		gRPCClient = client.NewGRPCClient(serverAddr, opts)
+	},
+	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Error().Err(err).Msg("failed to flush traces")
+		}
 	},
 }
 
@@ -46,11 +92,48 @@ var setHostname = &cobra.Command{
 		ctx, cancel := context.WithTimeout(context.Background(), TTL)
 		defer cancel()
 
-		if err := gRPCClient.SetHostname(ctx, hostname); err != nil {
+		res, err := gRPCClient.SetHostname(ctx, hostname, mutationOpts)
+		if err != nil {
 			log.Fatal().Err(err).Msg("failed to set hostname")
 		}
 
-		fmt.Printf("set hostname %s\n", hostname)
+		if res.PendingChangeID != "" {
+			printPendingApproval(res)
+			return
+		}
+
+		if mutationOpts.ValidateOnly {
+			fmt.Printf("set hostname %s passes all checks (version %s)\n", hostname, res.Version)
+			return
+		}
+
+		fmt.Printf("set hostname %s (version %s)\n", hostname, res.Version)
+		printPendingConfirmation(res)
+	},
+}
+
+var getHostname = &cobra.Command{
+	Use:   "get-hostname",
+	Short: "show hostname on machine",
+	Run: func(cmd *cobra.Command, args []string) {
+		defer func() {
+			if err := gRPCClient.Close(); err != nil {
+				log.Fatal().Msg("failed to close gRPC cli")
+			}
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), TTL)
+		defer cancel()
+
+		hostname, version, err := gRPCClient.GetHostname(ctx)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to get hostname")
+		}
+
+		fmt.Println(hostname)
+		if showVersion {
+			fmt.Printf("version %s\n", version)
+		}
 	},
 }
 
@@ -72,9 +155,16 @@ var listDNSService = &cobra.Command{
 			log.Fatal().Err(err).Msg("failed to list DNS servers")
 		}
 
-		for _, service := range services {
+		for _, service := range services.Servers {
+			if at, ok := services.Expirations[service]; ok {
+				fmt.Printf("%s (expires %s)\n", service, at.Local().Format(time.RFC3339))
+				continue
+			}
 			fmt.Println(service)
 		}
+		if showVersion {
+			fmt.Printf("version %s\n", services.Version)
+		}
 	},
 }
 
@@ -90,15 +180,34 @@ var addDNSServer = &cobra.Command{
 		}()
 		servername := args[0]
 
+		if expireAt != "" {
+			at, err := time.Parse(time.RFC3339, expireAt)
+			if err != nil {
+				log.Fatal().Err(err).Msg("invalid --expire-at")
+			}
+			dnsExpiry.At = at
+		}
+
 		ctx, cancel := context.WithTimeout(context.Background(), TTL)
 		defer cancel()
 
-		err := gRPCClient.AddDNSServer(ctx, servername)
+		res, err := gRPCClient.AddDNSServer(ctx, servername, dnsExpiry, mutationOpts)
 		if err != nil {
 			log.Fatal().Err(err).Msg("failed to add DNS server")
 		}
 
-		fmt.Printf("add server %s\n", servername)
+		if res.PendingChangeID != "" {
+			printPendingApproval(res)
+			return
+		}
+
+		if mutationOpts.ValidateOnly {
+			fmt.Printf("add server %s passes all checks (version %s)\n", servername, res.Version)
+			return
+		}
+
+		fmt.Printf("add server %s (version %s)\n", servername, res.Version)
+		printPendingConfirmation(res)
 	},
 }
 
@@ -117,23 +226,104 @@ var removeDNSServer = &cobra.Command{
 		ctx, cancel := context.WithTimeout(context.Background(), TTL)
 		defer cancel()
 
-		err := gRPCClient.RemoveDNSServer(ctx, servername)
+		res, err := gRPCClient.RemoveDNSServer(ctx, servername, mutationOpts)
 		if err != nil {
 			log.Fatal().Err(err).Msg("failed to remove DNS server")
 		}
 
-		fmt.Printf("remove server %s\n", servername)
+		if res.PendingChangeID != "" {
+			printPendingApproval(res)
+			return
+		}
+
+		if mutationOpts.ValidateOnly {
+			fmt.Printf("remove server %s passes all checks (version %s)\n", servername, res.Version)
+			return
+		}
+
+		fmt.Printf("remove server %s (version %s)\n", servername, res.Version)
+		printPendingConfirmation(res)
 	},
 }
 
+var confirmChange = &cobra.Command{
+	Use:   "confirm <change-id>",
+	Short: "confirm a change so that it is not rolled back",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		defer func() {
+			if err := gRPCClient.Close(); err != nil {
+				log.Fatal().Msg("failed to close gRPC cli")
+			}
+		}()
+		changeID := args[0]
+
+		ctx, cancel := context.WithTimeout(context.Background(), TTL)
+		defer cancel()
+
+		if err := gRPCClient.ConfirmChange(ctx, changeID); err != nil {
+			log.Fatal().Err(err).Msg("failed to confirm change")
+		}
+
+		fmt.Printf("confirm change %s\n", changeID)
+	},
+}
+
+func printPendingConfirmation(res client.MutationResult) {
+	if res.ChangeID == "" {
+		return
+	}
+
+	fmt.Printf("change %s is rolled back unless confirmed within %s: host-manager confirm %s\n",
+		res.ChangeID, mutationOpts.ConfirmWithin, res.ChangeID)
+}
+
+func printPendingApproval(res client.MutationResult) {
+	fmt.Printf("change %s waits for approval by someone else: host-manager approval approve %s\n",
+		res.PendingChangeID, res.PendingChangeID)
+}
+
 func main() {
-	rootCmd.PersistentFlags().StringVar(&serverAddr, "server-addr", DefaultServerAddr, "grpc addr")
+	rootCmd.PersistentFlags().StringVar(&serverAddr, "server-addr", DefaultServerAddr, "grpc addr, host:port or unix:///path/to.sock")
 	rootCmd.PersistentFlags().DurationVar(&TTL, "TTL", DefaultTTL, "request timeout")
+	rootCmd.PersistentFlags().BoolVar(&useTLS, "tls", false, "connect over TLS using the system roots")
+	rootCmd.PersistentFlags().StringVar(&clientTLS.CAFile, "tls-ca-file", "", "CA bundle to verify the server with")
+	rootCmd.PersistentFlags().StringVar(&clientTLS.CertFile, "tls-cert-file", "", "client certificate for mutual TLS")
+	rootCmd.PersistentFlags().StringVar(&clientTLS.KeyFile, "tls-key-file", "", "client key for mutual TLS")
+	rootCmd.PersistentFlags().StringVar(&clientTLS.ServerName, "tls-server-name", "", "override the server name to verify")
+	rootCmd.PersistentFlags().StringVar(&token, "token", "", "bearer token to authenticate with")
+	rootCmd.PersistentFlags().StringVar(&tokenFile, "token-file", "", "file holding the bearer token")
+	rootCmd.MarkFlagsMutuallyExclusive("token", "token-file")
+	rootCmd.PersistentFlags().StringVar(&tracingCfg.Exporter, "trace", "", "export traces: otlp, stdout or file")
+	rootCmd.PersistentFlags().StringVar(&tracingCfg.Endpoint, "trace-endpoint", "localhost:4317", "OTLP gRPC collector address")
+	rootCmd.PersistentFlags().BoolVar(&tracingCfg.Insecure, "trace-insecure", false, "send traces to the collector without TLS")
+	rootCmd.PersistentFlags().StringVar(&tracingCfg.File, "trace-file", "traces.json", "file to append traces to with --trace=file")
+
+	for _, cmd := range []*cobra.Command{setHostname, addDNSServer, removeDNSServer} {
+		cmd.Flags().StringVar(&mutationOpts.ExpectedVersion, "expected-version", "", "fail if the resource version differs")
+		cmd.Flags().DurationVar(&mutationOpts.ConfirmWithin, "confirm-timeout", 0, "roll the change back unless confirmed within this time")
+		cmd.Flags().BoolVar(&mutationOpts.RequireApproval, "require-approval", false, "hold the change until someone else approves it")
+		cmd.Flags().BoolVar(&mutationOpts.ValidateOnly, "dry-run", false, "only check the change against the version, policy and host state")
+	}
+	for _, cmd := range []*cobra.Command{getHostname, listDNSService} {
+		cmd.Flags().BoolVar(&showVersion, "show-version", false, "print the resource version")
+	}
+
+	addDNSServer.Flags().DurationVar(&dnsExpiry.TTL, "ttl", 0, "remove the DNS server after this time")
+	addDNSServer.Flags().StringVar(&expireAt, "expire-at", "", "remove the DNS server at this RFC 3339 time")
 
 	rootCmd.AddCommand(setHostname)
+	rootCmd.AddCommand(getHostname)
 	rootCmd.AddCommand(listDNSService)
 	rootCmd.AddCommand(addDNSServer)
 	rootCmd.AddCommand(removeDNSServer)
+	rootCmd.AddCommand(getHosts)
+	rootCmd.AddCommand(setHosts)
+	rootCmd.AddCommand(confirmChange)
+	rootCmd.AddCommand(scheduleCmd)
+	rootCmd.AddCommand(adminCmd)
+	rootCmd.AddCommand(policyCmd)
+	rootCmd.AddCommand(approvalCmd)
 
 	rootCmd.Execute()
 }
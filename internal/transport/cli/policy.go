@@ -0,0 +1,68 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"hostManager/internal/admission"
+)
+
+var (
+	currentHostname   string
+	currentDNSServers []string
+	policyRequester   string
+	policyGroups      []string
+)
+
+var policyCmd = &cobra.Command{
+	Use:   "policy",
+	Short: "work with admission policies",
+	// Policies are evaluated offline, so no connection to the server is made.
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {},
+}
+
+var policyTest = &cobra.Command{
+	Use:   "test <policy-file> <set-hostname|add-dns-server|remove-dns-server> <target>",
+	Short: "check whether a policy admits a change",
+	Args:  cobra.ExactArgs(3),
+	Run: func(cmd *cobra.Command, args []string) {
+		policy, err := admission.Load(args[0])
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to load policy")
+		}
+
+		req := admission.Request{Action: args[1], Requester: policyRequester, Groups: policyGroups}
+		switch args[1] {
+		case admission.ActionSetHostname:
+			req.Hostname = args[2]
+		case admission.ActionAddDNSServer, admission.ActionRemoveDNSServer:
+			req.DNSServer = args[2]
+		default:
+			log.Fatal().Msgf("unknown action %q", args[1])
+		}
+
+		err = policy.Check(req, admission.State{Hostname: currentHostname, DNSServers: currentDNSServers})
+		if errors.Is(err, admission.ErrDenied) || errors.Is(err, admission.ErrApprovalRequired) {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to check policy")
+		}
+
+		fmt.Println("admitted")
+	},
+}
+
+func init() {
+	policyTest.Flags().StringVar(&currentHostname, "hostname", "", "current hostname of the host")
+	policyTest.Flags().StringSliceVar(&currentDNSServers, "dns-servers", nil, "current DNS servers of the host")
+	policyTest.Flags().StringVar(&policyRequester, "requester", "", "principal of the caller making the change")
+	policyTest.Flags().StringSliceVar(&policyGroups, "groups", nil, "groups of the caller making the change")
+
+	policyCmd.AddCommand(policyTest)
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"hostManager/internal/transport/client"
+)
+
+var (
+	scheduleAt   string
+	scheduleCron string
+)
+
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "manage changes scheduled on the server",
+}
+
+var scheduleSetHostname = &cobra.Command{
+	Use:   "set-hostname <hostname>",
+	Short: "schedule a hostname change",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		scheduleChange(client.ChangeSetHostname, args[0])
+	},
+}
+
+var scheduleAddDNSServer = &cobra.Command{
+	Use:   "add-dns-server <servername>",
+	Short: "schedule adding a DNS server",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		scheduleChange(client.ChangeAddDNSServer, args[0])
+	},
+}
+
+var scheduleRemoveDNSServer = &cobra.Command{
+	Use:   "remove-dns-server <servername>",
+	Short: "schedule removing a DNS server",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		scheduleChange(client.ChangeRemoveDNSServer, args[0])
+	},
+}
+
+var scheduleSetDNSServers = &cobra.Command{
+	Use:   "set-dns-servers <servername>...",
+	Short: "schedule replacing the DNS servers",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		scheduleChange(client.ChangeUpdateResolverConfig, strings.Join(args, " "))
+	},
+}
+
+var scheduleList = &cobra.Command{
+	Use:   "list",
+	Short: "show scheduled changes",
+	Run: func(cmd *cobra.Command, args []string) {
+		defer func() {
+			if err := gRPCClient.Close(); err != nil {
+				log.Fatal().Msg("failed to close gRPC cli")
+			}
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), TTL)
+		defer cancel()
+
+		changes, err := gRPCClient.ListScheduledChanges(ctx)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to list scheduled changes")
+		}
+
+		for _, change := range changes {
+			fmt.Printf("%s\t%s %s\tnext %s", change.ID, change.Kind, change.Target, change.NextRun.Local().Format(time.RFC3339))
+			if change.Schedule.Cron != "" {
+				fmt.Printf("\tcron %q", change.Schedule.Cron)
+			}
+			if change.LastError != "" {
+				fmt.Printf("\tlast error: %s", change.LastError)
+			}
+			fmt.Println()
+		}
+	},
+}
+
+var scheduleCancel = &cobra.Command{
+	Use:   "cancel <id>",
+	Short: "cancel a scheduled change",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		defer func() {
+			if err := gRPCClient.Close(); err != nil {
+				log.Fatal().Msg("failed to close gRPC cli")
+			}
+		}()
+		id := args[0]
+
+		ctx, cancel := context.WithTimeout(context.Background(), TTL)
+		defer cancel()
+
+		if err := gRPCClient.CancelScheduledChange(ctx, id); err != nil {
+			log.Fatal().Err(err).Msg("failed to cancel scheduled change")
+		}
+
+		fmt.Printf("cancel scheduled change %s\n", id)
+	},
+}
+
+func scheduleChange(kind client.ChangeKind, target string) {
+	defer func() {
+		if err := gRPCClient.Close(); err != nil {
+			log.Fatal().Msg("failed to close gRPC cli")
+		}
+	}()
+
+	sched := client.Schedule{Cron: scheduleCron}
+	if scheduleAt != "" {
+		at, err := time.Parse(time.RFC3339, scheduleAt)
+		if err != nil {
+			log.Fatal().Err(err).Msg("invalid --at")
+		}
+		sched.At = at
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), TTL)
+	defer cancel()
+
+	change, err := gRPCClient.ScheduleChange(ctx, kind, target, sched)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to schedule change")
+	}
+
+	fmt.Printf("schedule %s %s as %s, next run %s\n", kind, target, change.ID, change.NextRun.Local().Format(time.RFC3339))
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{scheduleSetHostname, scheduleAddDNSServer, scheduleRemoveDNSServer, scheduleSetDNSServers} {
+		cmd.Flags().StringVar(&scheduleAt, "at", "", "run once at this RFC 3339 time")
+		cmd.Flags().StringVar(&scheduleCron, "cron", "", "run on every match of this cron expression")
+		scheduleCmd.AddCommand(cmd)
+	}
+	scheduleCmd.AddCommand(scheduleList)
+	scheduleCmd.AddCommand(scheduleCancel)
+}
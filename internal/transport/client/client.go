@@ -1,10 +1,141 @@
 package client
 
-import "context"
+import (
+	"context"
+	"time"
+)
+
+// MutationOptions are the optional parameters shared by all mutations.
+type MutationOptions struct {
+	// ExpectedVersion makes the mutation fail if the resource changed meanwhile.
+	ExpectedVersion string
+	// ConfirmWithin makes the server roll the change back unless it is confirmed in time.
+	ConfirmWithin time.Duration
+	// RequireApproval holds the change until another identity approves it.
+	RequireApproval bool
+	// ValidateOnly only checks the change, without making it.
+	ValidateOnly bool
+}
+
+// MutationResult is the outcome of a mutation.
+type MutationResult struct {
+	Version  string
+	ChangeID string
+	// PendingChangeID is set instead of Version when the change waits for approval.
+	PendingChangeID string
+}
+
+// DNSServers is the resolver configuration reported by the server.
+type DNSServers struct {
+	Servers []string
+	Version string
+	// Expirations holds the expiry time of temporary servers.
+	Expirations map[string]time.Time
+}
+
+// Expiry limits the lifetime of an added DNS server. Leave both fields zero
+// for a permanent server.
+type Expiry struct {
+	TTL time.Duration
+	At  time.Time
+}
+
+// ChangeKind names a mutation that can be scheduled.
+type ChangeKind string
+
+const (
+	ChangeSetHostname     ChangeKind = "set-hostname"
+	ChangeAddDNSServer    ChangeKind = "add-dns-server"
+	ChangeRemoveDNSServer ChangeKind = "remove-dns-server"
+	// ChangeUpdateResolverConfig replaces the DNS servers with the
+	// space-separated servers of its target.
+	ChangeUpdateResolverConfig ChangeKind = "update-resolver-config"
+)
+
+// Schedule says when a scheduled change runs: once at At, or on every match of
+// the Cron expression.
+type Schedule struct {
+	At   time.Time
+	Cron string
+}
+
+// ScheduledChange is a change queued on the server.
+type ScheduledChange struct {
+	ID        string
+	Kind      ChangeKind
+	Target    string
+	Schedule  Schedule
+	NextRun   time.Time
+	LastRun   time.Time
+	LastError string
+}
+
+// PendingChange is a change waiting for approval.
+type PendingChange struct {
+	ID        string
+	Kind      ChangeKind
+	Target    string
+	Requester string
+	Diff      string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// FreezeState is the server-wide change freeze.
+type FreezeState struct {
+	Frozen bool
+	Reason string
+	Since  time.Time
+	// Until is zero for a freeze that lasts until lifted.
+	Until time.Time
+}
+
+// LogLevel is the server log level.
+type LogLevel struct {
+	Level   string
+	Default string
+	// RevertAt is when Level reverts to Default, zero for never.
+	RevertAt time.Time
+}
+
+// LogEntry is a server log entry; JSON holds all of its fields.
+type LogEntry struct {
+	Time    time.Time
+	Level   string
+	Message string
+	JSON    string
+}
+
+// Status is the server state reported by the admin API.
+type Status struct {
+	Freeze   FreezeState
+	LogLevel LogLevel
+}
 
 type Client interface {
-	SetHostname(ctx context.Context, hostname string) error
-	ListDNSServers(ctx context.Context) ([]string, error)
-	AddDNSServer(ctx context.Context, server string) error
-	RemoveDNSServer(ctx context.Context, server string) error
+	GetHostname(ctx context.Context) (hostname string, version string, err error)
+	SetHostname(ctx context.Context, hostname string, opts MutationOptions) (MutationResult, error)
+	ListDNSServers(ctx context.Context) (DNSServers, error)
+	AddDNSServer(ctx context.Context, server string, expiry Expiry, opts MutationOptions) (MutationResult, error)
+	RemoveDNSServer(ctx context.Context, server string, opts MutationOptions) (MutationResult, error)
+	GetHosts(ctx context.Context) (content string, version string, err error)
+	// SetHosts replaces /etc/hosts. RequireApproval is not supported.
+	SetHosts(ctx context.Context, content string, opts MutationOptions) (MutationResult, error)
+	ConfirmChange(ctx context.Context, changeID string) error
+	ScheduleChange(ctx context.Context, kind ChangeKind, target string, sched Schedule) (ScheduledChange, error)
+	ListScheduledChanges(ctx context.Context) ([]ScheduledChange, error)
+	CancelScheduledChange(ctx context.Context, id string) error
+	ListPendingChanges(ctx context.Context) ([]PendingChange, error)
+	ApproveChange(ctx context.Context, id string) (MutationResult, error)
+	RejectChange(ctx context.Context, id, reason string) error
+	// SetFreeze freezes the server for ttl (zero for no expiry), or lifts the
+	// freeze when frozen is false.
+	SetFreeze(ctx context.Context, frozen bool, reason string, ttl time.Duration) (FreezeState, error)
+	GetStatus(ctx context.Context) (Status, error)
+	// SetLogLevel changes the server log level, and reverts it after ttl
+	// unless ttl is zero.
+	SetLogLevel(ctx context.Context, level string, ttl time.Duration) (LogLevel, error)
+	// TailLogs calls fn for up to lines recent log entries at or above level,
+	// then for new ones until ctx ends if follow is set.
+	TailLogs(ctx context.Context, level string, lines int, follow bool, fn func(LogEntry) error) error
 }
@@ -2,19 +2,64 @@ package client
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/credentials/local"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"hostManager/internal/certs"
+	"hostManager/internal/config"
 	api "hostManager/pkg/gen"
+	apiv2 "hostManager/pkg/gen/v2"
 )
 
 type GRPCClient struct {
 	conn   *grpc.ClientConn
 	client api.DNSHostnameServiceClient
+	admin  api.AdminServiceClient
 }
 
-func NewGRPCClient(serverAddr string) *GRPCClient {
-	conn, err := grpc.NewClient(serverAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// Options configure how the client connects to the server.
+type Options struct {
+	// TLS enables TLS with the given settings; nil keeps the connection insecure.
+	// It is ignored for unix:// addresses, which are trusted as local.
+	TLS *config.ClientTLSConfig
+	// Token is sent as a bearer token with every call. It requires TLS or a
+	// Unix socket.
+	Token string
+}
+
+func NewGRPCClient(serverAddr string, opts Options) *GRPCClient {
+	creds := insecure.NewCredentials()
+	if strings.HasPrefix(serverAddr, "unix:") {
+		creds = local.NewCredentials()
+	} else if opts.TLS != nil {
+		tlsCfg, err := certs.ClientConfig(*opts.TLS)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to load TLS configuration")
+		}
+		creds = credentials.NewTLS(tlsCfg)
+	}
+
+	dialOpts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	}
+	if opts.Token != "" {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(bearerToken(opts.Token)))
+	}
+
+	conn, err := grpc.NewClient(serverAddr, dialOpts...)
 
 	if err != nil {
 		log.Fatal().Err(err).Msg("failed to connect to gRPC server")
@@ -25,42 +70,271 @@ func NewGRPCClient(serverAddr string) *GRPCClient {
 	return &GRPCClient{
 		conn:   conn,
 		client: client,
+		admin:  api.NewAdminServiceClient(conn),
+	}
+}
+
+func (g *GRPCClient) GetHostname(ctx context.Context) (string, string, error) {
+	r, err := g.client.GetHostname(ctx, &api.GetHostnameRequest{})
+	if err != nil {
+		return "", "", err
+	}
+
+	return r.Hostname, r.Version, nil
+}
+
+func (g *GRPCClient) SetHostname(ctx context.Context, hostname string, opts MutationOptions) (MutationResult, error) {
+	r, err := g.client.SetHostname(ctx, &api.SetHostnameRequest{
+		Hostname:        hostname,
+		ExpectedVersion: opts.ExpectedVersion,
+		ConfirmWithin:   confirmWithin(opts),
+		RequireApproval: opts.RequireApproval,
+		ValidateOnly:    opts.ValidateOnly,
+	})
+	if err != nil {
+		return MutationResult{}, err
+	}
+
+	return MutationResult{Version: r.Version, ChangeID: r.ChangeId, PendingChangeID: r.PendingChangeId}, nil
+}
+
+func (g *GRPCClient) GetHosts(ctx context.Context) (string, string, error) {
+	r, err := g.client.GetHosts(ctx, &api.GetHostsRequest{})
+	if err != nil {
+		return "", "", err
+	}
+
+	return r.Content, r.Version, nil
+}
+
+func (g *GRPCClient) SetHosts(ctx context.Context, content string, opts MutationOptions) (MutationResult, error) {
+	if opts.RequireApproval {
+		return MutationResult{}, errors.New("hosts changes cannot require approval")
+	}
+
+	r, err := g.client.SetHosts(ctx, &api.SetHostsRequest{
+		Content:         content,
+		ExpectedVersion: opts.ExpectedVersion,
+		ConfirmWithin:   confirmWithin(opts),
+	})
+	if err != nil {
+		return MutationResult{}, err
 	}
+
+	return MutationResult{Version: r.Version, ChangeID: r.ChangeId}, nil
 }
 
-func (g *GRPCClient) SetHostname(ctx context.Context, hostname string) error {
-	if _, err := g.client.SetHostname(ctx, &api.SetHostnameRequest{Hostname: hostname}); err != nil {
+func (g *GRPCClient) ListDNSServers(ctx context.Context) (DNSServers, error) {
+	r, err := g.client.ListDNSServers(ctx, &api.ListDNSServersRequest{})
+	if err != nil {
+		return DNSServers{}, err
+	}
+
+	expirations := make(map[string]time.Time)
+	for server, metadata := range r.Metadata {
+		if metadata.ExpireTime != nil {
+			expirations[server] = metadata.ExpireTime.AsTime()
+		}
+	}
+
+	return DNSServers{Servers: r.DnsServers, Version: r.Version, Expirations: expirations}, nil
+}
+
+func (g *GRPCClient) AddDNSServer(ctx context.Context, server string, expiry Expiry, opts MutationOptions) (MutationResult, error) {
+	req := &api.AddDNSServerRequest{
+		DnsServer:       server,
+		ExpectedVersion: opts.ExpectedVersion,
+		ConfirmWithin:   confirmWithin(opts),
+		RequireApproval: opts.RequireApproval,
+		ValidateOnly:    opts.ValidateOnly,
+	}
+	if expiry.TTL > 0 {
+		req.Ttl = durationpb.New(expiry.TTL)
+	}
+	if !expiry.At.IsZero() {
+		req.ExpireTime = timestamppb.New(expiry.At)
+	}
+
+	r, err := g.client.AddDNSServer(ctx, req)
+	if err != nil {
+		return MutationResult{}, err
+	}
+
+	return MutationResult{Version: r.Version, ChangeID: r.ChangeId, PendingChangeID: r.PendingChangeId}, nil
+}
+
+func (g *GRPCClient) RemoveDNSServer(ctx context.Context, server string, opts MutationOptions) (MutationResult, error) {
+	r, err := g.client.RemoveDNSServer(ctx, &api.RemoveDNSServerRequest{
+		DnsServer:       server,
+		ExpectedVersion: opts.ExpectedVersion,
+		ConfirmWithin:   confirmWithin(opts),
+		RequireApproval: opts.RequireApproval,
+		ValidateOnly:    opts.ValidateOnly,
+	})
+	if err != nil {
+		return MutationResult{}, err
+	}
+
+	return MutationResult{Version: r.Version, ChangeID: r.ChangeId, PendingChangeID: r.PendingChangeId}, nil
+}
+
+func (g *GRPCClient) ConfirmChange(ctx context.Context, changeID string) error {
+	if _, err := g.client.ConfirmChange(ctx, &api.ConfirmChangeRequest{ChangeId: changeID}); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (g *GRPCClient) ListDNSServers(ctx context.Context) ([]string, error) {
-	r, err := g.client.ListDNSServers(ctx, &api.ListDNSServersRequest{})
+func (g *GRPCClient) ScheduleChange(ctx context.Context, kind ChangeKind, target string, sched Schedule) (ScheduledChange, error) {
+	req := &api.ScheduleChangeRequest{Cron: sched.Cron}
+	if !sched.At.IsZero() {
+		req.RunTime = timestamppb.New(sched.At)
+	}
+
+	switch kind {
+	case ChangeSetHostname:
+		req.Change = &api.ScheduleChangeRequest_SetHostname{SetHostname: &api.SetHostnameRequest{Hostname: target}}
+	case ChangeAddDNSServer:
+		req.Change = &api.ScheduleChangeRequest_AddDnsServer{AddDnsServer: &api.AddDNSServerRequest{DnsServer: target}}
+	case ChangeRemoveDNSServer:
+		req.Change = &api.ScheduleChangeRequest_RemoveDnsServer{RemoveDnsServer: &api.RemoveDNSServerRequest{DnsServer: target}}
+	case ChangeUpdateResolverConfig:
+		var nameservers []*apiv2.Nameserver
+		for _, server := range strings.Fields(target) {
+			nameservers = append(nameservers, &apiv2.Nameserver{Address: server})
+		}
+		req.Change = &api.ScheduleChangeRequest_UpdateResolverConfig{UpdateResolverConfig: &apiv2.UpdateResolverConfigRequest{
+			ResolverConfig: &apiv2.ResolverConfig{Nameservers: nameservers},
+			UpdateMask:     &fieldmaskpb.FieldMask{Paths: []string{"nameservers"}},
+		}}
+	default:
+		return ScheduledChange{}, fmt.Errorf("unknown change kind %q", kind)
+	}
+
+	r, err := g.client.ScheduleChange(ctx, req)
+	if err != nil {
+		return ScheduledChange{}, err
+	}
+
+	return scheduledChange(r), nil
+}
+
+func (g *GRPCClient) ListScheduledChanges(ctx context.Context) ([]ScheduledChange, error) {
+	r, err := g.client.ListScheduledChanges(ctx, &api.ListScheduledChangesRequest{})
 	if err != nil {
 		return nil, err
 	}
 
-	return r.DnsServers, nil
+	changes := make([]ScheduledChange, 0, len(r.Changes))
+	for _, change := range r.Changes {
+		changes = append(changes, scheduledChange(change))
+	}
+
+	return changes, nil
 }
 
-func (g *GRPCClient) AddDNSServer(ctx context.Context, server string) error {
-	if _, err := g.client.AddDNSServer(ctx, &api.AddDNSServerRequest{DnsServer: server}); err != nil {
+func (g *GRPCClient) CancelScheduledChange(ctx context.Context, id string) error {
+	if _, err := g.client.CancelScheduledChange(ctx, &api.CancelScheduledChangeRequest{Id: id}); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (g *GRPCClient) RemoveDNSServer(ctx context.Context, server string) error {
-	if _, err := g.client.RemoveDNSServer(ctx, &api.RemoveDNSServerRequest{DnsServer: server}); err != nil {
+func (g *GRPCClient) ListPendingChanges(ctx context.Context) ([]PendingChange, error) {
+	r, err := g.client.ListPendingChanges(ctx, &api.ListPendingChangesRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]PendingChange, 0, len(r.Changes))
+	for _, c := range r.Changes {
+		changes = append(changes, pendingChange(c))
+	}
+
+	return changes, nil
+}
+
+func (g *GRPCClient) ApproveChange(ctx context.Context, id string) (MutationResult, error) {
+	r, err := g.client.ApproveChange(ctx, &api.ApproveChangeRequest{Id: id})
+	if err != nil {
+		return MutationResult{}, err
+	}
+
+	return MutationResult{Version: r.Version, ChangeID: r.ChangeId}, nil
+}
+
+func (g *GRPCClient) RejectChange(ctx context.Context, id, reason string) error {
+	if _, err := g.client.RejectChange(ctx, &api.RejectChangeRequest{Id: id, Reason: reason}); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+func (g *GRPCClient) SetFreeze(ctx context.Context, frozen bool, reason string, ttl time.Duration) (FreezeState, error) {
+	req := &api.SetFreezeRequest{Frozen: frozen, Reason: reason}
+	if ttl > 0 {
+		req.Ttl = durationpb.New(ttl)
+	}
+
+	r, err := g.admin.SetFreeze(ctx, req)
+	if err != nil {
+		return FreezeState{}, err
+	}
+
+	return freezeState(r), nil
+}
+
+func (g *GRPCClient) GetStatus(ctx context.Context) (Status, error) {
+	r, err := g.admin.GetStatus(ctx, &api.GetStatusRequest{})
+	if err != nil {
+		return Status{}, err
+	}
+
+	return Status{Freeze: freezeState(r.Freeze), LogLevel: logLevel(r.LogLevel)}, nil
+}
+
+func (g *GRPCClient) SetLogLevel(ctx context.Context, level string, ttl time.Duration) (LogLevel, error) {
+	req := &api.SetLogLevelRequest{Level: level}
+	if ttl > 0 {
+		req.Ttl = durationpb.New(ttl)
+	}
+
+	r, err := g.admin.SetLogLevel(ctx, req)
+	if err != nil {
+		return LogLevel{}, err
+	}
+
+	return logLevel(r), nil
+}
+
+func (g *GRPCClient) TailLogs(ctx context.Context, level string, lines int, follow bool, fn func(LogEntry) error) error {
+	stream, err := g.admin.TailLogs(ctx, &api.TailLogsRequest{Level: level, Lines: int32(lines), Follow: follow})
+	if err != nil {
+		return err
+	}
+
+	for {
+		r, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		entry := LogEntry{Level: r.GetLevel(), Message: r.GetMessage(), JSON: r.GetJson()}
+		if r.GetTime() != nil {
+			entry.Time = r.GetTime().AsTime()
+		}
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+}
+
 func (g *GRPCClient) Close() error {
 	if err := g.conn.Close(); err != nil {
 		return err
@@ -68,3 +342,111 @@ func (g *GRPCClient) Close() error {
 
 	return nil
 }
+
+func confirmWithin(opts MutationOptions) *durationpb.Duration {
+	if opts.ConfirmWithin <= 0 {
+		return nil
+	}
+
+	return durationpb.New(opts.ConfirmWithin)
+}
+
+func scheduledChange(r *api.ScheduledChange) ScheduledChange {
+	change := ScheduledChange{
+		ID:        r.Id,
+		Schedule:  Schedule{Cron: r.Cron},
+		LastError: r.LastError,
+	}
+	if r.RunTime != nil {
+		change.Schedule.At = r.RunTime.AsTime()
+	}
+	if r.NextRunTime != nil {
+		change.NextRun = r.NextRunTime.AsTime()
+	}
+	if r.LastRunTime != nil {
+		change.LastRun = r.LastRunTime.AsTime()
+	}
+
+	switch c := r.Change.(type) {
+	case *api.ScheduledChange_SetHostname:
+		change.Kind, change.Target = ChangeSetHostname, c.SetHostname.Hostname
+	case *api.ScheduledChange_AddDnsServer:
+		change.Kind, change.Target = ChangeAddDNSServer, c.AddDnsServer.DnsServer
+	case *api.ScheduledChange_RemoveDnsServer:
+		change.Kind, change.Target = ChangeRemoveDNSServer, c.RemoveDnsServer.DnsServer
+	case *api.ScheduledChange_UpdateHostname:
+		change.Kind, change.Target = ChangeSetHostname, c.UpdateHostname.GetHostname().GetHostname()
+	case *api.ScheduledChange_UpdateResolverConfig:
+		change.Kind, change.Target = ChangeUpdateResolverConfig, nameserverList(c.UpdateResolverConfig)
+	}
+
+	return change
+}
+
+func pendingChange(r *api.PendingChange) PendingChange {
+	change := PendingChange{
+		ID:        r.Id,
+		Requester: r.Requester,
+		Diff:      r.Diff,
+		CreatedAt: r.CreateTime.AsTime(),
+		ExpiresAt: r.ExpireTime.AsTime(),
+	}
+
+	switch c := r.Change.(type) {
+	case *api.PendingChange_SetHostname:
+		change.Kind, change.Target = ChangeSetHostname, c.SetHostname.Hostname
+	case *api.PendingChange_AddDnsServer:
+		change.Kind, change.Target = ChangeAddDNSServer, c.AddDnsServer.DnsServer
+	case *api.PendingChange_RemoveDnsServer:
+		change.Kind, change.Target = ChangeRemoveDNSServer, c.RemoveDnsServer.DnsServer
+	case *api.PendingChange_UpdateHostname:
+		change.Kind, change.Target = ChangeSetHostname, c.UpdateHostname.GetHostname().GetHostname()
+	case *api.PendingChange_UpdateResolverConfig:
+		change.Kind, change.Target = ChangeUpdateResolverConfig, nameserverList(c.UpdateResolverConfig)
+	}
+
+	return change
+}
+
+// nameserverList returns the nameservers of r separated by spaces, the target
+// of a ChangeUpdateResolverConfig.
+func nameserverList(r *apiv2.UpdateResolverConfigRequest) string {
+	var servers []string
+	for _, ns := range r.GetResolverConfig().GetNameservers() {
+		servers = append(servers, ns.GetAddress())
+	}
+
+	return strings.Join(servers, " ")
+}
+
+func freezeState(r *api.FreezeState) FreezeState {
+	state := FreezeState{Frozen: r.GetFrozen(), Reason: r.GetReason()}
+	if r.GetSince() != nil {
+		state.Since = r.GetSince().AsTime()
+	}
+	if r.GetExpireTime() != nil {
+		state.Until = r.GetExpireTime().AsTime()
+	}
+
+	return state
+}
+
+func logLevel(r *api.LogLevelState) LogLevel {
+	level := LogLevel{Level: r.GetLevel(), Default: r.GetDefaultLevel()}
+	if r.GetRevertTime() != nil {
+		level.RevertAt = r.GetRevertTime().AsTime()
+	}
+
+	return level
+}
+
+// bearerToken sends a token in the authorization metadata of every call.
+type bearerToken string
+
+func (t bearerToken) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+func (t bearerToken) RequireTransportSecurity() bool {
+	return true
+}
@@ -0,0 +1,107 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"hostManager/internal/logging"
+	"hostManager/internal/service"
+	api "hostManager/pkg/gen"
+)
+
+type AdminHandler struct {
+	api.UnimplementedAdminServiceServer
+	freeze *service.Freeze
+	logs   *logging.Logs
+}
+
+func NewAdminHandler(freeze *service.Freeze, logs *logging.Logs) *AdminHandler {
+	return &AdminHandler{freeze: freeze, logs: logs}
+}
+
+func (s *AdminHandler) SetFreeze(ctx context.Context, r *api.SetFreezeRequest) (*api.FreezeState, error) {
+	if !r.GetFrozen() {
+		return freezeState(s.freeze.Lift()), nil
+	}
+
+	if r.GetReason() == "" {
+		return nil, status.Error(codes.InvalidArgument, "reason is empty")
+	}
+
+	var until time.Time
+	switch {
+	case r.GetTtl() != nil && r.GetExpireTime() != nil:
+		return nil, status.Error(codes.InvalidArgument, "set either ttl or expire_time, not both")
+	case r.GetTtl() != nil:
+		if err := r.GetTtl().CheckValid(); err != nil || r.GetTtl().AsDuration() <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "ttl must be a positive duration")
+		}
+		until = time.Now().Add(r.GetTtl().AsDuration())
+	case r.GetExpireTime() != nil:
+		if err := r.GetExpireTime().CheckValid(); err != nil || !r.GetExpireTime().AsTime().After(time.Now()) {
+			return nil, status.Error(codes.InvalidArgument, "expire_time must be in the future")
+		}
+		until = r.GetExpireTime().AsTime()
+	}
+
+	return freezeState(s.freeze.Set(r.GetReason(), until)), nil
+}
+
+func (s *AdminHandler) GetStatus(ctx context.Context, r *api.GetStatusRequest) (*api.GetStatusResponse, error) {
+	return &api.GetStatusResponse{Freeze: freezeState(s.freeze.State()), LogLevel: logLevelState(s.logs.State())}, nil
+}
+
+func (s *AdminHandler) SetLogLevel(ctx context.Context, r *api.SetLogLevelRequest) (*api.LogLevelState, error) {
+	level, err := parseLevel(r.GetLevel())
+	if err != nil {
+		return nil, err
+	}
+
+	var ttl time.Duration
+	if r.GetTtl() != nil {
+		if err := r.GetTtl().CheckValid(); err != nil || r.GetTtl().AsDuration() <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "ttl must be a positive duration")
+		}
+		ttl = r.GetTtl().AsDuration()
+	}
+
+	state := s.logs.SetLevel(level, ttl)
+	zerolog.Ctx(ctx).Info().Str("logLevel", level.String()).Dur("ttl", ttl).Msg("Log level changed")
+
+	return logLevelState(state), nil
+}
+
+func freezeState(state service.FreezeState) *api.FreezeState {
+	r := &api.FreezeState{Frozen: state.Frozen, Reason: state.Reason}
+	if !state.Since.IsZero() {
+		r.Since = timestamppb.New(state.Since)
+	}
+	if !state.Until.IsZero() {
+		r.ExpireTime = timestamppb.New(state.Until)
+	}
+
+	return r
+}
+
+func logLevelState(state logging.State) *api.LogLevelState {
+	r := &api.LogLevelState{Level: state.Level.String(), DefaultLevel: state.DefaultLevel.String()}
+	if !state.RevertAt.IsZero() {
+		r.RevertTime = timestamppb.New(state.RevertAt)
+	}
+
+	return r
+}
+
+func parseLevel(s string) (zerolog.Level, error) {
+	level, err := zerolog.ParseLevel(s)
+	if err != nil || s == "" || level == zerolog.NoLevel {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid log level %q", s)
+	}
+
+	return level, nil
+}
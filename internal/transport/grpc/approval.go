@@ -0,0 +1,251 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"hostManager/internal/admission"
+	"hostManager/internal/approval"
+	"hostManager/internal/auth"
+	api "hostManager/pkg/gen"
+	apiv2 "hostManager/pkg/gen/v2"
+)
+
+func (s *Handler) ListPendingChanges(ctx context.Context, r *api.ListPendingChangesRequest) (*api.ListPendingChangesResponse, error) {
+	requests := s.approvals.List()
+
+	changes := make([]*api.PendingChange, 0, len(requests))
+	for _, req := range requests {
+		change, err := pendingChange(req)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+
+	return &api.ListPendingChangesResponse{Changes: changes}, nil
+}
+
+func (s *Handler) ApproveChange(ctx context.Context, r *api.ApproveChangeRequest) (*api.ApproveChangeResponse, error) {
+	if r.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is empty")
+	}
+
+	approver, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "approving a change requires an authenticated caller")
+	}
+
+	var resp *api.ApproveChangeResponse
+	err := s.approvals.Approve(ctx, r.GetId(), approver.String(), approver.Principal(),
+		func(ctx context.Context, req approval.Request) error {
+			var err error
+			resp, err = s.runPending(ctx, req)
+			return err
+		})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, toStatus(err)
+	}
+
+	return resp, nil
+}
+
+func (s *Handler) RejectChange(ctx context.Context, r *api.RejectChangeRequest) (*api.RejectChangeResponse, error) {
+	if r.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is empty")
+	}
+
+	actor := ""
+	if id, ok := auth.FromContext(ctx); ok {
+		actor = id.String()
+	}
+
+	if err := s.approvals.Reject(r.GetId(), actor, r.GetReason()); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &api.RejectChangeResponse{}, nil
+}
+
+// approvalRequested returns admission.ErrApprovalRequired when the caller asked
+// for approval and the change has not been approved yet.
+func approvalRequested(ctx context.Context, requested bool) error {
+	if requested && !admission.Approved(ctx) {
+		return admission.ErrApprovalRequired
+	}
+
+	return nil
+}
+
+// requestApproval queues a mutation until another identity approves it.
+func (s *Handler) requestApproval(ctx context.Context, method string, r proto.Message) (string, error) {
+	payload, err := protojson.Marshal(r)
+	if err != nil {
+		return "", status.Error(codes.Internal, err.Error())
+	}
+
+	diff, err := s.diff(ctx, r)
+	if err != nil {
+		return "", toStatus(err)
+	}
+
+	req := approval.Request{Method: method, Payload: payload, Diff: diff}
+	if id, ok := auth.FromContext(ctx); ok {
+		req.Requester = id.String()
+		req.Principal = id.Principal()
+		req.Identity = &id
+	}
+
+	req, err = s.approvals.Add(req)
+	if err != nil {
+		return "", toStatus(err)
+	}
+
+	return req.ID, nil
+}
+
+// diff shows what a mutation would change in the managed files.
+func (s *Handler) diff(ctx context.Context, r proto.Message) (string, error) {
+	switch r := r.(type) {
+	case *api.SetHostnameRequest:
+		hostname, _, err := s.manager.GetHostname(ctx)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("-%s\n+%s\n", hostname, r.GetHostname()), nil
+	case *api.AddDNSServerRequest:
+		return fmt.Sprintf("+nameserver %s\n", r.GetDnsServer()), nil
+	case *api.RemoveDNSServerRequest:
+		return fmt.Sprintf("-nameserver %s\n", r.GetDnsServer()), nil
+	case *apiv2.UpdateHostnameRequest:
+		hostname, _, err := s.manager.GetHostname(ctx)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("-%s\n+%s\n", hostname, r.GetHostname().GetHostname()), nil
+	case *apiv2.UpdateResolverConfigRequest:
+		update, err := s.mergeResolverConfig(ctx, r)
+		if err != nil {
+			return "", err
+		}
+		return lineDiff(update.current.Lines(), update.cfg.Lines()), nil
+	default:
+		return "", fmt.Errorf("unknown mutation %T", r)
+	}
+}
+
+// runPending executes an approved change through the regular handler methods
+// as the identity that requested it, so that authorization, admission and
+// auditing see the requester rather than the approver.
+func (s *Handler) runPending(ctx context.Context, req approval.Request) (*api.ApproveChangeResponse, error) {
+	if req.Identity != nil {
+		ctx = auth.WithIdentity(ctx, *req.Identity)
+	} else {
+		ctx = auth.WithoutIdentity(ctx)
+	}
+	ctx = admission.WithApproval(ctx)
+
+	// The policy may have changed since the change was requested.
+	if err := s.authorizeMutation(ctx, req.Method); err != nil {
+		return nil, err
+	}
+
+	r, err := decodeMutation(req.Method, req.Payload)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "pending change %s: %v", req.ID, err)
+	}
+
+	switch r := r.(type) {
+	case *api.SetHostnameRequest:
+		resp, err := s.SetHostname(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		return &api.ApproveChangeResponse{Version: resp.GetVersion(), ChangeId: resp.GetChangeId()}, nil
+	case *api.AddDNSServerRequest:
+		resp, err := s.AddDNSServer(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		return &api.ApproveChangeResponse{Version: resp.GetVersion(), ChangeId: resp.GetChangeId()}, nil
+	case *api.RemoveDNSServerRequest:
+		resp, err := s.RemoveDNSServer(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		return &api.ApproveChangeResponse{Version: resp.GetVersion(), ChangeId: resp.GetChangeId()}, nil
+	case *apiv2.UpdateHostnameRequest:
+		resp, err := NewHostConfigHandler(s).UpdateHostname(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		return &api.ApproveChangeResponse{Version: resp.GetEtag(), ChangeId: resp.GetChangeId()}, nil
+	case *apiv2.UpdateResolverConfigRequest:
+		resp, err := NewHostConfigHandler(s).UpdateResolverConfig(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		return &api.ApproveChangeResponse{Version: resp.GetEtag(), ChangeId: resp.GetChangeId()}, nil
+	default:
+		return nil, status.Errorf(codes.Internal, "pending change %s: unknown mutation %T", req.ID, r)
+	}
+}
+
+func pendingChange(req approval.Request) (*api.PendingChange, error) {
+	change := &api.PendingChange{
+		Id:         req.ID,
+		Requester:  req.Requester,
+		Diff:       req.Diff,
+		CreateTime: timestamppb.New(req.CreatedAt),
+		ExpireTime: timestamppb.New(req.ExpiresAt),
+	}
+
+	r, err := decodeMutation(req.Method, req.Payload)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "pending change %s: %v", req.ID, err)
+	}
+
+	switch r := r.(type) {
+	case *api.SetHostnameRequest:
+		change.Change = &api.PendingChange_SetHostname{SetHostname: r}
+	case *api.AddDNSServerRequest:
+		change.Change = &api.PendingChange_AddDnsServer{AddDnsServer: r}
+	case *api.RemoveDNSServerRequest:
+		change.Change = &api.PendingChange_RemoveDnsServer{RemoveDnsServer: r}
+	case *apiv2.UpdateHostnameRequest:
+		change.Change = &api.PendingChange_UpdateHostname{UpdateHostname: r}
+	case *apiv2.UpdateResolverConfigRequest:
+		change.Change = &api.PendingChange_UpdateResolverConfig{UpdateResolverConfig: r}
+	}
+
+	return change, nil
+}
+
+// lineDiff shows the lines of before missing from after and the lines of after
+// missing from before.
+func lineDiff(before, after []string) string {
+	var b strings.Builder
+	for _, line := range before {
+		if !slices.Contains(after, line) {
+			fmt.Fprintf(&b, "-%s\n", line)
+		}
+	}
+	for _, line := range after {
+		if !slices.Contains(before, line) {
+			fmt.Fprintf(&b, "+%s\n", line)
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,76 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"hostManager/internal/auth"
+	"hostManager/internal/authz"
+	api "hostManager/pkg/gen"
+)
+
+// TestApprovedChangeRunsAsRequester checks that an approved change is
+// admitted and authorized as the identity that requested it, not as the
+// approver.
+func TestApprovedChangeRunsAsRequester(t *testing.T) {
+	policy := loadPolicy(t, `
+rules:
+  - name: four-eyes
+    actions: [set-hostname]
+    expression: "false"
+    require_approval: true
+  - name: only-alice
+    actions: [set-hostname]
+    expression: 'request.requester == "alice"'
+`)
+	rbac, err := authz.Load("../../../config/rbac.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := newTestHost(t, policy)
+	h.v1.authz = authz.NewAuthorizer(rbac)
+
+	alice := auth.WithIdentity(context.Background(), auth.Identity{Method: "jwt", Subject: "alice", Groups: []string{"provisioning"}})
+	bob := auth.WithIdentity(context.Background(), auth.Identity{Method: "jwt", Subject: "bob", Groups: []string{"netops-leads"}})
+
+	request := func() string {
+		t.Helper()
+
+		resp, err := h.v1.SetHostname(alice, &api.SetHostnameRequest{Hostname: "new-host", RequireApproval: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.GetPendingChangeId() == "" {
+			t.Fatalf("SetHostname() = %v, want a pending change", resp)
+		}
+		return resp.GetPendingChangeId()
+	}
+
+	// The approver may not set the hostname and is not admitted by
+	// only-alice, so the change only passes as alice.
+	if _, err := h.v1.ApproveChange(bob, &api.ApproveChangeRequest{Id: request()}); err != nil {
+		t.Fatalf("ApproveChange() error = %v", err)
+	}
+	if got := readFile(t, h.files.Hostname); got != "new-host\n" {
+		t.Errorf("hostname = %q, want new-host", got)
+	}
+
+	// Once alice loses the permission, her pending change no longer runs.
+	id := request()
+	h.v1.authz.SetPolicy(&authz.Policy{
+		Roles: map[string]authz.Role{
+			"approver": {Methods: []string{api.DNSHostnameService_ApproveChange_FullMethodName}},
+		},
+		Bindings: []authz.Binding{{Role: "approver", Groups: []string{"netops-leads"}}},
+	})
+	if _, err := h.v1.ApproveChange(bob, &api.ApproveChangeRequest{Id: id}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("ApproveChange() error = %v, want PermissionDenied", err)
+	}
+	if pending := h.v1.approvals.List(); len(pending) != 1 || pending[0].ID != id {
+		t.Errorf("pending changes = %v, want %s kept", pending, id)
+	}
+}
@@ -0,0 +1,560 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"hostManager/internal/admission"
+	"hostManager/internal/approval"
+	"hostManager/internal/audit"
+	"hostManager/internal/auth"
+	"hostManager/internal/config"
+	"hostManager/internal/service"
+	api "hostManager/pkg/gen"
+	apiv2 "hostManager/pkg/gen/v2"
+)
+
+const testResolvConf = `# managed by tests
+search example.com
+nameserver 192.0.2.1
+options ndots:2
+`
+
+type testHost struct {
+	files config.HostFilesConfig
+	v1    *Handler
+	v2    *HostConfigHandler
+}
+
+// newTestHost serves both API versions on host files in a temporary
+// directory. The hostname command is replaced by one that does nothing.
+func newTestHost(t *testing.T, policy *admission.Policy) *testHost {
+	t.Helper()
+	dir := t.TempDir()
+
+	bin := filepath.Join(dir, "bin")
+	mkdir(t, bin)
+	writeFile(t, filepath.Join(bin, "hostname"), "#!/bin/sh\nexit 0\n")
+	if err := os.Chmod(filepath.Join(bin, "hostname"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	files := config.HostFilesConfig{
+		Hostname:   filepath.Join(dir, "hostname"),
+		ResolvConf: filepath.Join(dir, "resolv.conf"),
+		Hosts:      filepath.Join(dir, "hosts"),
+	}
+	writeFile(t, files.Hostname, "old-host\n")
+	writeFile(t, files.ResolvConf, testResolvConf)
+	writeFile(t, files.Hosts, "127.0.0.1 localhost\n")
+
+	backup := config.BackupConfig{
+		BackupHostnameFilePath: filepath.Join(dir, "backup", "hostname") + "/",
+		BackupDNSFilePath:      filepath.Join(dir, "backup", "dns") + "/",
+		BackupHostsFilePath:    filepath.Join(dir, "backup", "hosts") + "/",
+	}
+	mkdir(t, backup.BackupHostnameFilePath)
+	mkdir(t, backup.BackupDNSFilePath)
+	mkdir(t, backup.BackupHostsFilePath)
+	stateDir := filepath.Join(dir, "state")
+
+	auditLog, err := audit.New(filepath.Join(dir, "audit.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := service.NewFileSystemHostManager(files, backup)
+	manager := admission.NewHostManager(fs, policy)
+
+	expirer := service.NewExpirer(fs, auditLog, stateDir)
+	if err := expirer.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	confirmer := service.NewConfirmer(fs, auditLog, stateDir)
+	if err := confirmer.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewHandler(manager, confirmer, expirer, service.NewFreeze(config.FreezeConfig{}), auditLog)
+	h.fs = fs
+	h.admission = manager
+	h.approvals = approval.New(stateDir, time.Hour, auditLog)
+	if err := h.approvals.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		expirer.Stop()
+		confirmer.Stop()
+		h.approvals.Stop()
+		_ = auditLog.Close()
+	})
+
+	return &testHost{files: files, v1: h, v2: NewHostConfigHandler(h)}
+}
+
+// hostAPI runs the operations both API versions offer through one of them.
+type hostAPI struct {
+	name         string
+	getHostname  func(ctx context.Context, h *testHost) (hostname, version string, err error)
+	setHostname  func(ctx context.Context, h *testHost, hostname, version string) (string, error)
+	listServers  func(ctx context.Context, h *testHost) (servers []string, version string, err error)
+	addServer    func(ctx context.Context, h *testHost, server, version string) (string, error)
+	removeServer func(ctx context.Context, h *testHost, server, version string) (string, error)
+}
+
+var hostAPIs = []hostAPI{
+	{
+		name: "v1",
+		getHostname: func(ctx context.Context, h *testHost) (string, string, error) {
+			resp, err := h.v1.GetHostname(ctx, &api.GetHostnameRequest{})
+			return resp.GetHostname(), resp.GetVersion(), err
+		},
+		setHostname: func(ctx context.Context, h *testHost, hostname, version string) (string, error) {
+			resp, err := h.v1.SetHostname(ctx, &api.SetHostnameRequest{Hostname: hostname, ExpectedVersion: version})
+			return resp.GetVersion(), err
+		},
+		listServers: func(ctx context.Context, h *testHost) ([]string, string, error) {
+			resp, err := h.v1.ListDNSServers(ctx, &api.ListDNSServersRequest{})
+			return resp.GetDnsServers(), resp.GetVersion(), err
+		},
+		addServer: func(ctx context.Context, h *testHost, server, version string) (string, error) {
+			resp, err := h.v1.AddDNSServer(ctx, &api.AddDNSServerRequest{DnsServer: server, ExpectedVersion: version})
+			return resp.GetVersion(), err
+		},
+		removeServer: func(ctx context.Context, h *testHost, server, version string) (string, error) {
+			resp, err := h.v1.RemoveDNSServer(ctx, &api.RemoveDNSServerRequest{DnsServer: server, ExpectedVersion: version})
+			return resp.GetVersion(), err
+		},
+	},
+	{
+		name: "v2",
+		getHostname: func(ctx context.Context, h *testHost) (string, string, error) {
+			resp, err := h.v2.GetHostname(ctx, &apiv2.GetHostnameRequest{})
+			return resp.GetHostname(), resp.GetEtag(), err
+		},
+		setHostname: func(ctx context.Context, h *testHost, hostname, version string) (string, error) {
+			resp, err := h.v2.UpdateHostname(ctx, &apiv2.UpdateHostnameRequest{
+				Hostname: &apiv2.Hostname{Hostname: hostname, Etag: version},
+			})
+			return resp.GetEtag(), err
+		},
+		listServers: func(ctx context.Context, h *testHost) ([]string, string, error) {
+			resp, err := h.v2.GetResolverConfig(ctx, &apiv2.GetResolverConfigRequest{})
+			return addresses(resp.GetNameservers()), resp.GetEtag(), err
+		},
+		addServer: func(ctx context.Context, h *testHost, server, version string) (string, error) {
+			return updateNameservers(ctx, h, version, func(servers []string) []string {
+				return append(servers, server)
+			})
+		},
+		removeServer: func(ctx context.Context, h *testHost, server, version string) (string, error) {
+			return updateNameservers(ctx, h, version, func(servers []string) []string {
+				return slices.DeleteFunc(servers, func(s string) bool { return s == server })
+			})
+		},
+	},
+}
+
+func TestCompatibility(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, ctx context.Context, h *testHost, a hostAPI)
+	}{
+		{
+			name: "hostname version round trip",
+			run: func(t *testing.T, ctx context.Context, h *testHost, a hostAPI) {
+				hostname, version := must2(a.getHostname(ctx, h))(t)
+				if hostname != "old-host" {
+					t.Fatalf("hostname = %q, want old-host", hostname)
+				}
+
+				newVersion := must(a.setHostname(ctx, h, "new-host", version))(t)
+				if newVersion == version {
+					t.Fatalf("version did not change on update")
+				}
+
+				hostname, got := must2(a.getHostname(ctx, h))(t)
+				if hostname != "new-host" || got != newVersion {
+					t.Fatalf("got %q at version %s, want new-host at version %s", hostname, got, newVersion)
+				}
+			},
+		},
+		{
+			name: "stale hostname version",
+			run: func(t *testing.T, ctx context.Context, h *testHost, a hostAPI) {
+				_, version := must2(a.getHostname(ctx, h))(t)
+				must(a.setHostname(ctx, h, "new-host", version))(t)
+
+				_, err := a.setHostname(ctx, h, "other-host", version)
+				if status.Code(err) != codes.Aborted {
+					t.Fatalf("update with stale version: error = %v, want Aborted", err)
+				}
+			},
+		},
+		{
+			name: "nameserver version round trip",
+			run: func(t *testing.T, ctx context.Context, h *testHost, a hostAPI) {
+				_, version := must2(a.listServers(ctx, h))(t)
+
+				newVersion := must(a.addServer(ctx, h, "192.0.2.2", version))(t)
+				servers, got := must2(a.listServers(ctx, h))(t)
+				if !slices.Equal(servers, []string{"192.0.2.1", "192.0.2.2"}) || got != newVersion {
+					t.Fatalf("got %v at version %s, want [192.0.2.1 192.0.2.2] at version %s", servers, got, newVersion)
+				}
+
+				if _, err := a.addServer(ctx, h, "192.0.2.3", version); status.Code(err) != codes.Aborted {
+					t.Fatalf("update with stale version: error = %v, want Aborted", err)
+				}
+			},
+		},
+		{
+			name: "IPv6 nameservers",
+			run: func(t *testing.T, ctx context.Context, h *testHost, a hostAPI) {
+				must(a.addServer(ctx, h, "2001:db8::53", ""))(t)
+				must(a.addServer(ctx, h, "fe80::1%eth0", ""))(t)
+				must(a.removeServer(ctx, h, "192.0.2.1", ""))(t)
+
+				servers, _ := must2(a.listServers(ctx, h))(t)
+				if !slices.Equal(servers, []string{"2001:db8::53", "fe80::1%eth0"}) {
+					t.Fatalf("servers = %v, want [2001:db8::53 fe80::1%%eth0]", servers)
+				}
+			},
+		},
+		{
+			name: "other resolv.conf lines are kept",
+			run: func(t *testing.T, ctx context.Context, h *testHost, a hostAPI) {
+				must(a.addServer(ctx, h, "192.0.2.2", ""))(t)
+
+				want := "# managed by tests\nsearch example.com\nnameserver 192.0.2.1\nnameserver 192.0.2.2\noptions ndots:2\n"
+				if a.name == "v1" {
+					// v1 appends new nameservers to the end of the file.
+					want = testResolvConf + "nameserver 192.0.2.2\n"
+				}
+				if got := readFile(t, h.files.ResolvConf); got != want {
+					t.Fatalf("resolv.conf = %q, want %q", got, want)
+				}
+			},
+		},
+	}
+
+	for _, a := range hostAPIs {
+		for _, tt := range tests {
+			t.Run(a.name+"/"+tt.name, func(t *testing.T) {
+				tt.run(t, context.Background(), newTestHost(t, nil), a)
+			})
+		}
+	}
+}
+
+// TestVersionsAreShared checks that a version read through one API makes a
+// conditional update through the other.
+func TestVersionsAreShared(t *testing.T) {
+	for _, read := range hostAPIs {
+		for _, write := range hostAPIs {
+			t.Run(read.name+"->"+write.name, func(t *testing.T) {
+				ctx := context.Background()
+				h := newTestHost(t, nil)
+
+				_, version := must2(read.getHostname(ctx, h))(t)
+				must(write.setHostname(ctx, h, "new-host", version))(t)
+
+				_, version = must2(read.listServers(ctx, h))(t)
+				must(write.addServer(ctx, h, "192.0.2.2", version))(t)
+			})
+		}
+	}
+}
+
+func TestUpdateResolverConfigMask(t *testing.T) {
+	update := &apiv2.ResolverConfig{
+		Nameservers: []*apiv2.Nameserver{{Address: "2001:DB8:0::1"}},
+		Search:      []string{"example.org"},
+		Options:     []string{"rotate"},
+	}
+
+	tests := []struct {
+		name string
+		mask []string
+		want service.ResolverConfig
+	}{
+		{
+			name: "all fields",
+			want: service.ResolverConfig{Nameservers: []string{"2001:db8::1"}, Search: []string{"example.org"}, Options: []string{"rotate"}},
+		},
+		{
+			name: "nameservers",
+			mask: []string{"nameservers"},
+			want: service.ResolverConfig{Nameservers: []string{"2001:db8::1"}, Search: []string{"example.com"}, Options: []string{"ndots:2"}},
+		},
+		{
+			name: "search and options",
+			mask: []string{"search", "options", "etag"},
+			want: service.ResolverConfig{Nameservers: []string{"192.0.2.1"}, Search: []string{"example.org"}, Options: []string{"rotate"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			h := newTestHost(t, nil)
+
+			resp, err := h.v2.UpdateResolverConfig(ctx, &apiv2.UpdateResolverConfigRequest{
+				ResolverConfig: update,
+				UpdateMask:     &fieldmaskpb.FieldMask{Paths: tt.mask},
+			})
+			if err != nil {
+				t.Fatalf("UpdateResolverConfig() error = %v", err)
+			}
+
+			got := service.ResolverConfig{Nameservers: addresses(resp.GetNameservers()), Search: resp.GetSearch(), Options: resp.GetOptions()}
+			if !resolverConfigEqual(got, tt.want) {
+				t.Fatalf("UpdateResolverConfig() = %+v, want %+v", got, tt.want)
+			}
+
+			cfg, version, err := h.v1.fs.GetResolverConfig(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !resolverConfigEqual(cfg, tt.want) || version != resp.GetEtag() {
+				t.Fatalf("resolv.conf holds %+v at version %s, want %+v at version %s", cfg, version, tt.want, resp.GetEtag())
+			}
+		})
+	}
+}
+
+func TestV2Confirmation(t *testing.T) {
+	ctx := context.Background()
+	h := newTestHost(t, nil)
+
+	resp, err := h.v2.UpdateHostname(ctx, &apiv2.UpdateHostnameRequest{
+		Hostname:      &apiv2.Hostname{Hostname: "new-host"},
+		ConfirmWithin: durationpb.New(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("UpdateHostname() error = %v", err)
+	}
+	if resp.GetChangeId() == "" {
+		t.Fatal("UpdateHostname() with confirm_within returned no change id")
+	}
+
+	if _, err := h.v1.ConfirmChange(ctx, &api.ConfirmChangeRequest{ChangeId: resp.GetChangeId()}); err != nil {
+		t.Fatalf("ConfirmChange() error = %v", err)
+	}
+}
+
+func TestV2Approval(t *testing.T) {
+	policy := loadPolicy(t, `
+rules:
+  - name: four-eyes
+    actions: [set-hostname, remove-dns-server]
+    expression: "false"
+    require_approval: true
+`)
+	h := newTestHost(t, policy)
+	alice := auth.WithIdentity(context.Background(), auth.Identity{Method: "mtls", Subject: "alice"})
+	bob := auth.WithIdentity(context.Background(), auth.Identity{Method: "mtls", Subject: "bob"})
+
+	hostname, err := h.v2.UpdateHostname(alice, &apiv2.UpdateHostnameRequest{Hostname: &apiv2.Hostname{Hostname: "new-host"}})
+	if err != nil {
+		t.Fatalf("UpdateHostname() error = %v", err)
+	}
+	if hostname.GetPendingChangeId() == "" || hostname.GetHostname() != "old-host" {
+		t.Fatalf("UpdateHostname() = %v, want a pending change and the current hostname", hostname)
+	}
+
+	cfg, err := h.v2.UpdateResolverConfig(alice, &apiv2.UpdateResolverConfigRequest{
+		ResolverConfig: &apiv2.ResolverConfig{Nameservers: []*apiv2.Nameserver{{Address: "192.0.2.2"}}},
+		UpdateMask:     &fieldmaskpb.FieldMask{Paths: []string{"nameservers"}},
+	})
+	if err != nil {
+		t.Fatalf("UpdateResolverConfig() error = %v", err)
+	}
+	if cfg.GetPendingChangeId() == "" {
+		t.Fatalf("UpdateResolverConfig() = %v, want a pending change", cfg)
+	}
+
+	pending, err := h.v1.ListPendingChanges(alice, &api.ListPendingChangesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	diffs := make(map[string]string)
+	for _, change := range pending.GetChanges() {
+		diffs[change.GetId()] = change.GetDiff()
+	}
+	if got, want := diffs[hostname.GetPendingChangeId()], "-old-host\n+new-host\n"; got != want {
+		t.Errorf("hostname diff = %q, want %q", got, want)
+	}
+	if got, want := diffs[cfg.GetPendingChangeId()], "-nameserver 192.0.2.1\n+nameserver 192.0.2.2\n"; got != want {
+		t.Errorf("resolver config diff = %q, want %q", got, want)
+	}
+
+	if _, err := h.v1.ApproveChange(alice, &api.ApproveChangeRequest{Id: hostname.GetPendingChangeId()}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("self-approval: error = %v, want PermissionDenied", err)
+	}
+
+	for _, id := range []string{hostname.GetPendingChangeId(), cfg.GetPendingChangeId()} {
+		if _, err := h.v1.ApproveChange(bob, &api.ApproveChangeRequest{Id: id}); err != nil {
+			t.Fatalf("ApproveChange(%s) error = %v", id, err)
+		}
+	}
+
+	if got := readFile(t, h.files.Hostname); got != "new-host\n" {
+		t.Errorf("hostname file = %q, want new-host", got)
+	}
+	servers, _, err := h.v1.fs.ListDNSServers(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(servers, []string{"192.0.2.2"}) {
+		t.Errorf("servers = %v, want [192.0.2.2]", servers)
+	}
+}
+
+// TestConcurrentAdmission checks that concurrent changes are admitted against
+// the state the changes before them leave behind.
+func TestConcurrentAdmission(t *testing.T) {
+	policy := loadPolicy(t, `
+rules:
+  - name: max-nameservers
+    actions: [add-dns-server]
+    expression: "size(state.dns_servers) < 3"
+`)
+	h := newTestHost(t, policy)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := h.v1.AddDNSServer(context.Background(), &api.AddDNSServerRequest{DnsServer: fmt.Sprintf("192.0.2.%d", i+10)})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	var admitted int
+	for err := range errs {
+		switch status.Code(err) {
+		case codes.OK:
+			admitted++
+		case codes.FailedPrecondition:
+		default:
+			t.Fatalf("AddDNSServer() error = %v", err)
+		}
+	}
+
+	servers, _, err := h.v1.fs.ListDNSServers(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if admitted != 2 || len(servers) != 3 {
+		t.Fatalf("admitted %d changes, resolv.conf has %v; want 2 admitted and 3 nameservers", admitted, servers)
+	}
+}
+
+// updateNameservers replaces the nameserver list through v2, like a client
+// editing the list it read.
+func updateNameservers(ctx context.Context, h *testHost, version string, edit func([]string) []string) (string, error) {
+	cfg, err := h.v2.GetResolverConfig(ctx, &apiv2.GetResolverConfigRequest{})
+	if err != nil {
+		return "", err
+	}
+
+	var servers []*apiv2.Nameserver
+	for _, server := range edit(addresses(cfg.GetNameservers())) {
+		servers = append(servers, &apiv2.Nameserver{Address: server})
+	}
+
+	resp, err := h.v2.UpdateResolverConfig(ctx, &apiv2.UpdateResolverConfigRequest{
+		ResolverConfig: &apiv2.ResolverConfig{Nameservers: servers, Etag: version},
+		UpdateMask:     &fieldmaskpb.FieldMask{Paths: []string{"nameservers"}},
+	})
+
+	return resp.GetEtag(), err
+}
+
+func addresses(servers []*apiv2.Nameserver) []string {
+	out := make([]string, 0, len(servers))
+	for _, server := range servers {
+		out = append(out, server.GetAddress())
+	}
+
+	return out
+}
+
+func resolverConfigEqual(a, b service.ResolverConfig) bool {
+	return slices.Equal(a.Nameservers, b.Nameservers) && slices.Equal(a.Search, b.Search) && slices.Equal(a.Options, b.Options)
+}
+
+func loadPolicy(t *testing.T, rules string) *admission.Policy {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "admission.yaml")
+	writeFile(t, path, rules)
+
+	policy, err := admission.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return policy
+}
+
+func must[T any](v T, err error) func(*testing.T) T {
+	return func(t *testing.T) T {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+}
+
+func must2[T, U any](v T, w U, err error) func(*testing.T) (T, U) {
+	return func(t *testing.T) (T, U) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v, w
+	}
+}
+
+func mkdir(t *testing.T, dir string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeFile(t *testing.T, path, data string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(data)
+}
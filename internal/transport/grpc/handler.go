@@ -2,30 +2,155 @@ package grpc
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
+	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"hostManager/internal/admission"
+	"hostManager/internal/approval"
+	"hostManager/internal/audit"
+	"hostManager/internal/authz"
 	"hostManager/internal/config"
+	"hostManager/internal/logging"
+	"hostManager/internal/metrics"
+	"hostManager/internal/scheduler"
 	"hostManager/internal/service"
 	api "hostManager/pkg/gen"
+	apiv2 "hostManager/pkg/gen/v2"
 )
 
 type Handler struct {
 	api.UnimplementedDNSHostnameServiceServer
-	manager service.HostManager
+	manager   service.HostManager
+	confirmer *service.Confirmer
+	expirer   *service.Expirer
+	scheduler *scheduler.Scheduler
+	approvals *approval.Queue
+	health    *healthChecker
+	watcher   *service.Watcher
+	freeze    *service.Freeze
+	audit     *audit.Logger
+	// fs and admission take the backup directories and the admission policy
+	// on config reloads.
+	fs        *service.FileSystemHostManager
+	admission *admission.HostManager
+	// authz checks the mutations that scheduled and approved changes run,
+	// which the interceptors only see as ScheduleChange or ApproveChange.
+	authz *authz.Authorizer
 }
 
-func NewHandler(manager service.HostManager) *Handler {
-	return &Handler{manager: manager}
+func NewHandler(
+	manager service.HostManager,
+	confirmer *service.Confirmer,
+	expirer *service.Expirer,
+	freeze *service.Freeze,
+	audit *audit.Logger,
+) *Handler {
+	return &Handler{manager: manager, confirmer: confirmer, expirer: expirer, freeze: freeze, audit: audit}
 }
 
-func Register(gRPC *grpc.Server, cfg config.BackupConfig) {
-	manager := service.NewFileSystemHostManager(cfg)
-	server := NewHandler(manager)
+func Register(gRPC *grpc.Server, cfg *config.Config, logs *logging.Logs) (*Handler, error) {
+	const op = "grpc.Register"
+
+	auditLog, err := audit.New(cfg.AuditConfig.Path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	fsManager := service.NewFileSystemHostManager(cfg.HostFilesConfig, cfg.BackupConfig)
+	err = metrics.RegisterHostCollector(fsManager, func() map[string]string {
+		backup := fsManager.BackupConfig()
+		return map[string]string{
+			string(service.ResourceHostname):   backup.BackupHostnameFilePath,
+			string(service.ResourceResolvConf): backup.BackupDNSFilePath,
+			string(service.ResourceHosts):      backup.BackupHostsFilePath,
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// The watcher is started before anything can change the files, so it
+	// knows every write of fsManager.
+	watcher := service.NewWatcher(cfg.HostFilesConfig, cfg.EventsConfig.BufferSize)
+	if err := watcher.Start(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	fsManager.OnWrite(watcher.Expect)
+
+	expirer := service.NewExpirer(fsManager, auditLog, cfg.StateConfig.Dir)
+	if err := expirer.Start(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Expiries and rollbacks undo changes that were already admitted, so only
+	// requested changes go through the admission policy.
+	var policy *admission.Policy
+	if cfg.AdmissionConfig.PolicyFile != "" {
+		policy, err = admission.Load(cfg.AdmissionConfig.PolicyFile)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	manager := admission.NewHostManager(fsManager, policy)
+
+	confirmer := service.NewConfirmer(fsManager, auditLog, cfg.StateConfig.Dir)
+	if err := confirmer.Start(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	freeze := service.NewFreeze(cfg.FreezeConfig)
+	server := NewHandler(manager, confirmer, expirer, freeze, auditLog)
+	server.fs = fsManager
+	server.watcher = watcher
+	server.admission = manager
+	server.scheduler = scheduler.New(cfg.StateConfig.Dir, server.runScheduled, auditLog)
+	if err := server.scheduler.Start(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	server.approvals = approval.New(cfg.StateConfig.Dir, cfg.ApprovalConfig.TTL, auditLog)
+	if err := server.approvals.Start(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	server.health = newHealthChecker(fsManager.Ready)
+	server.health.Start()
 
 	api.RegisterDNSHostnameServiceServer(gRPC, server)
+	api.RegisterAdminServiceServer(gRPC, NewAdminHandler(freeze, logs))
+	apiv2.RegisterHostConfigServiceServer(gRPC, NewHostConfigHandler(server))
+	healthpb.RegisterHealthServer(gRPC, server.health.server)
+	reflection.Register(gRPC)
+	return server, nil
+}
+
+// Close stops background jobs and releases the audit log.
+func (s *Handler) Close() error {
+	s.expirer.Stop()
+	s.confirmer.Stop()
+	s.scheduler.Stop()
+	s.approvals.Stop()
+
+	return s.audit.Close()
+}
+
+func (s *Handler) GetHostname(ctx context.Context, r *api.GetHostnameRequest) (*api.GetHostnameResponse, error) {
+	hostname, version, err := s.manager.GetHostname(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &api.GetHostnameResponse{Hostname: hostname, Version: version}, nil
 }
 
 func (s *Handler) SetHostname(ctx context.Context, r *api.SetHostnameRequest) (*api.SetHostnameResponse, error) {
@@ -33,20 +158,61 @@ func (s *Handler) SetHostname(ctx context.Context, r *api.SetHostnameRequest) (*
 		return nil, status.Error(codes.InvalidArgument, "hostname or hostname is empty")
 	}
 
-	if err := s.manager.SetHostname(ctx, r.GetHostname()); err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+	if err := validateConfirmWithin(r.GetConfirmWithin()); err != nil {
+		return nil, err
+	}
+
+	if r.GetValidateOnly() {
+		change, err := s.manager.SetHostname(service.WithDryRun(ctx), r.GetHostname(), r.GetExpectedVersion())
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		return &api.SetHostnameResponse{Version: change.Version}, nil
+	}
+
+	if err := s.freeze.Check(); err != nil {
+		return nil, toStatus(err)
 	}
 
-	return &api.SetHostnameResponse{}, nil
+	var change service.Change
+	err := approvalRequested(ctx, r.GetRequireApproval())
+	if err == nil {
+		change, err = s.manager.SetHostname(ctx, r.GetHostname(), r.GetExpectedVersion())
+	}
+	if errors.Is(err, admission.ErrApprovalRequired) {
+		id, err := s.requestApproval(ctx, methodSetHostname, r)
+		if err != nil {
+			return nil, err
+		}
+		return &api.SetHostnameResponse{PendingChangeId: id}, nil
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	changeID, err := s.track(change, r.GetConfirmWithin())
+	if err != nil {
+		return nil, s.revert(ctx, change, err)
+	}
+
+	return &api.SetHostnameResponse{Version: change.Version, ChangeId: changeID}, nil
 }
 
 func (s *Handler) ListDNSServers(ctx context.Context, r *api.ListDNSServersRequest) (*api.ListDNSServersResponse, error) {
-	servers, err := s.manager.ListDNSServers(ctx)
+	servers, version, err := s.manager.ListDNSServers(ctx)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, toStatus(err)
 	}
 
-	return &api.ListDNSServersResponse{DnsServers: servers}, nil
+	metadata := make(map[string]*api.DNSServerMetadata)
+	pending := s.expirer.Pending()
+	for _, server := range servers {
+		if at, ok := pending[server]; ok {
+			metadata[server] = &api.DNSServerMetadata{ExpireTime: timestamppb.New(at)}
+		}
+	}
+
+	return &api.ListDNSServersResponse{DnsServers: servers, Version: version, Metadata: metadata}, nil
 }
 
 func (s *Handler) AddDNSServer(ctx context.Context, r *api.AddDNSServerRequest) (*api.AddDNSServerResponse, error) {
@@ -54,11 +220,58 @@ func (s *Handler) AddDNSServer(ctx context.Context, r *api.AddDNSServerRequest)
 		return nil, status.Error(codes.InvalidArgument, "dns server is empty")
 	}
 
-	if err := s.manager.AddDNSServer(ctx, r.GetDnsServer()); err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+	if err := validateConfirmWithin(r.GetConfirmWithin()); err != nil {
+		return nil, err
+	}
+
+	expireAt, err := expiryTime(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.GetValidateOnly() {
+		change, err := s.manager.AddDNSServer(service.WithDryRun(ctx), r.GetDnsServer(), r.GetExpectedVersion())
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		return &api.AddDNSServerResponse{Version: change.Version}, nil
 	}
 
-	return &api.AddDNSServerResponse{}, nil
+	if err := s.freeze.Check(); err != nil {
+		return nil, toStatus(err)
+	}
+
+	var change service.Change
+	err = approvalRequested(ctx, r.GetRequireApproval())
+	if err == nil {
+		change, err = s.manager.AddDNSServer(ctx, r.GetDnsServer(), r.GetExpectedVersion())
+	}
+	if errors.Is(err, admission.ErrApprovalRequired) {
+		id, err := s.requestApproval(ctx, methodAddDNSServer, r)
+		if err != nil {
+			return nil, err
+		}
+		return &api.AddDNSServerResponse{PendingChangeId: id}, nil
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	if !expireAt.IsZero() {
+		if err := s.expirer.Schedule(r.GetDnsServer(), expireAt); err != nil {
+			return nil, s.revert(ctx, change, err)
+		}
+	}
+
+	changeID, err := s.track(change, r.GetConfirmWithin())
+	if err != nil {
+		if !expireAt.IsZero() {
+			s.restoreExpiry(r.GetDnsServer(), time.Time{})
+		}
+		return nil, s.revert(ctx, change, err)
+	}
+
+	return &api.AddDNSServerResponse{Version: change.Version, ChangeId: changeID}, nil
 }
 
 func (s *Handler) RemoveDNSServer(ctx context.Context, r *api.RemoveDNSServerRequest) (*api.RemoveDNSServerResponse, error) {
@@ -66,9 +279,187 @@ func (s *Handler) RemoveDNSServer(ctx context.Context, r *api.RemoveDNSServerReq
 		return nil, status.Error(codes.InvalidArgument, "dns server is empty")
 	}
 
-	if err := s.manager.RemoveDNSServer(ctx, r.GetDnsServer()); err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+	if err := validateConfirmWithin(r.GetConfirmWithin()); err != nil {
+		return nil, err
+	}
+
+	if r.GetValidateOnly() {
+		change, err := s.manager.RemoveDNSServer(service.WithDryRun(ctx), r.GetDnsServer(), r.GetExpectedVersion())
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		return &api.RemoveDNSServerResponse{Version: change.Version}, nil
+	}
+
+	if err := s.freeze.Check(); err != nil {
+		return nil, toStatus(err)
 	}
 
-	return &api.RemoveDNSServerResponse{}, nil
+	expireAt := s.expirer.Pending()[r.GetDnsServer()]
+
+	var change service.Change
+	err := approvalRequested(ctx, r.GetRequireApproval())
+	if err == nil {
+		change, err = s.manager.RemoveDNSServer(ctx, r.GetDnsServer(), r.GetExpectedVersion())
+	}
+	if errors.Is(err, admission.ErrApprovalRequired) {
+		id, err := s.requestApproval(ctx, methodRemoveDNSServer, r)
+		if err != nil {
+			return nil, err
+		}
+		return &api.RemoveDNSServerResponse{PendingChangeId: id}, nil
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	if err := s.expirer.Cancel(r.GetDnsServer()); err != nil {
+		return nil, s.revert(ctx, change, err)
+	}
+
+	changeID, err := s.track(change, r.GetConfirmWithin())
+	if err != nil {
+		s.restoreExpiry(r.GetDnsServer(), expireAt)
+		return nil, s.revert(ctx, change, err)
+	}
+
+	return &api.RemoveDNSServerResponse{Version: change.Version, ChangeId: changeID}, nil
+}
+
+func (s *Handler) GetHosts(ctx context.Context, r *api.GetHostsRequest) (*api.GetHostsResponse, error) {
+	content, version, err := s.manager.GetHosts(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &api.GetHostsResponse{Content: content, Version: version}, nil
+}
+
+func (s *Handler) SetHosts(ctx context.Context, r *api.SetHostsRequest) (*api.SetHostsResponse, error) {
+	if err := s.freeze.Check(); err != nil {
+		return nil, toStatus(err)
+	}
+
+	if err := validateConfirmWithin(r.GetConfirmWithin()); err != nil {
+		return nil, err
+	}
+
+	change, err := s.manager.SetHosts(ctx, r.GetContent(), r.GetExpectedVersion())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	changeID, err := s.track(change, r.GetConfirmWithin())
+	if err != nil {
+		return nil, s.revert(ctx, change, err)
+	}
+
+	return &api.SetHostsResponse{Version: change.Version, ChangeId: changeID}, nil
+}
+
+func (s *Handler) ConfirmChange(ctx context.Context, r *api.ConfirmChangeRequest) (*api.ConfirmChangeResponse, error) {
+	if r.GetChangeId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "change id is empty")
+	}
+
+	if err := s.confirmer.Confirm(r.GetChangeId()); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &api.ConfirmChangeResponse{}, nil
+}
+
+// revert undoes change after a later step of the call failed with err, such as
+// persisting its rollback or expiry, so that the failed call leaves the host
+// as it was and can be retried. It returns the status for err.
+func (s *Handler) revert(ctx context.Context, change service.Change, err error) error {
+	const op = "Handler.revert"
+
+	revertErr := s.manager.Revert(ctx, change)
+	metrics.RollbackDone(string(change.Resource), revertErr)
+	if revertErr != nil {
+		log.Error().Str("op", op).Err(revertErr).Str("resource", string(change.Resource)).
+			Msg("Failed to revert change after a failed call")
+	}
+
+	return toStatus(err)
+}
+
+// restoreExpiry sets the expiry of server back to at, or cancels it for the
+// zero time, after the change that updated it is reverted.
+func (s *Handler) restoreExpiry(server string, at time.Time) {
+	const op = "Handler.restoreExpiry"
+
+	var err error
+	if at.IsZero() {
+		err = s.expirer.Cancel(server)
+	} else {
+		err = s.expirer.Schedule(server, at)
+	}
+	if err != nil {
+		log.Error().Str("op", op).Err(err).Str("server", server).Msg("Failed to restore DNS server expiry")
+	}
+}
+
+// track arms the rollback timer of change when the caller asked for confirmation.
+func (s *Handler) track(change service.Change, within *durationpb.Duration) (string, error) {
+	if within == nil {
+		return "", nil
+	}
+
+	return s.confirmer.Track(change, within.AsDuration())
+}
+
+func validateConfirmWithin(within *durationpb.Duration) error {
+	if within == nil {
+		return nil
+	}
+
+	if err := within.CheckValid(); err != nil || within.AsDuration() <= 0 {
+		return status.Error(codes.InvalidArgument, "confirm_within must be a positive duration")
+	}
+
+	return nil
+}
+
+// expiryTime returns when a temporary DNS server expires, or the zero time for
+// a permanent one.
+func expiryTime(r *api.AddDNSServerRequest) (time.Time, error) {
+	switch {
+	case r.GetTtl() != nil && r.GetExpireTime() != nil:
+		return time.Time{}, status.Error(codes.InvalidArgument, "set either ttl or expire_time, not both")
+	case r.GetTtl() != nil:
+		if err := r.GetTtl().CheckValid(); err != nil || r.GetTtl().AsDuration() <= 0 {
+			return time.Time{}, status.Error(codes.InvalidArgument, "ttl must be a positive duration")
+		}
+		return time.Now().Add(r.GetTtl().AsDuration()), nil
+	case r.GetExpireTime() != nil:
+		if err := r.GetExpireTime().CheckValid(); err != nil || !r.GetExpireTime().AsTime().After(time.Now()) {
+			return time.Time{}, status.Error(codes.InvalidArgument, "expire_time must be in the future")
+		}
+		return r.GetExpireTime().AsTime(), nil
+	default:
+		return time.Time{}, nil
+	}
+}
+
+// toStatus converts a service error into a gRPC status error.
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, service.ErrVersionMismatch), errors.Is(err, approval.ErrInProgress):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, service.ErrChangeNotFound), errors.Is(err, service.ErrDNSServerNotFound),
+		errors.Is(err, scheduler.ErrJobNotFound), errors.Is(err, approval.ErrRequestNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrFrozen), errors.Is(err, admission.ErrDenied), errors.Is(err, admission.ErrApprovalRequired):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, scheduler.ErrInvalidSchedule):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrDNSServerExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, approval.ErrSelfApproval):
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
 }
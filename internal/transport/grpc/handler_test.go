@@ -0,0 +1,351 @@
+package grpc
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	api "hostManager/pkg/gen"
+	apiv2 "hostManager/pkg/gen/v2"
+)
+
+// failStateFile makes persisting the state file name fail, by putting a
+// directory where its temporary file is written.
+func failStateFile(t *testing.T, h *testHost, name string) func() {
+	t.Helper()
+
+	tmp := filepath.Join(filepath.Dir(h.files.Hostname), "state", name+".tmp")
+	mkdir(t, tmp)
+
+	return func() {
+		if err := os.Remove(tmp); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestMutationRevertedWhenNotArmed checks that a change whose rollback or
+// expiry cannot be persisted is reverted, so that the failed call can be
+// retried.
+func TestMutationRevertedWhenNotArmed(t *testing.T) {
+	const (
+		confirmations = "confirmations.json"
+		expiries      = "dns-expiry.json"
+	)
+	ctx := context.Background()
+	hour := durationpb.New(time.Hour)
+	inAnHour := timestamppb.New(time.Now().Add(time.Hour))
+
+	tests := []struct {
+		name      string
+		stateFile string
+		// setup runs before persisting starts to fail.
+		setup  func(t *testing.T, h *testHost)
+		mutate func(h *testHost) error
+		// wantExpiries are the pending expiries after the failed call.
+		wantExpiries []string
+	}{
+		{
+			name:      "set hostname with confirmation",
+			stateFile: confirmations,
+			mutate: func(h *testHost) error {
+				_, err := h.v1.SetHostname(ctx, &api.SetHostnameRequest{Hostname: "new-host", ConfirmWithin: hour})
+				return err
+			},
+		},
+		{
+			name:      "update hostname with confirmation",
+			stateFile: confirmations,
+			mutate: func(h *testHost) error {
+				_, err := h.v2.UpdateHostname(ctx, &apiv2.UpdateHostnameRequest{
+					Hostname:      &apiv2.Hostname{Hostname: "new-host"},
+					ConfirmWithin: hour,
+				})
+				return err
+			},
+		},
+		{
+			name:      "add server with expiry",
+			stateFile: expiries,
+			mutate: func(h *testHost) error {
+				_, err := h.v1.AddDNSServer(ctx, &api.AddDNSServerRequest{DnsServer: "192.0.2.2", Ttl: hour})
+				return err
+			},
+		},
+		{
+			name:      "add server with expiry and confirmation",
+			stateFile: confirmations,
+			mutate: func(h *testHost) error {
+				_, err := h.v1.AddDNSServer(ctx, &api.AddDNSServerRequest{DnsServer: "192.0.2.2", Ttl: hour, ConfirmWithin: hour})
+				return err
+			},
+		},
+		{
+			name:      "remove server with expiry",
+			stateFile: expiries,
+			setup: func(t *testing.T, h *testHost) {
+				must(h.v1.AddDNSServer(ctx, &api.AddDNSServerRequest{DnsServer: "192.0.2.2", Ttl: hour}))(t)
+			},
+			mutate: func(h *testHost) error {
+				_, err := h.v1.RemoveDNSServer(ctx, &api.RemoveDNSServerRequest{DnsServer: "192.0.2.2"})
+				return err
+			},
+			wantExpiries: []string{"192.0.2.2"},
+		},
+		{
+			name:      "remove server with expiry and confirmation",
+			stateFile: confirmations,
+			setup: func(t *testing.T, h *testHost) {
+				must(h.v1.AddDNSServer(ctx, &api.AddDNSServerRequest{DnsServer: "192.0.2.2", Ttl: hour}))(t)
+			},
+			mutate: func(h *testHost) error {
+				_, err := h.v1.RemoveDNSServer(ctx, &api.RemoveDNSServerRequest{DnsServer: "192.0.2.2", ConfirmWithin: hour})
+				return err
+			},
+			wantExpiries: []string{"192.0.2.2"},
+		},
+		{
+			name:      "update nameservers with expiry",
+			stateFile: expiries,
+			mutate: func(h *testHost) error {
+				_, err := h.v2.UpdateResolverConfig(ctx, &apiv2.UpdateResolverConfigRequest{
+					ResolverConfig: &apiv2.ResolverConfig{Nameservers: []*apiv2.Nameserver{
+						{Address: "192.0.2.1"},
+						{Address: "192.0.2.2", ExpireTime: inAnHour},
+					}},
+					UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"nameservers"}},
+				})
+				return err
+			},
+		},
+		{
+			name:      "update nameservers with confirmation",
+			stateFile: confirmations,
+			setup: func(t *testing.T, h *testHost) {
+				must(h.v1.AddDNSServer(ctx, &api.AddDNSServerRequest{DnsServer: "192.0.2.2", Ttl: hour}))(t)
+			},
+			mutate: func(h *testHost) error {
+				_, err := h.v2.UpdateResolverConfig(ctx, &apiv2.UpdateResolverConfigRequest{
+					ResolverConfig: &apiv2.ResolverConfig{Nameservers: []*apiv2.Nameserver{
+						{Address: "192.0.2.3", ExpireTime: inAnHour},
+					}},
+					UpdateMask:    &fieldmaskpb.FieldMask{Paths: []string{"nameservers"}},
+					ConfirmWithin: hour,
+				})
+				return err
+			},
+			wantExpiries: []string{"192.0.2.2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHost(t, nil)
+			if tt.setup != nil {
+				tt.setup(t, h)
+			}
+			hostname := readFile(t, h.files.Hostname)
+			resolvConf := readFile(t, h.files.ResolvConf)
+
+			restore := failStateFile(t, h, tt.stateFile)
+			if err := tt.mutate(h); err == nil {
+				t.Fatal("call succeeded without persisting its state")
+			}
+
+			if got := readFile(t, h.files.Hostname); got != hostname {
+				t.Errorf("hostname = %q, want %q", got, hostname)
+			}
+			if got := readFile(t, h.files.ResolvConf); got != resolvConf {
+				t.Errorf("resolv.conf = %q, want %q", got, resolvConf)
+			}
+			pending := h.v1.expirer.Pending()
+			if len(pending) != len(tt.wantExpiries) {
+				t.Errorf("pending expiries = %v, want %v", pending, tt.wantExpiries)
+			}
+			for _, server := range tt.wantExpiries {
+				if _, ok := pending[server]; !ok {
+					t.Errorf("expiry of %s was dropped", server)
+				}
+			}
+
+			// Once the state can be persisted again, a retry applies the
+			// change once.
+			restore()
+			if err := tt.mutate(h); err != nil {
+				t.Fatalf("retry error = %v", err)
+			}
+		})
+	}
+}
+
+func TestSetHostsExpectedVersion(t *testing.T) {
+	ctx := context.Background()
+	h := newTestHost(t, nil)
+
+	current := must(h.v1.GetHosts(ctx, &api.GetHostsRequest{}))(t)
+	if current.GetContent() != "127.0.0.1 localhost\n" || current.GetVersion() == "" {
+		t.Fatalf("GetHosts() = %v", current)
+	}
+
+	const content = "127.0.0.1 localhost\n192.0.2.10 db\n"
+	set := must(h.v1.SetHosts(ctx, &api.SetHostsRequest{Content: content, ExpectedVersion: current.GetVersion()}))(t)
+	if got := readFile(t, h.files.Hosts); got != content {
+		t.Errorf("hosts = %q, want %q", got, content)
+	}
+	if got := must(h.v1.GetHosts(ctx, &api.GetHostsRequest{}))(t).GetVersion(); got != set.GetVersion() {
+		t.Errorf("version = %s, want the one SetHosts returned, %s", got, set.GetVersion())
+	}
+
+	// A second operator still holding the first version must not overwrite
+	// the change.
+	_, err := h.v1.SetHosts(ctx, &api.SetHostsRequest{Content: "127.0.0.1 localhost\n", ExpectedVersion: current.GetVersion()})
+	if status.Code(err) != codes.Aborted {
+		t.Errorf("SetHosts() with a stale version: error = %v, want Aborted", err)
+	}
+	if got := readFile(t, h.files.Hosts); got != content {
+		t.Errorf("hosts = %q after a stale update, want %q", got, content)
+	}
+}
+
+// TestValidateOnly checks that dry runs go through the same checks as
+// changes, also while the server is frozen, and write nothing.
+func TestValidateOnly(t *testing.T) {
+	policy := loadPolicy(t, `
+rules:
+  - name: no-forbidden-host
+    actions: [set-hostname]
+    expression: 'request.hostname != "forbidden"'
+  - name: keep-primary
+    actions: [remove-dns-server]
+    expression: 'request.dns_server != "192.0.2.1"'
+`)
+	ctx := context.Background()
+	nameservers := &fieldmaskpb.FieldMask{Paths: []string{"nameservers"}}
+
+	tests := []struct {
+		name     string
+		mutate   func(h *testHost, version string) error
+		wantCode codes.Code
+	}{
+		{
+			name: "admitted hostname",
+			mutate: func(h *testHost, _ string) error {
+				_, err := h.v1.SetHostname(ctx, &api.SetHostnameRequest{Hostname: "new-host", ValidateOnly: true})
+				return err
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "denied hostname",
+			mutate: func(h *testHost, _ string) error {
+				_, err := h.v1.SetHostname(ctx, &api.SetHostnameRequest{Hostname: "forbidden", ValidateOnly: true})
+				return err
+			},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name: "denied v2 hostname",
+			mutate: func(h *testHost, _ string) error {
+				_, err := h.v2.UpdateHostname(ctx, &apiv2.UpdateHostnameRequest{
+					Hostname:     &apiv2.Hostname{Hostname: "forbidden"},
+					ValidateOnly: true,
+				})
+				return err
+			},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name: "stale version",
+			mutate: func(h *testHost, _ string) error {
+				_, err := h.v1.SetHostname(ctx, &api.SetHostnameRequest{Hostname: "new-host", ExpectedVersion: "stale", ValidateOnly: true})
+				return err
+			},
+			wantCode: codes.Aborted,
+		},
+		{
+			name: "current version",
+			mutate: func(h *testHost, version string) error {
+				_, err := h.v1.AddDNSServer(ctx, &api.AddDNSServerRequest{DnsServer: "192.0.2.2", ExpectedVersion: version, ValidateOnly: true})
+				return err
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "existing server",
+			mutate: func(h *testHost, _ string) error {
+				_, err := h.v1.AddDNSServer(ctx, &api.AddDNSServerRequest{DnsServer: "192.0.2.1", ValidateOnly: true})
+				return err
+			},
+			wantCode: codes.AlreadyExists,
+		},
+		{
+			name: "denied removal",
+			mutate: func(h *testHost, _ string) error {
+				_, err := h.v1.RemoveDNSServer(ctx, &api.RemoveDNSServerRequest{DnsServer: "192.0.2.1", ValidateOnly: true})
+				return err
+			},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name: "missing server",
+			mutate: func(h *testHost, _ string) error {
+				_, err := h.v1.RemoveDNSServer(ctx, &api.RemoveDNSServerRequest{DnsServer: "192.0.2.9", ValidateOnly: true})
+				return err
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "nameserver update dropping a kept server",
+			mutate: func(h *testHost, _ string) error {
+				_, err := h.v2.UpdateResolverConfig(ctx, &apiv2.UpdateResolverConfigRequest{
+					ResolverConfig: &apiv2.ResolverConfig{Nameservers: []*apiv2.Nameserver{{Address: "192.0.2.2"}}},
+					UpdateMask:     nameservers,
+					ValidateOnly:   true,
+				})
+				return err
+			},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name: "admitted nameserver update",
+			mutate: func(h *testHost, _ string) error {
+				_, err := h.v2.UpdateResolverConfig(ctx, &apiv2.UpdateResolverConfigRequest{
+					ResolverConfig: &apiv2.ResolverConfig{Nameservers: []*apiv2.Nameserver{{Address: "192.0.2.1"}, {Address: "192.0.2.2"}}},
+					UpdateMask:     nameservers,
+					ValidateOnly:   true,
+				})
+				return err
+			},
+			wantCode: codes.OK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHost(t, policy)
+			h.v1.freeze.Set("incident", time.Time{})
+			hostname := readFile(t, h.files.Hostname)
+			resolvConf := readFile(t, h.files.ResolvConf)
+			version := must(h.v1.ListDNSServers(ctx, &api.ListDNSServersRequest{}))(t).GetVersion()
+
+			if err := tt.mutate(h, version); status.Code(err) != tt.wantCode {
+				t.Errorf("error = %v, want %v", err, tt.wantCode)
+			}
+
+			if got := readFile(t, h.files.Hostname); got != hostname {
+				t.Errorf("hostname = %q, want %q", got, hostname)
+			}
+			if got := readFile(t, h.files.ResolvConf); got != resolvConf {
+				t.Errorf("resolv.conf = %q, want %q", got, resolvConf)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package grpc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	api "hostManager/pkg/gen"
+	apiv2 "hostManager/pkg/gen/v2"
+)
+
+const healthCheckInterval = 10 * time.Second
+
+// healthChecker keeps the standard gRPC health service in line with whether
+// the host manager can apply changes, reporting NOT_SERVING when it cannot.
+type healthChecker struct {
+	server *health.Server
+	ready  func() error
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+func newHealthChecker(ready func() error) *healthChecker {
+	return &healthChecker{server: health.NewServer(), ready: ready, stop: make(chan struct{})}
+}
+
+func (h *healthChecker) Start() {
+	h.check(true)
+
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+
+		ticker := time.NewTicker(healthCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				h.check(false)
+			case <-h.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop reports NOT_SERVING for every service so that load balancers drain
+// the server before it shuts down.
+func (h *healthChecker) Stop() {
+	close(h.stop)
+	h.wg.Wait()
+	h.server.Shutdown()
+}
+
+func (h *healthChecker) check(first bool) {
+	const op = "grpc.healthCheck"
+
+	status := healthpb.HealthCheckResponse_SERVING
+	err := h.ready()
+	if err != nil {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+
+	current, _ := h.server.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if first || current.GetStatus() != status {
+		if err != nil {
+			log.Warn().Str("op", op).Err(err).Msg("Not ready")
+		} else {
+			log.Info().Str("op", op).Msg("Ready")
+		}
+	}
+
+	for _, service := range []string{"", api.DNSHostnameService_ServiceDesc.ServiceName, api.AdminService_ServiceDesc.ServiceName, apiv2.HostConfigService_ServiceDesc.ServiceName} {
+		h.server.SetServingStatus(service, status)
+	}
+}
@@ -0,0 +1,336 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"slices"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"hostManager/internal/admission"
+	"hostManager/internal/service"
+	apiv2 "hostManager/pkg/gen/v2"
+)
+
+// HostConfigHandler serves the v2 API on top of the services of Handler, so
+// that v1 and v2 changes share versions, freezes, policies and expiries.
+type HostConfigHandler struct {
+	apiv2.UnimplementedHostConfigServiceServer
+	handler *Handler
+}
+
+func NewHostConfigHandler(handler *Handler) *HostConfigHandler {
+	return &HostConfigHandler{handler: handler}
+}
+
+func (s *HostConfigHandler) GetHostname(ctx context.Context, r *apiv2.GetHostnameRequest) (*apiv2.Hostname, error) {
+	hostname, version, err := s.handler.manager.GetHostname(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &apiv2.Hostname{Hostname: hostname, Etag: version}, nil
+}
+
+func (s *HostConfigHandler) UpdateHostname(ctx context.Context, r *apiv2.UpdateHostnameRequest) (*apiv2.Hostname, error) {
+	if r.GetHostname() == nil {
+		return nil, status.Error(codes.InvalidArgument, "hostname is not set")
+	}
+
+	fields, err := maskedFields(r.GetUpdateMask(), "hostname")
+	if err != nil {
+		return nil, err
+	}
+	if !fields["hostname"] {
+		return s.GetHostname(ctx, &apiv2.GetHostnameRequest{})
+	}
+
+	if r.GetHostname().GetHostname() == "" {
+		return nil, status.Error(codes.InvalidArgument, "hostname is empty")
+	}
+
+	if err := validateConfirmWithin(r.GetConfirmWithin()); err != nil {
+		return nil, err
+	}
+
+	if r.GetValidateOnly() {
+		change, err := s.handler.manager.SetHostname(service.WithDryRun(ctx), r.GetHostname().GetHostname(), r.GetHostname().GetEtag())
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		return &apiv2.Hostname{Hostname: r.GetHostname().GetHostname(), Etag: change.Version}, nil
+	}
+
+	if err := s.handler.freeze.Check(); err != nil {
+		return nil, toStatus(err)
+	}
+
+	var change service.Change
+	err = approvalRequested(ctx, r.GetRequireApproval())
+	if err == nil {
+		change, err = s.handler.manager.SetHostname(ctx, r.GetHostname().GetHostname(), r.GetHostname().GetEtag())
+	}
+	if errors.Is(err, admission.ErrApprovalRequired) {
+		id, err := s.handler.requestApproval(ctx, methodUpdateHostname, r)
+		if err != nil {
+			return nil, err
+		}
+		hostname, err := s.GetHostname(ctx, &apiv2.GetHostnameRequest{})
+		if err != nil {
+			return nil, err
+		}
+		hostname.PendingChangeId = id
+		return hostname, nil
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	changeID, err := s.handler.track(change, r.GetConfirmWithin())
+	if err != nil {
+		return nil, s.handler.revert(ctx, change, err)
+	}
+
+	return &apiv2.Hostname{Hostname: r.GetHostname().GetHostname(), Etag: change.Version, ChangeId: changeID}, nil
+}
+
+func (s *HostConfigHandler) GetResolverConfig(ctx context.Context, r *apiv2.GetResolverConfigRequest) (*apiv2.ResolverConfig, error) {
+	cfg, version, err := s.handler.manager.GetResolverConfig(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return s.resolverConfig(cfg, version), nil
+}
+
+func (s *HostConfigHandler) UpdateResolverConfig(ctx context.Context, r *apiv2.UpdateResolverConfigRequest) (*apiv2.ResolverConfig, error) {
+	if r.GetResolverConfig() == nil {
+		return nil, status.Error(codes.InvalidArgument, "resolver_config is not set")
+	}
+
+	if err := validateConfirmWithin(r.GetConfirmWithin()); err != nil {
+		return nil, err
+	}
+
+	update, err := s.handler.mergeResolverConfig(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.GetValidateOnly() {
+		change, err := s.handler.manager.UpdateResolverConfig(service.WithDryRun(ctx), update.cfg, update.version)
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		return s.resolverConfig(update.cfg, change.Version), nil
+	}
+
+	if err := s.handler.freeze.Check(); err != nil {
+		return nil, toStatus(err)
+	}
+
+	expiries := s.handler.expirer.Pending()
+
+	var change service.Change
+	err = approvalRequested(ctx, r.GetRequireApproval())
+	if err == nil {
+		change, err = s.handler.manager.UpdateResolverConfig(ctx, update.cfg, update.version)
+	}
+	if errors.Is(err, admission.ErrApprovalRequired) {
+		id, err := s.handler.requestApproval(ctx, methodUpdateResolverConfig, r)
+		if err != nil {
+			return nil, err
+		}
+		cfg := s.resolverConfig(update.current, update.currentVersion)
+		cfg.PendingChangeId = id
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	if update.fields["nameservers"] {
+		if err := s.updateExpiries(update.current.Nameservers, update.cfg.Nameservers, update.expiries); err != nil {
+			s.restoreExpiries(update.cfg.Nameservers, update.current.Nameservers, expiries)
+			return nil, s.handler.revert(ctx, change, err)
+		}
+	}
+
+	changeID, err := s.handler.track(change, r.GetConfirmWithin())
+	if err != nil {
+		if update.fields["nameservers"] {
+			s.restoreExpiries(update.cfg.Nameservers, update.current.Nameservers, expiries)
+		}
+		return nil, s.handler.revert(ctx, change, err)
+	}
+
+	cfg := s.resolverConfig(update.cfg, change.Version)
+	cfg.ChangeId = changeID
+	return cfg, nil
+}
+
+// resolverUpdate is a v2 resolver config update merged into the current
+// configuration.
+type resolverUpdate struct {
+	current        service.ResolverConfig
+	currentVersion string
+	cfg            service.ResolverConfig
+	// version is the version cfg must be written over.
+	version  string
+	fields   map[string]bool
+	expiries map[string]time.Time
+}
+
+func (s *Handler) mergeResolverConfig(ctx context.Context, r *apiv2.UpdateResolverConfigRequest) (resolverUpdate, error) {
+	fields, err := maskedFields(r.GetUpdateMask(), "nameservers", "search", "options")
+	if err != nil {
+		return resolverUpdate{}, err
+	}
+
+	servers, expiries, err := nameservers(r.GetResolverConfig().GetNameservers())
+	if err != nil {
+		return resolverUpdate{}, err
+	}
+
+	current, version, err := s.manager.GetResolverConfig(ctx)
+	if err != nil {
+		return resolverUpdate{}, toStatus(err)
+	}
+
+	cfg := current
+	if fields["nameservers"] {
+		cfg.Nameservers = servers
+	}
+	if fields["search"] {
+		cfg.Search = r.GetResolverConfig().GetSearch()
+	}
+	if fields["options"] {
+		cfg.Options = r.GetResolverConfig().GetOptions()
+	}
+
+	update := resolverUpdate{
+		current:        current,
+		currentVersion: version,
+		cfg:            cfg,
+		version:        r.GetResolverConfig().GetEtag(),
+		fields:         fields,
+		expiries:       expiries,
+	}
+	// Without an etag the update is still made against the configuration it
+	// was merged into, so fields outside the mask are never rolled back.
+	if update.version == "" {
+		update.version = version
+	}
+
+	return update, nil
+}
+
+// updateExpiries schedules the expiry of every nameserver that has one and
+// cancels it for the permanent and the removed ones.
+func (s *HostConfigHandler) updateExpiries(previous, servers []string, expiries map[string]time.Time) error {
+	for _, server := range previous {
+		if !slices.Contains(servers, server) {
+			if err := s.handler.expirer.Cancel(server); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, server := range servers {
+		var err error
+		if at, ok := expiries[server]; ok {
+			err = s.handler.expirer.Schedule(server, at)
+		} else {
+			err = s.handler.expirer.Cancel(server)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// restoreExpiries undoes updateExpiries for a change that is being reverted,
+// from the updated nameservers back to the previous ones and their pending
+// expiries.
+func (s *HostConfigHandler) restoreExpiries(updated, previous []string, pending map[string]time.Time) {
+	const op = "HostConfigHandler.restoreExpiries"
+
+	if err := s.updateExpiries(updated, previous, pending); err != nil {
+		log.Error().Str("op", op).Err(err).Msg("Failed to restore DNS server expiries")
+	}
+}
+
+func (s *HostConfigHandler) resolverConfig(cfg service.ResolverConfig, version string) *apiv2.ResolverConfig {
+	pending := s.handler.expirer.Pending()
+
+	servers := make([]*apiv2.Nameserver, 0, len(cfg.Nameservers))
+	for _, server := range cfg.Nameservers {
+		ns := &apiv2.Nameserver{Address: server}
+		if at, ok := pending[server]; ok {
+			ns.ExpireTime = timestamppb.New(at)
+		}
+		servers = append(servers, ns)
+	}
+
+	return &apiv2.ResolverConfig{Nameservers: servers, Search: cfg.Search, Options: cfg.Options, Etag: version}
+}
+
+// nameservers validates the nameservers of an update and returns their
+// addresses in canonical form and the expiry time of the temporary ones.
+func nameservers(in []*apiv2.Nameserver) ([]string, map[string]time.Time, error) {
+	servers := make([]string, 0, len(in))
+	expiries := make(map[string]time.Time)
+	for _, ns := range in {
+		addr, err := netip.ParseAddr(ns.GetAddress())
+		if err != nil {
+			return nil, nil, status.Errorf(codes.InvalidArgument, "nameserver %q is not an IP address", ns.GetAddress())
+		}
+		server := addr.String()
+		if slices.Contains(servers, server) {
+			return nil, nil, status.Errorf(codes.InvalidArgument, "nameserver %s is listed twice", server)
+		}
+		servers = append(servers, server)
+
+		if at := ns.GetExpireTime(); at != nil {
+			if err := at.CheckValid(); err != nil || !at.AsTime().After(time.Now()) {
+				return nil, nil, status.Errorf(codes.InvalidArgument, "expire_time of nameserver %s must be in the future", server)
+			}
+			expiries[server] = at.AsTime()
+		}
+	}
+
+	return servers, expiries, nil
+}
+
+// maskedFields returns which of fields an update mask selects. An empty mask
+// or "*" selects all of them. The mask may name etag, as the gateway derives
+// the mask of PATCH requests from the body, but etag is never updated.
+func maskedFields(mask *fieldmaskpb.FieldMask, fields ...string) (map[string]bool, error) {
+	selected := make(map[string]bool, len(fields))
+	if len(mask.GetPaths()) == 0 || slices.Equal(mask.GetPaths(), []string{"*"}) {
+		for _, field := range fields {
+			selected[field] = true
+		}
+		return selected, nil
+	}
+
+	for _, path := range mask.GetPaths() {
+		switch {
+		case slices.Contains(fields, path):
+			selected[path] = true
+		case path == "etag":
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "update_mask: unknown or immutable field %q", path)
+		}
+	}
+
+	return selected, nil
+}
@@ -0,0 +1,57 @@
+package grpc
+
+import (
+	"maps"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestMaskedFields(t *testing.T) {
+	fields := []string{"nameservers", "search", "options"}
+	all := map[string]bool{"nameservers": true, "search": true, "options": true}
+
+	tests := []struct {
+		name    string
+		mask    *fieldmaskpb.FieldMask
+		want    map[string]bool
+		wantErr bool
+	}{
+		{name: "nil mask", mask: nil, want: all},
+		{name: "empty mask", mask: &fieldmaskpb.FieldMask{}, want: all},
+		{name: "wildcard", mask: &fieldmaskpb.FieldMask{Paths: []string{"*"}}, want: all},
+		{
+			name: "subset",
+			mask: &fieldmaskpb.FieldMask{Paths: []string{"search", "options"}},
+			want: map[string]bool{"search": true, "options": true},
+		},
+		{
+			name: "etag is ignored",
+			mask: &fieldmaskpb.FieldMask{Paths: []string{"nameservers", "etag"}},
+			want: map[string]bool{"nameservers": true},
+		},
+		{name: "only etag", mask: &fieldmaskpb.FieldMask{Paths: []string{"etag"}}, want: map[string]bool{}},
+		{name: "unknown field", mask: &fieldmaskpb.FieldMask{Paths: []string{"domain"}}, wantErr: true},
+		{name: "wildcard with fields", mask: &fieldmaskpb.FieldMask{Paths: []string{"*", "search"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := maskedFields(tt.mask, fields...)
+			if tt.wantErr {
+				if status.Code(err) != codes.InvalidArgument {
+					t.Fatalf("maskedFields() error = %v, want InvalidArgument", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("maskedFields() error = %v", err)
+			}
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("maskedFields() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
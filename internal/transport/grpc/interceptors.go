@@ -0,0 +1,129 @@
+package grpc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"hostManager/internal/grpcutil"
+)
+
+// RequestIDKey is the metadata key carrying the request ID in both directions.
+const RequestIDKey = "x-request-id"
+
+// requestLogUnaryInterceptor assigns or propagates a request ID, puts a
+// request-scoped logger into the context and writes an access log line.
+func requestLogUnaryInterceptor(log zerolog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		id := requestID(ctx)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDKey, id))
+
+		l := requestLogger(ctx, log, id, info.FullMethod)
+		resp, err := handler(l.WithContext(ctx), req)
+		logAccess(l, info.FullMethod, start, err)
+
+		return resp, err
+	}
+}
+
+// requestLogStreamInterceptor is the streaming counterpart of requestLogUnaryInterceptor.
+func requestLogStreamInterceptor(log zerolog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx := ss.Context()
+		id := requestID(ctx)
+		_ = ss.SetHeader(metadata.Pairs(RequestIDKey, id))
+
+		l := requestLogger(ctx, log, id, info.FullMethod)
+		err := handler(srv, grpcutil.WithContext(ss, l.WithContext(ctx)))
+		logAccess(l, info.FullMethod, start, err)
+
+		return err
+	}
+}
+
+// recoveryUnaryInterceptor turns a panic in a handler into an Internal error
+// so that one bad request does not take the server down.
+func recoveryUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(ctx, r)
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// recoveryStreamInterceptor is the streaming counterpart of recoveryUnaryInterceptor.
+func recoveryStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(ss.Context(), r)
+			}
+		}()
+
+		return handler(srv, ss)
+	}
+}
+
+func recovered(ctx context.Context, r any) error {
+	zerolog.Ctx(ctx).Error().Interface("panic", r).Bytes("stack", debug.Stack()).Msg("Handler panicked")
+	return status.Error(codes.Internal, "internal error")
+}
+
+// requestID returns the ID sent by the caller or a new one.
+func requestID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(RequestIDKey); len(ids) > 0 && ids[0] != "" {
+			return ids[0]
+		}
+	}
+
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func requestLogger(ctx context.Context, log zerolog.Logger, id, method string) zerolog.Logger {
+	c := log.With().Str("requestID", id).Str("method", method)
+	if p, ok := peer.FromContext(ctx); ok {
+		c = c.Str("peer", p.Addr.String())
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		c = c.Str("traceID", sc.TraceID().String())
+	}
+
+	return c.Logger()
+}
+
+// logAccess logs at info level, at error level for server faults and at debug
+// level for health checks, which probes send every few seconds.
+func logAccess(l zerolog.Logger, method string, start time.Time, err error) {
+	code := status.Code(err)
+
+	e := l.Info()
+	switch {
+	case code == codes.Internal || code == codes.Unknown:
+		e = l.Error()
+	case strings.HasPrefix(method, "/"+healthpb.Health_ServiceDesc.ServiceName+"/"):
+		e = l.Debug()
+	}
+
+	e.Str("code", code.String()).Dur("duration", time.Since(start)).Msg("Request handled")
+}
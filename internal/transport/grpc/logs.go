@@ -0,0 +1,81 @@
+package grpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"hostManager/internal/logging"
+	api "hostManager/pkg/gen"
+)
+
+func (s *AdminHandler) TailLogs(r *api.TailLogsRequest, stream api.AdminService_TailLogsServer) error {
+	level := zerolog.InfoLevel
+	if r.GetLevel() != "" {
+		var err error
+		if level, err = parseLevel(r.GetLevel()); err != nil {
+			return err
+		}
+	}
+
+	if r.GetLines() < 0 {
+		return status.Error(codes.InvalidArgument, "lines must not be negative")
+	}
+
+	recent, entries, cancel := s.logs.Tail(level, int(r.GetLines()), r.GetFollow())
+	defer cancel()
+
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for _, entry := range recent {
+		if err := stream.Send(logEntry(entry)); err != nil {
+			return err
+		}
+	}
+
+	if entries == nil {
+		return nil
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case entry, ok := <-entries:
+			if !ok {
+				return status.Error(codes.Unavailable, "log stream closed")
+			}
+			if err := stream.Send(logEntry(entry)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func logEntry(entry logging.Entry) *api.LogEntry {
+	r := &api.LogEntry{Level: entry.Level.String(), Json: string(bytes.TrimSpace(entry.Data))}
+
+	var fields map[string]any
+	if err := json.Unmarshal(entry.Data, &fields); err != nil {
+		return r
+	}
+
+	if message, ok := fields[zerolog.MessageFieldName].(string); ok {
+		r.Message = message
+	}
+	if ts, ok := fields[zerolog.TimestampFieldName].(string); ok {
+		if t, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+			r.Time = timestamppb.New(t)
+		}
+	}
+
+	return r
+}
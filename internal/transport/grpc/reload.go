@@ -0,0 +1,73 @@
+package grpc
+
+import (
+	"crypto/tls"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+
+	"hostManager/internal/admission"
+	"hostManager/internal/authz"
+	"hostManager/internal/certs"
+	"hostManager/internal/config"
+	"hostManager/internal/service"
+)
+
+// PrepareReload checks the settings of cfg that can change while the server
+// runs: policies, rate limits, backup directories and TLS certificates. It
+// returns a function applying them, and changes nothing if cfg is invalid.
+// TLS can only be turned on or off by a restart, and authorization and
+// admission policies only be removed by one, so a config edit can not
+// silently open up the server.
+func (s *Server) PrepareReload(cfg *config.Config) (func(), error) {
+	const op = "grpc.PrepareReload"
+
+	var authzPolicy *authz.Policy
+	if cfg.AuthzConfig.PolicyFile != "" {
+		var err error
+		authzPolicy, err = authz.Load(cfg.AuthzConfig.PolicyFile)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	var admissionPolicy *admission.Policy
+	if cfg.AdmissionConfig.PolicyFile != "" {
+		var err error
+		admissionPolicy, err = admission.Load(cfg.AdmissionConfig.PolicyFile)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := service.CheckBackupDirs(cfg.BackupConfig); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var tlsCfg *tls.Config
+	if s.certs != nil && cfg.GRPCConfig.TLS.CertFile != "" {
+		var err error
+		tlsCfg, err = certs.ServerConfig(cfg.GRPCConfig.TLS)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return func() {
+		if authzPolicy != nil {
+			s.authz.SetPolicy(authzPolicy)
+		} else if s.authz.Enabled() {
+			log.Error().Str("op", op).Msg("Authorization policy removed from config, keeping the running one until a restart")
+		}
+		if admissionPolicy != nil {
+			s.handler.admission.SetPolicy(admissionPolicy)
+		} else if s.handler.admission.Enabled() {
+			log.Error().Str("op", op).Msg("Admission policy removed from config, keeping the running one until a restart")
+		}
+		s.limiter.SetConfig(cfg.RateLimitConfig)
+		s.handler.fs.SetBackupConfig(cfg.BackupConfig)
+		if tlsCfg != nil {
+			s.certs.SetConfig(cfg.GRPCConfig.TLS, tlsCfg)
+		}
+	}, nil
+}
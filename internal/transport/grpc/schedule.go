@@ -0,0 +1,274 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"hostManager/internal/auth"
+	"hostManager/internal/scheduler"
+	api "hostManager/pkg/gen"
+	apiv2 "hostManager/pkg/gen/v2"
+)
+
+// Mutations a scheduled or pending change can run.
+const (
+	methodSetHostname     = "SetHostname"
+	methodAddDNSServer    = "AddDNSServer"
+	methodRemoveDNSServer = "RemoveDNSServer"
+
+	methodUpdateHostname       = "v2.UpdateHostname"
+	methodUpdateResolverConfig = "v2.UpdateResolverConfig"
+)
+
+// mutationMethods maps the mutations to the RPCs a caller needs permission
+// for to run them.
+var mutationMethods = map[string]string{
+	methodSetHostname:          api.DNSHostnameService_SetHostname_FullMethodName,
+	methodAddDNSServer:         api.DNSHostnameService_AddDNSServer_FullMethodName,
+	methodRemoveDNSServer:      api.DNSHostnameService_RemoveDNSServer_FullMethodName,
+	methodUpdateHostname:       apiv2.HostConfigService_UpdateHostname_FullMethodName,
+	methodUpdateResolverConfig: apiv2.HostConfigService_UpdateResolverConfig_FullMethodName,
+}
+
+// authorizeMutation checks that the caller of ctx may call the RPC of method
+// itself, as the interceptors only authorize the RPC that runs it.
+func (s *Handler) authorizeMutation(ctx context.Context, method string) error {
+	if s.authz == nil {
+		return nil
+	}
+
+	fullMethod, ok := mutationMethods[method]
+	if !ok {
+		return status.Errorf(codes.Internal, "unknown mutation %q", method)
+	}
+
+	return s.authz.Authorize(ctx, fullMethod)
+}
+
+func (s *Handler) ScheduleChange(ctx context.Context, r *api.ScheduleChangeRequest) (*api.ScheduledChange, error) {
+	method, change, err := scheduledMutation(r)
+	if err != nil {
+		return nil, err
+	}
+	if c, ok := change.(interface{ GetValidateOnly() bool }); ok && c.GetValidateOnly() {
+		return nil, status.Error(codes.InvalidArgument, "scheduled changes cannot be validate_only")
+	}
+
+	if err := s.authorizeMutation(ctx, method); err != nil {
+		return nil, err
+	}
+
+	if err := s.freeze.Check(); err != nil {
+		return nil, toStatus(err)
+	}
+
+	payload, err := protojson.Marshal(change)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	job := scheduler.Job{Method: method, Payload: payload, Cron: r.GetCron()}
+	if id, ok := auth.FromContext(ctx); ok {
+		job.Requester = &id
+	}
+	if r.GetRunTime() != nil {
+		job.RunAt = r.GetRunTime().AsTime()
+	}
+
+	job, err = s.scheduler.Add(job)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return scheduledChange(job)
+}
+
+func (s *Handler) ListScheduledChanges(ctx context.Context, r *api.ListScheduledChangesRequest) (*api.ListScheduledChangesResponse, error) {
+	jobs := s.scheduler.List()
+
+	changes := make([]*api.ScheduledChange, 0, len(jobs))
+	for _, job := range jobs {
+		change, err := scheduledChange(job)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+
+	return &api.ListScheduledChangesResponse{Changes: changes}, nil
+}
+
+func (s *Handler) CancelScheduledChange(ctx context.Context, r *api.CancelScheduledChangeRequest) (*api.CancelScheduledChangeResponse, error) {
+	if r.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is empty")
+	}
+
+	if err := s.scheduler.Cancel(r.GetId()); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &api.CancelScheduledChangeResponse{}, nil
+}
+
+// runScheduled executes a due job through the regular handler methods as the
+// identity that scheduled it, so a scheduled change gets the same
+// authorization, validation and admission as an immediate one.
+func (s *Handler) runScheduled(ctx context.Context, job scheduler.Job) error {
+	if job.Requester != nil {
+		ctx = auth.WithIdentity(ctx, *job.Requester)
+	}
+
+	// The policy may have changed since the job was scheduled.
+	if err := s.authorizeMutation(ctx, job.Method); err != nil {
+		return err
+	}
+
+	change, err := scheduledChange(job)
+	if err != nil {
+		return err
+	}
+
+	switch c := change.GetChange().(type) {
+	case *api.ScheduledChange_SetHostname:
+		_, err = s.SetHostname(ctx, c.SetHostname)
+	case *api.ScheduledChange_AddDnsServer:
+		_, err = s.AddDNSServer(ctx, c.AddDnsServer)
+	case *api.ScheduledChange_RemoveDnsServer:
+		_, err = s.RemoveDNSServer(ctx, c.RemoveDnsServer)
+	case *api.ScheduledChange_UpdateHostname:
+		_, err = NewHostConfigHandler(s).UpdateHostname(ctx, c.UpdateHostname)
+	case *api.ScheduledChange_UpdateResolverConfig:
+		_, err = NewHostConfigHandler(s).UpdateResolverConfig(ctx, c.UpdateResolverConfig)
+	}
+
+	return err
+}
+
+// scheduledMutation validates the change of a schedule request and returns
+// the mutation it runs.
+func scheduledMutation(r *api.ScheduleChangeRequest) (string, proto.Message, error) {
+	switch c := r.GetChange().(type) {
+	case *api.ScheduleChangeRequest_SetHostname:
+		if c.SetHostname.GetHostname() == "" {
+			return "", nil, status.Error(codes.InvalidArgument, "hostname is empty")
+		}
+		if c.SetHostname.GetConfirmWithin() != nil {
+			return "", nil, status.Error(codes.InvalidArgument, "scheduled changes cannot be confirmed")
+		}
+		return methodSetHostname, c.SetHostname, nil
+	case *api.ScheduleChangeRequest_AddDnsServer:
+		if c.AddDnsServer.GetDnsServer() == "" {
+			return "", nil, status.Error(codes.InvalidArgument, "dns server is empty")
+		}
+		if c.AddDnsServer.GetConfirmWithin() != nil {
+			return "", nil, status.Error(codes.InvalidArgument, "scheduled changes cannot be confirmed")
+		}
+		if c.AddDnsServer.GetExpireTime() != nil {
+			return "", nil, status.Error(codes.InvalidArgument, "use ttl instead of expire_time for scheduled changes")
+		}
+		return methodAddDNSServer, c.AddDnsServer, nil
+	case *api.ScheduleChangeRequest_RemoveDnsServer:
+		if c.RemoveDnsServer.GetDnsServer() == "" {
+			return "", nil, status.Error(codes.InvalidArgument, "dns server is empty")
+		}
+		if c.RemoveDnsServer.GetConfirmWithin() != nil {
+			return "", nil, status.Error(codes.InvalidArgument, "scheduled changes cannot be confirmed")
+		}
+		return methodRemoveDNSServer, c.RemoveDnsServer, nil
+	case *api.ScheduleChangeRequest_UpdateHostname:
+		if c.UpdateHostname.GetHostname().GetHostname() == "" {
+			return "", nil, status.Error(codes.InvalidArgument, "hostname is empty")
+		}
+		if c.UpdateHostname.GetConfirmWithin() != nil {
+			return "", nil, status.Error(codes.InvalidArgument, "scheduled changes cannot be confirmed")
+		}
+		return methodUpdateHostname, c.UpdateHostname, nil
+	case *api.ScheduleChangeRequest_UpdateResolverConfig:
+		if c.UpdateResolverConfig.GetResolverConfig() == nil {
+			return "", nil, status.Error(codes.InvalidArgument, "resolver_config is empty")
+		}
+		if c.UpdateResolverConfig.GetConfirmWithin() != nil {
+			return "", nil, status.Error(codes.InvalidArgument, "scheduled changes cannot be confirmed")
+		}
+		// A fixed expiry would be in the past from the second run on.
+		if r.GetCron() != "" {
+			for _, ns := range c.UpdateResolverConfig.GetResolverConfig().GetNameservers() {
+				if ns.GetExpireTime() != nil {
+					return "", nil, status.Error(codes.InvalidArgument, "recurring changes cannot set a nameserver expire_time")
+				}
+			}
+		}
+		return methodUpdateResolverConfig, c.UpdateResolverConfig, nil
+	default:
+		return "", nil, status.Error(codes.InvalidArgument, "change is empty")
+	}
+}
+
+func scheduledChange(job scheduler.Job) (*api.ScheduledChange, error) {
+	change := &api.ScheduledChange{
+		Id:         job.ID,
+		Cron:       job.Cron,
+		LastError:  job.LastError,
+		CreateTime: timestamppb.New(job.CreatedAt),
+	}
+	if !job.RunAt.IsZero() {
+		change.RunTime = timestamppb.New(job.RunAt)
+	}
+	if !job.NextRun.IsZero() {
+		change.NextRunTime = timestamppb.New(job.NextRun)
+	}
+	if !job.LastRun.IsZero() {
+		change.LastRunTime = timestamppb.New(job.LastRun)
+	}
+
+	r, err := decodeMutation(job.Method, job.Payload)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "scheduled change %s: %v", job.ID, err)
+	}
+
+	switch r := r.(type) {
+	case *api.SetHostnameRequest:
+		change.Change = &api.ScheduledChange_SetHostname{SetHostname: r}
+	case *api.AddDNSServerRequest:
+		change.Change = &api.ScheduledChange_AddDnsServer{AddDnsServer: r}
+	case *api.RemoveDNSServerRequest:
+		change.Change = &api.ScheduledChange_RemoveDnsServer{RemoveDnsServer: r}
+	case *apiv2.UpdateHostnameRequest:
+		change.Change = &api.ScheduledChange_UpdateHostname{UpdateHostname: r}
+	case *apiv2.UpdateResolverConfigRequest:
+		change.Change = &api.ScheduledChange_UpdateResolverConfig{UpdateResolverConfig: r}
+	}
+
+	return change, nil
+}
+
+// decodeMutation decodes the stored request of a queued mutation.
+func decodeMutation(method string, payload []byte) (proto.Message, error) {
+	var r proto.Message
+	switch method {
+	case methodSetHostname:
+		r = &api.SetHostnameRequest{}
+	case methodAddDNSServer:
+		r = &api.AddDNSServerRequest{}
+	case methodRemoveDNSServer:
+		r = &api.RemoveDNSServerRequest{}
+	case methodUpdateHostname:
+		r = &apiv2.UpdateHostnameRequest{}
+	case methodUpdateResolverConfig:
+		r = &apiv2.UpdateResolverConfigRequest{}
+	default:
+		return nil, fmt.Errorf("unknown method %q", method)
+	}
+
+	if err := protojson.Unmarshal(payload, r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
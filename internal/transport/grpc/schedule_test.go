@@ -0,0 +1,193 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"hostManager/internal/auth"
+	"hostManager/internal/authz"
+	"hostManager/internal/scheduler"
+	api "hostManager/pkg/gen"
+	apiv2 "hostManager/pkg/gen/v2"
+)
+
+// TestScheduledChangeRunsAsRequester checks that a scheduled change is
+// admitted as the identity that scheduled it, also after a restart.
+func TestScheduledChangeRunsAsRequester(t *testing.T) {
+	policy := loadPolicy(t, `
+rules:
+  - name: only-alice
+    actions: [set-hostname]
+    expression: 'request.requester == "alice"'
+`)
+
+	tests := []struct {
+		name      string
+		requester string
+		wantHost  string
+	}{
+		{name: "admitted requester", requester: "alice", wantHost: "new-host\n"},
+		{name: "denied requester", requester: "bob", wantHost: "old-host\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHost(t, policy)
+			stateDir := t.TempDir()
+
+			h.v1.scheduler = scheduler.New(stateDir, h.v1.runScheduled, h.v1.audit)
+			if err := h.v1.scheduler.Start(); err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := auth.WithIdentity(context.Background(), auth.Identity{Method: "jwt", Subject: tt.requester})
+			job := must(h.v1.ScheduleChange(ctx, &api.ScheduleChangeRequest{
+				Change: &api.ScheduleChangeRequest_SetHostname{
+					SetHostname: &api.SetHostnameRequest{Hostname: "new-host"},
+				},
+				RunTime: timestamppb.New(time.Now().Add(200 * time.Millisecond)),
+			}))(t)
+
+			// The job is run by a restarted scheduler, from its persisted
+			// state.
+			h.v1.scheduler.Stop()
+			h.v1.scheduler = scheduler.New(stateDir, h.v1.runScheduled, h.v1.audit)
+			if err := h.v1.scheduler.Start(); err != nil {
+				t.Fatal(err)
+			}
+			defer h.v1.scheduler.Stop()
+
+			deadline := time.Now().Add(5 * time.Second)
+			for len(h.v1.scheduler.List()) > 0 && time.Now().Before(deadline) {
+				time.Sleep(10 * time.Millisecond)
+			}
+			if n := len(h.v1.scheduler.List()); n > 0 {
+				t.Fatalf("job %s did not run, %d jobs left", job.GetId(), n)
+			}
+
+			if got := readFile(t, h.files.Hostname); got != tt.wantHost {
+				t.Errorf("hostname = %q, want %q", got, tt.wantHost)
+			}
+		})
+	}
+}
+
+// TestScheduleChangeAuthorization checks scheduled changes against the RBAC
+// policy of the mutation they run, with the shipped example policy.
+func TestScheduleChangeAuthorization(t *testing.T) {
+	policy, err := authz.Load("../../../config/rbac.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := newTestHost(t, nil)
+	h.v1.authz = authz.NewAuthorizer(policy)
+	h.v1.scheduler = scheduler.New(t.TempDir(), h.v1.runScheduled, h.v1.audit)
+	if err := h.v1.scheduler.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.v1.scheduler.Stop()
+
+	netops := auth.Identity{Method: "jwt", Subject: "carol", Groups: []string{"netops"}}
+	ctx := auth.WithIdentity(context.Background(), netops)
+	runTime := timestamppb.New(time.Now().Add(time.Hour))
+
+	_, err = h.v1.ScheduleChange(ctx, &api.ScheduleChangeRequest{
+		Change:  &api.ScheduleChangeRequest_SetHostname{SetHostname: &api.SetHostnameRequest{Hostname: "new-host"}},
+		RunTime: runTime,
+	})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("scheduling SetHostname as netops: error = %v, want PermissionDenied", err)
+	}
+
+	_, err = h.v1.ScheduleChange(ctx, &api.ScheduleChangeRequest{
+		Change:  &api.ScheduleChangeRequest_AddDnsServer{AddDnsServer: &api.AddDNSServerRequest{DnsServer: "192.0.2.2"}},
+		RunTime: runTime,
+	})
+	if err != nil {
+		t.Errorf("scheduling AddDNSServer as netops: error = %v", err)
+	}
+
+	// A job stored before the policy denied it is checked again when it runs.
+	payload, err := protojson.Marshal(&api.SetHostnameRequest{Hostname: "new-host"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = h.v1.runScheduled(context.Background(), scheduler.Job{Method: methodSetHostname, Payload: payload, Requester: &netops})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("running SetHostname as netops: error = %v, want PermissionDenied", err)
+	}
+	if got := readFile(t, h.files.Hostname); got != "old-host\n" {
+		t.Errorf("hostname = %q, want old-host", got)
+	}
+}
+
+// TestScheduleV2Changes checks that host config updates of the v2 API can be
+// scheduled and run like the v1 mutations.
+func TestScheduleV2Changes(t *testing.T) {
+	h := newTestHost(t, nil)
+	h.v1.scheduler = scheduler.New(t.TempDir(), h.v1.runScheduled, h.v1.audit)
+	if err := h.v1.scheduler.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.v1.scheduler.Stop()
+
+	ctx := context.Background()
+	runTime := timestamppb.New(time.Now().Add(200 * time.Millisecond))
+	nameservers := &fieldmaskpb.FieldMask{Paths: []string{"nameservers"}}
+
+	_, err := h.v1.ScheduleChange(ctx, &api.ScheduleChangeRequest{
+		Change: &api.ScheduleChangeRequest_UpdateResolverConfig{UpdateResolverConfig: &apiv2.UpdateResolverConfigRequest{
+			ResolverConfig: &apiv2.ResolverConfig{Nameservers: []*apiv2.Nameserver{
+				{Address: "192.0.2.3", ExpireTime: timestamppb.New(time.Now().Add(time.Hour))},
+			}},
+			UpdateMask: nameservers,
+		}},
+		Cron: "0 3 * * *",
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("recurring change with a nameserver expiry: error = %v, want InvalidArgument", err)
+	}
+
+	must(h.v1.ScheduleChange(ctx, &api.ScheduleChangeRequest{
+		Change: &api.ScheduleChangeRequest_UpdateHostname{UpdateHostname: &apiv2.UpdateHostnameRequest{
+			Hostname: &apiv2.Hostname{Hostname: "new-host"},
+		}},
+		RunTime: runTime,
+	}))(t)
+	must(h.v1.ScheduleChange(ctx, &api.ScheduleChangeRequest{
+		Change: &api.ScheduleChangeRequest_UpdateResolverConfig{UpdateResolverConfig: &apiv2.UpdateResolverConfigRequest{
+			ResolverConfig: &apiv2.ResolverConfig{Nameservers: []*apiv2.Nameserver{{Address: "192.0.2.3"}}},
+			UpdateMask:     nameservers,
+		}},
+		RunTime: runTime,
+	}))(t)
+
+	listed := must(h.v1.ListScheduledChanges(ctx, &api.ListScheduledChangesRequest{}))(t)
+	if len(listed.GetChanges()) != 2 || listed.GetChanges()[0].GetChange() == nil || listed.GetChanges()[1].GetChange() == nil {
+		t.Fatalf("ListScheduledChanges() = %v, want both changes", listed.GetChanges())
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(h.v1.scheduler.List()) > 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := len(h.v1.scheduler.List()); n > 0 {
+		t.Fatalf("%d jobs did not run", n)
+	}
+
+	if got := readFile(t, h.files.Hostname); got != "new-host\n" {
+		t.Errorf("hostname = %q, want new-host", got)
+	}
+	servers := must(h.v1.ListDNSServers(ctx, &api.ListDNSServersRequest{}))(t)
+	if got := servers.GetDnsServers(); len(got) != 1 || got[0] != "192.0.2.3" {
+		t.Errorf("dns servers = %v, want [192.0.2.3]", got)
+	}
+}
@@ -3,25 +3,126 @@ package grpc
 import (
 	"fmt"
 	"net"
+	"net/http"
 
+	"github.com/improbable-eng/grpc-web/go/grpcweb"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 
+	"hostManager/internal/auth"
+	"hostManager/internal/authz"
+	"hostManager/internal/certs"
 	"hostManager/internal/config"
+	"hostManager/internal/logging"
+	"hostManager/internal/metrics"
+	"hostManager/internal/ratelimit"
 )
 
 type Server struct {
 	post       int
+	unix       config.UnixSocketConfig
+	inProcess  *inProcessListener
+	cors       config.CORSConfig
 	grpcServer *grpc.Server
+	handler    *Handler
+	logs       *logging.Logs
+	certs      *certs.Reloader
+	jwt        *auth.JWTVerifier
+	authz      *authz.Authorizer
+	limiter    *ratelimit.Limiter
 	log        zerolog.Logger
 }
 
-func NewServer(post int, log zerolog.Logger, cfg config.BackupConfig) *Server {
-	grpcServer := grpc.NewServer()
-	Register(grpcServer, cfg)
+func NewServer(cfg *config.Config, logs *logging.Logs, log zerolog.Logger) (*Server, error) {
+	const op = "grpc.NewServer"
 
-	return &Server{post: post, log: log, grpcServer: grpcServer}
+	var verifier *auth.JWTVerifier
+	if jwtCfg := cfg.JWTConfig; jwtCfg.JWKSFile != "" || jwtCfg.JWKSURL != "" {
+		var err error
+		verifier, err = auth.NewJWTVerifier(jwtCfg)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	unary := []grpc.UnaryServerInterceptor{
+		requestLogUnaryInterceptor(log),
+		metrics.UnaryServerInterceptor(),
+		recoveryUnaryInterceptor(),
+		auth.UnaryServerInterceptor(verifier),
+	}
+	stream := []grpc.StreamServerInterceptor{
+		requestLogStreamInterceptor(log),
+		metrics.StreamServerInterceptor(),
+		recoveryStreamInterceptor(),
+		auth.StreamServerInterceptor(verifier),
+	}
+
+	// The limiter and the authorizer are installed even when disabled, so a
+	// config reload can enable them.
+	limiter := ratelimit.New(cfg.RateLimitConfig)
+	unary = append(unary, limiter.UnaryServerInterceptor())
+	stream = append(stream, limiter.StreamServerInterceptor())
+
+	var policy *authz.Policy
+	if cfg.AuthzConfig.PolicyFile != "" {
+		var err error
+		policy, err = authz.Load(cfg.AuthzConfig.PolicyFile)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	authorizer := authz.NewAuthorizer(policy)
+	unary = append(unary, authorizer.UnaryServerInterceptor())
+	stream = append(stream, authorizer.StreamServerInterceptor())
+
+	opts := []grpc.ServerOption{
+		// The stats handler starts the server span from the propagated trace
+		// context before any interceptor runs.
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}
+
+	var (
+		reloader *certs.Reloader
+		creds    = insecure.NewCredentials()
+	)
+	if cfg.GRPCConfig.TLS.CertFile != "" {
+		var err error
+		reloader, err = certs.NewReloader(cfg.GRPCConfig.TLS, log)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		creds = credentials.NewTLS(reloader.TLSConfig())
+	}
+	opts = append(opts, grpc.Creds(auth.NewUnixCredentials(creds)))
+
+	grpcServer := grpc.NewServer(opts...)
+	handler, err := Register(grpcServer, cfg, logs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	handler.authz = authorizer
+
+	return &Server{
+		post:       cfg.GRPCConfig.Port,
+		unix:       cfg.GRPCConfig.Unix,
+		inProcess:  newInProcessListener(),
+		cors:       cfg.HTTPConfig.CORS,
+		log:        log,
+		grpcServer: grpcServer,
+		handler:    handler,
+		logs:       logs,
+		certs:      reloader,
+		jwt:        verifier,
+		authz:      authorizer,
+		limiter:    limiter,
+	}, nil
 }
 
 func (s *Server) Start() error {
@@ -41,9 +142,76 @@ func (s *Server) Start() error {
 		}
 	}()
 
+	return s.StartLocal()
+}
+
+// StartLocal serves only the in-process gateway and the Unix socket, for when
+// network clients reach the server through Handler on a shared port.
+func (s *Server) StartLocal() error {
+	const op = "grpc.StartLocal"
+	s.log = log.With().Str("op", op).Logger()
+
+	go func() {
+		if err := s.grpcServer.Serve(s.inProcess); err != nil {
+			s.log.Fatal().Msg("grpc server failed to serve in-process connections")
+		}
+	}()
+
+	if s.unix.Path == "" {
+		return nil
+	}
+
+	ul, err := listenUnix(s.unix)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info().Msgf("gRPC server started on unix://%s", s.unix.Path)
+
+	go func() {
+		if err := s.grpcServer.Serve(ul); err != nil {
+			s.log.Fatal().Msg("grpc server failed to serve on unix socket")
+		}
+	}()
+
 	return nil
 }
 
+// Handler serves native gRPC over HTTP/2 and gRPC-Web over any HTTP version
+// through net/http. Peer identities come from the TLS state of the request,
+// and browsers may call gRPC-Web from the origins allowed for the REST API.
+func (s *Server) Handler() http.Handler {
+	web := grpcweb.WrapServer(s.grpcServer, grpcweb.WithOriginFunc(s.cors.OriginAllowed))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if web.IsGrpcWebRequest(r) || web.IsAcceptableGrpcCorsRequest(r) {
+			web.ServeHTTP(w, r)
+			return
+		}
+
+		s.grpcServer.ServeHTTP(w, r)
+	})
+}
+
 func (s *Server) Stop() {
+	s.handler.health.Stop()
+	// Change and log streams only end when the client leaves, which a
+	// graceful stop would wait for.
+	s.handler.watcher.Stop()
+	s.logs.CloseTails()
 	s.grpcServer.GracefulStop()
+
+	if err := s.handler.Close(); err != nil {
+		s.log.Error().Err(err).Msg("failed to close handler")
+	}
+
+	if s.jwt != nil {
+		s.jwt.Close()
+	}
+
+	if s.certs != nil {
+		if err := s.certs.Close(); err != nil {
+			s.log.Error().Err(err).Msg("failed to close certificate watcher")
+		}
+	}
 }
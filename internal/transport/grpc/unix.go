@@ -0,0 +1,102 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"os/user"
+	"strconv"
+	"syscall"
+
+	"hostManager/internal/config"
+)
+
+// listenUnix listens on the configured socket, replacing a stale socket left
+// behind by a previous run, and applies the configured owner, group and mode.
+func listenUnix(cfg config.UnixSocketConfig) (net.Listener, error) {
+	mode, err := strconv.ParseUint(cfg.Mode, 8, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid socket mode %q: %w", cfg.Mode, err)
+	}
+
+	uid, gid := -1, -1
+	if cfg.Owner != "" {
+		if uid, err = lookupID(cfg.Owner, lookupUser); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.Group != "" {
+		if gid, err = lookupID(cfg.Group, lookupGroup); err != nil {
+			return nil, err
+		}
+	}
+
+	if info, err := os.Lstat(cfg.Path); err == nil {
+		if info.Mode().Type() != fs.ModeSocket {
+			return nil, fmt.Errorf("%s exists and is not a socket", cfg.Path)
+		}
+		if err := os.Remove(cfg.Path); err != nil {
+			return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	// Create the socket accessible to the owner only, so that nobody connects
+	// before the configured mode and owner are applied. The umask is process
+	// wide, so keep the window short.
+	umask := syscall.Umask(0o177)
+	l, err := net.Listen("unix", cfg.Path)
+	syscall.Umask(umask)
+	if err != nil {
+		return nil, err
+	}
+
+	// Hand the socket over before widening its mode, or the group of the
+	// server process could connect in between.
+	if uid != -1 || gid != -1 {
+		if err := os.Chown(cfg.Path, uid, gid); err != nil {
+			_ = l.Close()
+			return nil, fmt.Errorf("failed to set socket owner: %w", err)
+		}
+	}
+
+	if err := os.Chmod(cfg.Path, fs.FileMode(mode)); err != nil {
+		_ = l.Close()
+		return nil, fmt.Errorf("failed to set socket mode: %w", err)
+	}
+
+	return l, nil
+}
+
+// lookupID resolves a user or group name, accepting numeric ids as is.
+func lookupID(name string, lookup func(string) (string, error)) (int, error) {
+	if id, err := strconv.Atoi(name); err == nil {
+		return id, nil
+	}
+
+	id, err := lookup(name)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(id)
+}
+
+func lookupUser(name string) (string, error) {
+	u, err := user.Lookup(name)
+	if err != nil {
+		return "", err
+	}
+	return u.Uid, nil
+}
+
+func lookupGroup(name string) (string, error) {
+	g, err := user.LookupGroup(name)
+	if err != nil {
+		return "", err
+	}
+	return g.Gid, nil
+}
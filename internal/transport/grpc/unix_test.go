@@ -0,0 +1,68 @@
+package grpc
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"hostManager/internal/config"
+)
+
+func TestListenUnix(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "server.sock")
+
+	old := syscall.Umask(0o022)
+	defer syscall.Umask(old)
+
+	l, err := listenUnix(config.UnixSocketConfig{Path: path, Mode: "0660"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := info.Mode().Perm(); got != 0o660 {
+		t.Errorf("socket mode = %o, want 660", got)
+	}
+	if got := syscall.Umask(0o022); got != 0o022 {
+		t.Errorf("umask after listenUnix = %o, want 022", got)
+	}
+}
+
+func TestListenUnixOwner(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "server.sock")
+	cfg := config.UnixSocketConfig{Path: path, Group: "4242", Mode: "0660"}
+
+	l, err := listenUnix(cfg)
+	if os.Geteuid() != 0 {
+		// Only root may hand the socket to another group. The socket must
+		// not be left behind with the wider mode.
+		if err == nil {
+			l.Close()
+			t.Fatal("listenUnix() succeeded without permission to change the group")
+		}
+		if _, statErr := os.Stat(path); !os.IsNotExist(statErr) {
+			t.Errorf("socket left behind after a failed chown: %v", statErr)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := info.Sys().(*syscall.Stat_t).Gid; got != 4242 {
+		t.Errorf("socket group = %d, want 4242", got)
+	}
+	if got := info.Mode().Perm(); got != 0o660 {
+		t.Errorf("socket mode = %o, want 660", got)
+	}
+}
@@ -0,0 +1,54 @@
+package grpc
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"hostManager/internal/service"
+	api "hostManager/pkg/gen"
+)
+
+func (s *Handler) WatchChanges(r *api.WatchChangesRequest, stream api.DNSHostnameService_WatchChangesServer) error {
+	events, updates, cancel := s.watcher.Subscribe(r.GetAfterId())
+	defer cancel()
+
+	// Send the headers right away so that clients can tell an accepted stream
+	// from a rejected one before the first change.
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		if err := stream.Send(changeEvent(event)); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-updates:
+			if !ok {
+				return status.Error(codes.Unavailable, "change stream closed, resume with after_id")
+			}
+			if err := stream.Send(changeEvent(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func changeEvent(event service.HostEvent) *api.ChangeEvent {
+	return &api.ChangeEvent{
+		Id:         event.ID,
+		Resource:   string(event.Resource),
+		Version:    event.Version,
+		Time:       timestamppb.New(event.Time),
+		Hostname:   event.Hostname,
+		DnsServers: event.DNSServers,
+		Snapshot:   event.Snapshot,
+	}
+}
@@ -0,0 +1,525 @@
+package mux
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const (
+	connectVersionHeader  = "Connect-Protocol-Version"
+	connectTimeoutHeader  = "Connect-Timeout-Ms"
+	connectStreamPrefix   = "application/connect+"
+	grpcWebContentType    = "application/grpc-web+proto"
+	maxConnectRequestSize = 4 << 20
+
+	// flagEndStream marks the last message of a Connect stream and
+	// flagTrailer the trailers of a gRPC-Web response.
+	flagEndStream = 0x02
+	flagTrailer   = 0x80
+)
+
+// connectCodes names the gRPC codes as the Connect protocol does.
+var connectCodes = map[codes.Code]string{
+	codes.Canceled:           "canceled",
+	codes.Unknown:            "unknown",
+	codes.InvalidArgument:    "invalid_argument",
+	codes.DeadlineExceeded:   "deadline_exceeded",
+	codes.NotFound:           "not_found",
+	codes.AlreadyExists:      "already_exists",
+	codes.PermissionDenied:   "permission_denied",
+	codes.ResourceExhausted:  "resource_exhausted",
+	codes.FailedPrecondition: "failed_precondition",
+	codes.Aborted:            "aborted",
+	codes.OutOfRange:         "out_of_range",
+	codes.Unimplemented:      "unimplemented",
+	codes.Internal:           "internal",
+	codes.Unavailable:        "unavailable",
+	codes.DataLoss:           "data_loss",
+	codes.Unauthenticated:    "unauthenticated",
+}
+
+// isConnect reports whether r is a Connect call: a streaming call by its
+// content type, a unary one by its protocol version header, or a CORS
+// preflight for either.
+func isConnect(r *http.Request) bool {
+	if r.Method == http.MethodOptions {
+		return strings.Contains(strings.ToLower(r.Header.Get("Access-Control-Request-Headers")),
+			strings.ToLower(connectVersionHeader))
+	}
+
+	return strings.HasPrefix(r.Header.Get("Content-Type"), connectStreamPrefix) || r.Header.Get(connectVersionHeader) != ""
+}
+
+// serveConnect serves unary and server streaming calls of the Connect
+// protocol by translating them to gRPC-Web, so they pass the same
+// interceptors with the caller's TLS identity. GET requests and compression
+// are not supported.
+func (s *Server) serveConnect(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodOptions {
+		// The gRPC-Web CORS handler allows any request header.
+		r = r.Clone(r.Context())
+		r.Header.Set("Access-Control-Request-Headers", r.Header.Get("Access-Control-Request-Headers")+", x-grpc-web")
+		s.grpc.ServeHTTP(w, r)
+		return
+	}
+
+	contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	stream := strings.HasPrefix(contentType, connectStreamPrefix)
+	codec := strings.TrimPrefix(strings.TrimPrefix(contentType, connectStreamPrefix), "application/")
+	if codec != "json" && codec != "proto" {
+		w.Header().Set("Accept-Post", "application/json, application/proto, application/connect+json, application/connect+proto")
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		return
+	}
+	c := &connectCall{stream: stream, json: codec == "json"}
+
+	if r.Method != http.MethodPost {
+		c.writeError(w, codes.Unimplemented, "only POST requests are supported")
+		return
+	}
+	if v := r.Header.Get(connectVersionHeader); v != "" && v != "1" {
+		c.writeError(w, codes.InvalidArgument, fmt.Sprintf("unsupported connect protocol version %q", v))
+		return
+	}
+
+	method, err := lookupMethod(r.URL.Path)
+	if err != nil {
+		c.writeError(w, codes.Unimplemented, err.Error())
+		return
+	}
+	if method.IsStreamingClient() || method.IsStreamingServer() != stream {
+		c.writeError(w, codes.Unimplemented, fmt.Sprintf("%s is not supported as a %s call", method.FullName(), callType(stream)))
+		return
+	}
+	if c.output, err = protoregistry.GlobalTypes.FindMessageByName(method.Output().FullName()); err != nil {
+		c.writeError(w, codes.Internal, err.Error())
+		return
+	}
+
+	encodingHeader := "Content-Encoding"
+	if stream {
+		encodingHeader = "Connect-Content-Encoding"
+	}
+	if encoding := r.Header.Get(encodingHeader); encoding != "" && encoding != "identity" {
+		c.writeError(w, codes.Unimplemented, fmt.Sprintf("unsupported compression %q", encoding))
+		return
+	}
+
+	msg, err := c.readRequest(w, r, method.Input().FullName())
+	if err != nil {
+		c.writeError(w, codes.InvalidArgument, err.Error())
+		return
+	}
+
+	req, err := grpcWebRequest(r, msg)
+	if err != nil {
+		c.writeError(w, codes.InvalidArgument, err.Error())
+		return
+	}
+
+	c.w = w
+	c.header = make(http.Header)
+	s.grpc.ServeHTTP(c, req)
+	c.finish()
+}
+
+// lookupMethod finds the method of a "/<service>/<method>" path.
+func lookupMethod(path string) (protoreflect.MethodDescriptor, error) {
+	service, name, ok := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	if !ok {
+		return nil, fmt.Errorf("unknown method %s", path)
+	}
+
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, fmt.Errorf("unknown service %s", service)
+	}
+	serviceDesc, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("unknown service %s", service)
+	}
+
+	method := serviceDesc.Methods().ByName(protoreflect.Name(name))
+	if method == nil {
+		return nil, fmt.Errorf("unknown method %s", path)
+	}
+
+	return method, nil
+}
+
+func callType(stream bool) string {
+	if stream {
+		return "streaming"
+	}
+	return "unary"
+}
+
+// grpcWebRequest turns a Connect request into a gRPC-Web request carrying msg.
+// Other headers are passed on as metadata.
+func grpcWebRequest(r *http.Request, msg []byte) (*http.Request, error) {
+	req := r.Clone(r.Context())
+
+	body := appendFrame(nil, 0, msg)
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+
+	req.Header.Set("Content-Type", grpcWebContentType)
+	req.Header.Set("X-Grpc-Web", "1")
+	for _, key := range []string{"Content-Length", "Content-Encoding", "Accept-Encoding", connectVersionHeader,
+		connectTimeoutHeader, "Connect-Content-Encoding", "Connect-Accept-Encoding"} {
+		req.Header.Del(key)
+	}
+
+	if timeout := r.Header.Get(connectTimeoutHeader); timeout != "" {
+		ms, err := strconv.ParseUint(timeout, 10, 64)
+		if err != nil || len(timeout) > 10 {
+			return nil, fmt.Errorf("invalid %s %q", connectTimeoutHeader, timeout)
+		}
+		// gRPC timeouts have at most 8 digits.
+		if ms < 1e8 {
+			req.Header.Set("Grpc-Timeout", strconv.FormatUint(ms, 10)+"m")
+		} else {
+			req.Header.Set("Grpc-Timeout", strconv.FormatUint(ms/1000, 10)+"S")
+		}
+	}
+
+	return req, nil
+}
+
+// connectCall answers a Connect call from the gRPC-Web response written to
+// it. Streamed messages are passed on as they arrive; a unary response is
+// held back until its status is known.
+type connectCall struct {
+	w      http.ResponseWriter
+	header http.Header
+	stream bool
+	json   bool
+	output protoreflect.MessageType
+
+	// buf holds the gRPC-Web frames not parsed yet.
+	buf         []byte
+	msg         []byte
+	trailer     http.Header
+	wroteHeader bool
+	err         error
+}
+
+func (c *connectCall) Header() http.Header {
+	return c.header
+}
+
+// WriteHeader ignores the HTTP status of the gRPC-Web response, which is 200
+// whatever the outcome of the call.
+func (c *connectCall) WriteHeader(int) {}
+
+func (c *connectCall) Write(b []byte) (int, error) {
+	c.buf = append(c.buf, b...)
+
+	for len(c.buf) >= 5 {
+		size := int(binary.BigEndian.Uint32(c.buf[1:5]))
+		if len(c.buf) < 5+size {
+			break
+		}
+		flags, payload := c.buf[0], c.buf[5:5+size]
+		c.buf = c.buf[5+size:]
+
+		if flags&flagTrailer != 0 {
+			c.trailer = parseTrailer(payload)
+			continue
+		}
+		if c.err != nil {
+			continue
+		}
+		if !c.stream {
+			c.msg = payload
+			continue
+		}
+
+		msg, err := c.encode(payload)
+		if err != nil {
+			c.err = err
+			continue
+		}
+		c.writeHeader()
+		if _, err := c.w.Write(appendFrame(nil, 0, msg)); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(b), nil
+}
+
+func (c *connectCall) Flush() {
+	if f, ok := c.w.(http.Flusher); ok && c.wroteHeader {
+		f.Flush()
+	}
+}
+
+// readRequest reads the request message and returns it in the binary
+// encoding.
+func (c *connectCall) readRequest(w http.ResponseWriter, r *http.Request, input protoreflect.FullName) ([]byte, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxConnectRequestSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request: %w", err)
+	}
+
+	if c.stream {
+		// A server streaming call sends exactly one message.
+		if len(body) < 5 || body[0] != 0 || int(binary.BigEndian.Uint32(body[1:5])) != len(body)-5 {
+			return nil, errors.New("request must hold a single uncompressed message")
+		}
+		body = body[5:]
+	}
+
+	if !c.json {
+		return body, nil
+	}
+
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(input)
+	if err != nil {
+		return nil, err
+	}
+	msg := mt.New().Interface()
+	if err := protojson.Unmarshal(body, msg); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	return proto.Marshal(msg)
+}
+
+// encode converts a binary response message to the codec of the call.
+func (c *connectCall) encode(payload []byte) ([]byte, error) {
+	if !c.json {
+		return payload, nil
+	}
+
+	msg := c.output.New().Interface()
+	if err := proto.Unmarshal(payload, msg); err != nil {
+		return nil, err
+	}
+
+	return protojson.Marshal(msg)
+}
+
+func (c *connectCall) contentType() string {
+	codec := "proto"
+	if c.json {
+		codec = "json"
+	}
+	if c.stream {
+		return connectStreamPrefix + codec
+	}
+	return "application/" + codec
+}
+
+// writeHeader sends the response headers the server set, leaving out those
+// of the gRPC-Web protocol.
+func (c *connectCall) writeHeader() {
+	if c.wroteHeader {
+		return
+	}
+	c.wroteHeader = true
+
+	h := c.w.Header()
+	for key, values := range c.header {
+		if isGRPCHeader(key) {
+			continue
+		}
+		h[key] = values
+	}
+	h.Set("Content-Type", c.contentType())
+	c.w.WriteHeader(http.StatusOK)
+}
+
+// finish ends the call with the status the server sent in the trailers or,
+// for responses without a message, in the headers.
+func (c *connectCall) finish() {
+	trailer := c.trailer
+	if trailer.Get("Grpc-Status") == "" {
+		trailer = c.header
+	}
+
+	code := codes.Unknown
+	msg := "no status in the response"
+	if n, err := strconv.ParseUint(trailer.Get("Grpc-Status"), 10, 32); err == nil {
+		code = codes.Code(n)
+		msg, err = url.PathUnescape(trailer.Get("Grpc-Message"))
+		if err != nil {
+			msg = trailer.Get("Grpc-Message")
+		}
+	}
+	if code == codes.OK && c.err != nil {
+		code, msg = codes.Internal, c.err.Error()
+	}
+	details := statusDetails(trailer.Get("Grpc-Status-Details-Bin"))
+
+	if c.stream {
+		c.writeHeader()
+		end := struct {
+			Error    *connectError       `json:"error,omitempty"`
+			Metadata map[string][]string `json:"metadata,omitempty"`
+		}{Metadata: metadata(c.trailer)}
+		if code != codes.OK {
+			end.Error = newConnectError(code, msg, details)
+		}
+		data, _ := json.Marshal(end)
+		_, _ = c.w.Write(appendFrame(nil, flagEndStream, data))
+		return
+	}
+
+	for key, values := range metadata(c.trailer) {
+		c.w.Header()["Trailer-"+key] = values
+	}
+
+	if code != codes.OK {
+		for key, values := range c.header {
+			if !isGRPCHeader(key) {
+				c.w.Header()[key] = values
+			}
+		}
+		c.writeErrorBody(c.w, newConnectError(code, msg, details))
+		return
+	}
+
+	body, err := c.encode(c.msg)
+	if err != nil {
+		c.writeError(c.w, codes.Internal, err.Error())
+		return
+	}
+	c.writeHeader()
+	_, _ = c.w.Write(body)
+}
+
+// connectError is the error of a Connect call. Details hold the binary
+// encoding of each message without padding.
+type connectError struct {
+	Code    string          `json:"code"`
+	Message string          `json:"message,omitempty"`
+	Details []connectDetail `json:"details,omitempty"`
+
+	status int
+}
+
+type connectDetail struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+func newConnectError(code codes.Code, msg string, details []connectDetail) *connectError {
+	name, ok := connectCodes[code]
+	if !ok {
+		name, code = connectCodes[codes.Unknown], codes.Unknown
+	}
+
+	return &connectError{Code: name, Message: msg, Details: details, status: runtime.HTTPStatusFromCode(code)}
+}
+
+// writeError fails the call before it reached the server.
+func (c *connectCall) writeError(w http.ResponseWriter, code codes.Code, msg string) {
+	err := newConnectError(code, msg, nil)
+	if !c.stream {
+		c.writeErrorBody(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", c.contentType())
+	w.WriteHeader(http.StatusOK)
+	data, _ := json.Marshal(struct {
+		Error *connectError `json:"error"`
+	}{err})
+	_, _ = w.Write(appendFrame(nil, flagEndStream, data))
+}
+
+// writeErrorBody writes the error of a unary call, which is always JSON.
+func (c *connectCall) writeErrorBody(w http.ResponseWriter, err *connectError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(err.status)
+	_ = json.NewEncoder(w).Encode(err)
+}
+
+// statusDetails decodes the details of a gRPC status.
+func statusDetails(bin string) []connectDetail {
+	if bin == "" {
+		return nil
+	}
+
+	data, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(bin, "="))
+	if err != nil {
+		return nil
+	}
+	var st spb.Status
+	if err := proto.Unmarshal(data, &st); err != nil {
+		return nil
+	}
+
+	details := make([]connectDetail, 0, len(st.GetDetails()))
+	for _, detail := range st.GetDetails() {
+		details = append(details, connectDetail{
+			Type:  strings.TrimPrefix(detail.GetTypeUrl(), "type.googleapis.com/"),
+			Value: base64.RawStdEncoding.EncodeToString(detail.GetValue()),
+		})
+	}
+
+	return details
+}
+
+// metadata returns the trailers the server set, without those of the gRPC
+// protocol.
+func metadata(trailer http.Header) map[string][]string {
+	md := make(map[string][]string)
+	for key, values := range trailer {
+		if !isGRPCHeader(key) {
+			md[key] = values
+		}
+	}
+	if len(md) == 0 {
+		return nil
+	}
+
+	return md
+}
+
+func isGRPCHeader(key string) bool {
+	key = http.CanonicalHeaderKey(key)
+	return strings.HasPrefix(key, "Grpc-") || key == "Content-Type" || key == "Content-Length" || key == "Trailer"
+}
+
+// parseTrailer parses the trailers of a gRPC-Web response, which are encoded
+// like HTTP/1 headers.
+func parseTrailer(payload []byte) http.Header {
+	r := textproto.NewReader(bufio.NewReader(io.MultiReader(bytes.NewReader(payload), strings.NewReader("\r\n"))))
+	h, err := r.ReadMIMEHeader()
+	if err != nil && len(h) == 0 {
+		return nil
+	}
+
+	return http.Header(h)
+}
+
+// appendFrame appends a message framed as in gRPC-Web and Connect streams: a
+// flags byte, the big-endian length and the data.
+func appendFrame(b []byte, flags byte, data []byte) []byte {
+	b = append(b, flags, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(b[len(b)-4:], uint32(len(data)))
+	return append(b, data...)
+}
@@ -0,0 +1,221 @@
+package mux
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+
+	grpcServer := grpc.NewServer()
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("dns", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	t.Cleanup(grpcServer.Stop)
+
+	rest := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	return &Server{grpc: grpcweb.WrapServer(grpcServer), rest: rest}
+}
+
+func TestConnectUnary(t *testing.T) {
+	protoRequest, err := proto.Marshal(&healthpb.HealthCheckRequest{Service: "dns"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		path        string
+		contentType string
+		header      map[string]string
+		body        []byte
+		wantStatus  int
+		// wantBody is checked as a substring.
+		wantBody string
+	}{
+		{
+			name:        "json",
+			path:        "/grpc.health.v1.Health/Check",
+			contentType: "application/json",
+			body:        []byte(`{"service":"dns"}`),
+			wantStatus:  http.StatusOK,
+			wantBody:    `"status":"SERVING"`,
+		},
+		{
+			name:        "json with charset",
+			path:        "/grpc.health.v1.Health/Check",
+			contentType: "application/json; charset=utf-8",
+			header:      map[string]string{connectTimeoutHeader: "5000"},
+			body:        []byte(`{"service":"dns"}`),
+			wantStatus:  http.StatusOK,
+			wantBody:    `"status":"SERVING"`,
+		},
+		{
+			name:        "proto",
+			path:        "/grpc.health.v1.Health/Check",
+			contentType: "application/proto",
+			body:        protoRequest,
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:        "server error",
+			path:        "/grpc.health.v1.Health/Check",
+			contentType: "application/json",
+			body:        []byte(`{"service":"other"}`),
+			wantStatus:  http.StatusNotFound,
+			wantBody:    `"code":"not_found"`,
+		},
+		{
+			name:        "unknown method",
+			path:        "/grpc.health.v1.Health/Nope",
+			contentType: "application/json",
+			body:        []byte(`{}`),
+			wantStatus:  http.StatusNotImplemented,
+			wantBody:    `"code":"unimplemented"`,
+		},
+		{
+			name:        "malformed request",
+			path:        "/grpc.health.v1.Health/Check",
+			contentType: "application/json",
+			body:        []byte(`{"service":`),
+			wantStatus:  http.StatusBadRequest,
+			wantBody:    `"code":"invalid_argument"`,
+		},
+		{
+			name:        "streaming method",
+			path:        "/grpc.health.v1.Health/Watch",
+			contentType: "application/json",
+			body:        []byte(`{}`),
+			wantStatus:  http.StatusNotImplemented,
+			wantBody:    `"code":"unimplemented"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t)
+
+			r := httptest.NewRequest(http.MethodPost, tt.path, bytes.NewReader(tt.body))
+			r.Header.Set("Content-Type", tt.contentType)
+			r.Header.Set(connectVersionHeader, "1")
+			for key, value := range tt.header {
+				r.Header.Set(key, value)
+			}
+			w := httptest.NewRecorder()
+			s.serveHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, tt.wantStatus, w.Body)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("body = %s, want it to contain %s", w.Body, tt.wantBody)
+			}
+
+			if tt.name == "proto" {
+				var resp healthpb.HealthCheckResponse
+				if err := proto.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+					t.Fatal(err)
+				}
+				if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+					t.Errorf("status = %v, want SERVING", resp.GetStatus())
+				}
+			}
+		})
+	}
+}
+
+func TestConnectServerStream(t *testing.T) {
+	s := newTestServer(t)
+
+	body := appendFrame(nil, 0, []byte(`{"service":"dns"}`))
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	r := httptest.NewRequest(http.MethodPost, "/grpc.health.v1.Health/Watch", bytes.NewReader(body)).WithContext(ctx)
+	r.Header.Set("Content-Type", "application/connect+json")
+	w := httptest.NewRecorder()
+	s.serveHTTP(w, r)
+
+	if got := w.Header().Get("Content-Type"); got != "application/connect+json" {
+		t.Fatalf("content type = %q, want application/connect+json", got)
+	}
+
+	frames := readFrames(t, w.Body)
+	if len(frames) < 2 {
+		t.Fatalf("got %d frames, want a message and the end of the stream", len(frames))
+	}
+	if first := frames[0]; first.flags != 0 || !strings.Contains(string(first.data), `"status":"SERVING"`) {
+		t.Errorf("first frame = %d %s, want the serving status", first.flags, first.data)
+	}
+
+	last := frames[len(frames)-1]
+	if last.flags != flagEndStream {
+		t.Fatalf("last frame flags = %d, want end of stream", last.flags)
+	}
+	var end struct {
+		Error *connectError `json:"error"`
+	}
+	if err := json.Unmarshal(last.data, &end); err != nil {
+		t.Fatal(err)
+	}
+	// The stream only ends with the client leaving.
+	if end.Error == nil {
+		t.Errorf("end of stream = %s, want an error", last.data)
+	}
+}
+
+func TestServeHTTPRoutesREST(t *testing.T) {
+	s := newTestServer(t)
+
+	// A REST call with a JSON body but without the Connect header.
+	r := httptest.NewRequest(http.MethodPost, "/v1/hostname", strings.NewReader(`{}`))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.serveHTTP(w, r)
+
+	if w.Code != http.StatusTeapot {
+		t.Errorf("status = %d, want the REST handler", w.Code)
+	}
+}
+
+type frame struct {
+	flags byte
+	data  []byte
+}
+
+func readFrames(t *testing.T, r io.Reader) []frame {
+	t.Helper()
+
+	var frames []frame
+	for {
+		var head [5]byte
+		if _, err := io.ReadFull(r, head[:]); err == io.EOF {
+			return frames
+		} else if err != nil {
+			t.Fatal(err)
+		}
+
+		data := make([]byte, binary.BigEndian.Uint32(head[1:]))
+		if _, err := io.ReadFull(r, data); err != nil {
+			t.Fatal(err)
+		}
+		frames = append(frames, frame{flags: head[0], data: data})
+	}
+}
@@ -0,0 +1,117 @@
+package mux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/rs/zerolog"
+
+	"hostManager/internal/certs"
+	"hostManager/internal/config"
+)
+
+// Server serves gRPC, gRPC-Web, Connect and the REST API on a single TLS port.
+// HTTP/2 is negotiated through ALPN, and requests are told apart by content
+// type and the Connect protocol version header.
+type Server struct {
+	cfg        config.MuxConfig
+	grpc       http.Handler
+	rest       http.Handler
+	certs      *certs.Reloader
+	httpServer *http.Server
+	log        zerolog.Logger
+}
+
+// NewServer creates a server dispatching gRPC, gRPC-Web and Connect requests
+// to grpc and everything else to rest.
+func NewServer(cfg config.MuxConfig, grpc, rest http.Handler, log zerolog.Logger) (*Server, error) {
+	const op = "mux.NewServer"
+
+	reloader, err := certs.NewReloader(cfg.TLS, log)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Server{cfg: cfg, grpc: grpc, rest: rest, certs: reloader, log: log}, nil
+}
+
+func (s *Server) Start() error {
+	const op = "mux.Start"
+	s.log = s.log.With().Str("op", op).Logger()
+
+	l, err := net.Listen("tcp", ":"+s.cfg.Port)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.httpServer = &http.Server{
+		Handler:   http.HandlerFunc(s.serveHTTP),
+		TLSConfig: s.certs.TLSConfig("h2", "http/1.1"),
+	}
+
+	s.log.Info().Msgf("gRPC, gRPC-Web, Connect and REST server started on %s", l.Addr().String())
+
+	go func() {
+		if err := s.httpServer.ServeTLS(l, "", ""); !errors.Is(err, http.ErrServerClosed) {
+			s.log.Fatal().Err(err).Msg("server failed to serve")
+		}
+	}()
+
+	return nil
+}
+
+// PrepareReload checks the TLS certificates of cfg and returns a function
+// switching to them. The port only changes with a restart.
+func (s *Server) PrepareReload(cfg config.MuxConfig) (func(), error) {
+	const op = "mux.PrepareReload"
+
+	tlsCfg, err := certs.ServerConfig(cfg.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return func() { s.certs.SetConfig(cfg.TLS, tlsCfg) }, nil
+}
+
+func (s *Server) Stop() error {
+	const op = "mux.Stop"
+
+	if err := s.httpServer.Shutdown(context.Background()); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.certs.Close(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if isGRPC(r) {
+		s.grpc.ServeHTTP(w, r)
+		return
+	}
+
+	if isConnect(r) {
+		s.serveConnect(w, r)
+		return
+	}
+
+	s.rest.ServeHTTP(w, r)
+}
+
+// isGRPC reports whether r is a gRPC or gRPC-Web call, whose content types
+// all start with application/grpc, or a CORS preflight for gRPC-Web.
+func isGRPC(r *http.Request) bool {
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+		return true
+	}
+
+	return r.Method == http.MethodOptions &&
+		strings.Contains(strings.ToLower(r.Header.Get("Access-Control-Request-Headers")), "x-grpc-web")
+}
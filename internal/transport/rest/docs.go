@@ -0,0 +1,134 @@
+package rest
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"hostManager/api"
+)
+
+// openAPI is the embedded OpenAPI document in JSON and YAML.
+var openAPI = sync.OnceValues(func() (openAPIDocs, error) {
+	var doc map[string]any
+	if err := yaml.Unmarshal(api.OpenAPI, &doc); err != nil {
+		return openAPIDocs{}, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+
+	uniqueOperationIDs(doc)
+
+	var (
+		docs openAPIDocs
+		err  error
+	)
+	if docs.json, err = json.Marshal(doc); err != nil {
+		return openAPIDocs{}, fmt.Errorf("failed to encode OpenAPI document: %w", err)
+	}
+	if docs.yaml, err = yaml.Marshal(doc); err != nil {
+		return openAPIDocs{}, fmt.Errorf("failed to encode OpenAPI document: %w", err)
+	}
+
+	return docs, nil
+})
+
+type openAPIDocs struct {
+	json []byte
+	yaml []byte
+}
+
+// uniqueOperationIDs suffixes the operation ID of RPCs bound to several HTTP
+// methods, such as PUT and PATCH, with the method, as operation IDs must be
+// unique. The generator gives every binding of an RPC the same ID, and an
+// operation option would apply to all of them.
+func uniqueOperationIDs(doc map[string]any) {
+	count := make(map[any]int)
+	paths, _ := doc["paths"].(map[string]any)
+	for _, item := range paths {
+		operations, _ := item.(map[string]any)
+		for _, operation := range operations {
+			if operation, ok := operation.(map[string]any); ok {
+				count[operation["operationId"]]++
+			}
+		}
+	}
+
+	for _, item := range paths {
+		operations, _ := item.(map[string]any)
+		for method, operation := range operations {
+			operation, ok := operation.(map[string]any)
+			if !ok {
+				continue
+			}
+			if id, ok := operation["operationId"].(string); ok && count[id] > 1 {
+				operation["operationId"] = id + "_" + strings.ToUpper(method[:1]) + method[1:]
+			}
+		}
+	}
+}
+
+func serveOpenAPIJSON(w http.ResponseWriter, r *http.Request) {
+	docs, err := openAPI()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(docs.json)
+}
+
+func serveOpenAPIYAML(w http.ResponseWriter, r *http.Request) {
+	docs, err := openAPI()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/yaml")
+	_, _ = w.Write(docs.yaml)
+}
+
+//go:embed swagger-ui/swagger-ui-bundle.js swagger-ui/swagger-ui.css
+var swaggerUIFiles embed.FS
+
+// swaggerUI serves the embedded Swagger UI assets under /docs/, so the docs
+// work without internet access and run no third-party script.
+var swaggerUI = func() http.Handler {
+	assets, err := fs.Sub(swaggerUIFiles, "swagger-ui")
+	if err != nil {
+		panic(err)
+	}
+
+	return http.StripPrefix("/docs/", http.FileServerFS(assets))
+}()
+
+// serveDocs serves Swagger UI for /openapi.json.
+func serveDocs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write([]byte(docsPage))
+}
+
+const docsPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Host Manager API</title>
+  <link rel="stylesheet" href="/docs/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="/docs/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = () => {
+      window.ui = SwaggerUIBundle({url: "/openapi.json", dom_id: "#swagger-ui"});
+    };
+  </script>
+</body>
+</html>
+`
@@ -0,0 +1,78 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDocs(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /docs", serveDocs)
+	mux.Handle("GET /docs/", swaggerUI)
+
+	tests := []struct {
+		path        string
+		wantStatus  int
+		contentType string
+	}{
+		{path: "/docs", wantStatus: http.StatusOK, contentType: "text/html"},
+		{path: "/docs/swagger-ui-bundle.js", wantStatus: http.StatusOK, contentType: "text/javascript"},
+		{path: "/docs/swagger-ui.css", wantStatus: http.StatusOK, contentType: "text/css"},
+		{path: "/docs/LICENSE", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("Content-Type"); !strings.HasPrefix(got, tt.contentType) {
+				t.Errorf("content type = %q, want %s", got, tt.contentType)
+			}
+		})
+	}
+
+	// The page only loads assets served above.
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	if strings.Contains(w.Body.String(), "https://") {
+		t.Errorf("docs page loads remote assets:\n%s", w.Body)
+	}
+}
+
+// TestOpenAPIProblems checks that the committed document describes errors as
+// the problem details errorHandler writes.
+func TestOpenAPIProblems(t *testing.T) {
+	docs, err := openAPI()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var doc struct {
+		Paths      map[string]map[string]struct{ Responses map[string]any }
+		Components struct {
+			Responses map[string]struct{ Content map[string]any }
+		}
+	}
+	if err := json.Unmarshal(docs.json, &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := doc.Components.Responses["Problem"].Content[problemContentType]; !ok {
+		t.Errorf("Problem response is not %s", problemContentType)
+	}
+	for path, operations := range doc.Paths {
+		for method, operation := range operations {
+			ref, _ := operation.Responses["default"].(map[string]any)["$ref"].(string)
+			if ref != "#/components/responses/Problem" {
+				t.Errorf("%s %s default response = %v, want the Problem response", method, path, operation.Responses["default"])
+			}
+		}
+	}
+}
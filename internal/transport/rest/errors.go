@@ -0,0 +1,101 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"hostManager/internal/ratelimit"
+)
+
+const problemContentType = "application/problem+json"
+
+// problem is an RFC 7807 problem details object. Code is the name of the gRPC
+// status code and RequestID ties the error to the server logs.
+type problem struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	Instance  string `json:"instance,omitempty"`
+	Code      string `json:"code,omitempty"`
+	RequestID string `json:"requestId,omitempty"`
+}
+
+// errorHandler writes gRPC errors as problem+json with the status code the
+// gateway maps them to, except that version conflicts are reported as 412
+// Precondition Failed instead of 409 Conflict. Metadata the server sent is
+// returned as headers, and the rate limiter's delay as Retry-After.
+func errorHandler(ctx context.Context, _ *runtime.ServeMux, _ runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	st := status.Convert(err)
+	httpStatus := runtime.HTTPStatusFromCode(st.Code())
+
+	var statusErr *runtime.HTTPStatusError
+	if errors.As(err, &statusErr) {
+		st = status.Convert(statusErr.Err)
+		httpStatus = statusErr.HTTPStatus
+	}
+	if st.Code() == codes.Aborted {
+		httpStatus = http.StatusPreconditionFailed
+	}
+
+	if md, ok := runtime.ServerMetadataFromContext(ctx); ok {
+		for key, values := range md.HeaderMD {
+			if strings.EqualFold(key, requestIDHeader) {
+				continue
+			}
+			if name, ok := outgoingHeaderMatcher(key); ok {
+				for _, value := range values {
+					w.Header().Add(name, value)
+				}
+			}
+		}
+
+		retryAfter := append(md.HeaderMD.Get(ratelimit.RetryAfterKey), md.TrailerMD.Get(ratelimit.RetryAfterKey)...)
+		if len(retryAfter) > 0 {
+			w.Header().Set("Retry-After", retryAfter[0])
+		}
+	}
+
+	writeProblem(w, r, httpStatus, st.Code().String(), st.Message())
+}
+
+// routingErrorHandler reports requests with an unsupported method as 405
+// Method Not Allowed, where the gateway would answer 501 Not Implemented.
+func routingErrorHandler(ctx context.Context, mux *runtime.ServeMux, m runtime.Marshaler, w http.ResponseWriter, r *http.Request, httpStatus int) {
+	if httpStatus == http.StatusMethodNotAllowed {
+		errorHandler(ctx, mux, m, w, r, &runtime.HTTPStatusError{
+			HTTPStatus: httpStatus,
+			Err:        status.Error(codes.Unimplemented, http.StatusText(httpStatus)),
+		})
+		return
+	}
+
+	runtime.DefaultRoutingErrorHandler(ctx, mux, m, w, r, httpStatus)
+}
+
+func writeProblem(w http.ResponseWriter, r *http.Request, httpStatus int, code, detail string) {
+	p := problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(httpStatus),
+		Status:    httpStatus,
+		Detail:    detail,
+		Instance:  r.URL.Path,
+		Code:      code,
+		RequestID: r.Header.Get(requestIDHeader),
+	}
+
+	if p.RequestID != "" {
+		w.Header().Set(requestIDHeader, p.RequestID)
+	}
+	w.Header().Set("Content-Type", problemContentType)
+	w.Header().Del("Content-Length")
+	w.WriteHeader(httpStatus)
+	_ = json.NewEncoder(w).Encode(p)
+}
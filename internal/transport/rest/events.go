@@ -0,0 +1,139 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"hostManager/pkg/gen"
+)
+
+const (
+	eventsPath       = "/v1/events"
+	eventsMethod     = "/dns.DNSHostnameService/WatchChanges"
+	keepaliveTimeout = 15 * time.Second
+)
+
+// events serves the WatchChanges stream as Server-Sent Events. Browsers
+// resume with the Last-Event-ID header on reconnect; the after_id query
+// parameter does the same for a fresh connection.
+func (s *Server) events(gwMux *runtime.ServeMux) runtime.HandlerFunc {
+	client := gen.NewDNSHostnameServiceClient(s.conn)
+
+	return func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		_, marshaler := runtime.MarshalerForRequest(gwMux, r)
+
+		ctx, err := runtime.AnnotateContext(r.Context(), gwMux, r, eventsMethod, runtime.WithHTTPPathPattern(eventsPath))
+		if err != nil {
+			runtime.HTTPError(r.Context(), gwMux, marshaler, w, r, err)
+			return
+		}
+		// Event streams end with the server, not only with the client.
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		stop := context.AfterFunc(s.streams, cancel)
+		defer stop()
+
+		afterID, err := lastEventID(r)
+		if err != nil {
+			runtime.HTTPError(ctx, gwMux, marshaler, w, r, err)
+			return
+		}
+
+		stream, err := client.WatchChanges(ctx, &gen.WatchChangesRequest{AfterId: afterID})
+		if err == nil {
+			_, err = stream.Header()
+		}
+		if err != nil {
+			runtime.HTTPError(ctx, gwMux, marshaler, w, r, err)
+			return
+		}
+
+		events := make(chan *gen.ChangeEvent)
+		go func() {
+			defer close(events)
+			for {
+				event, err := stream.Recv()
+				if err != nil {
+					if err != io.EOF && ctx.Err() == nil {
+						s.log.Warn().Err(err).Msg("change stream ended")
+					}
+					return
+				}
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		// Keep reverse proxies from buffering the stream.
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+
+		rc := http.NewResponseController(w)
+		if err := rc.Flush(); err != nil {
+			return
+		}
+
+		keepalive := time.NewTicker(keepaliveTimeout)
+		defer keepalive.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-keepalive.C:
+				_, err = fmt.Fprint(w, ": keepalive\n\n")
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				err = writeEvent(w, marshaler, event)
+			}
+			if err == nil {
+				err = rc.Flush()
+			}
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+func writeEvent(w io.Writer, marshaler runtime.Marshaler, event *gen.ChangeEvent) error {
+	data, err := marshaler.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\ndata: %s\n\n", event.GetId(), data)
+	return err
+}
+
+func lastEventID(r *http.Request) (uint64, error) {
+	id := r.Header.Get("Last-Event-ID")
+	if id == "" {
+		id = r.URL.Query().Get("after_id")
+	}
+	if id == "" {
+		return 0, nil
+	}
+
+	afterID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid event id %q", id)
+	}
+
+	return afterID, nil
+}
@@ -0,0 +1,90 @@
+package rest
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/rs/cors"
+
+	"hostManager/internal/config"
+)
+
+// withRequestID assigns a request ID to requests that come without one, so
+// that errors raised before the gRPC server is reached can be traced too.
+func withRequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(requestIDHeader) == "" {
+			b := make([]byte, 16)
+			_, _ = rand.Read(b)
+			r.Header.Set(requestIDHeader, hex.EncodeToString(b))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// withCORS answers preflight requests and adds CORS headers for the
+// configured origins.
+func withCORS(cfg config.CORSConfig, next http.Handler) http.Handler {
+	if len(cfg.AllowedOrigins) == 0 {
+		return next
+	}
+
+	return cors.New(cors.Options{
+		AllowOriginFunc:  cfg.OriginAllowed,
+		AllowedMethods:   []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete},
+		AllowedHeaders:   []string{"Authorization", "Content-Type", requestIDHeader},
+		ExposedHeaders:   []string{requestIDHeader, "Retry-After"},
+		AllowCredentials: cfg.AllowCredentials,
+		MaxAge:           int(cfg.MaxAge.Seconds()),
+	}).Handler(next)
+}
+
+// withBodyLimit rejects request bodies larger than limit bytes. Bodies are
+// read in full up front, as the gateway would otherwise report a body cut off
+// by the limit as malformed.
+func withBodyLimit(limit int64, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength > limit {
+			writeProblem(w, r, http.StatusRequestEntityTooLarge, "", fmt.Sprintf("request body exceeds %d bytes", limit))
+			return
+		}
+
+		if r.Body != nil && r.Body != http.NoBody {
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, limit))
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				writeProblem(w, r, http.StatusRequestEntityTooLarge, "", fmt.Sprintf("request body exceeds %d bytes", limit))
+				return
+			}
+			if err != nil {
+				writeProblem(w, r, http.StatusBadRequest, "", "failed to read request body")
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// withBearerToken rejects requests without a bearer token in the
+// Authorization header.
+func withBearerToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scheme, token, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+		if !strings.EqualFold(scheme, "bearer") || strings.TrimSpace(token) == "" {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeProblem(w, r, http.StatusUnauthorized, "Unauthenticated", "a bearer token is required")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -4,49 +4,72 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"strings"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 
+	"hostManager/internal/certs"
 	"hostManager/internal/config"
 	"hostManager/pkg/gen"
+	genv2 "hostManager/pkg/gen/v2"
 )
 
+const requestIDHeader = "X-Request-Id"
+
+// inProcessTarget is the dial target of the in-process gateway; the dialer
+// ignores it.
+const inProcessTarget = "passthrough:///in-process"
+
+// Dialer connects the gateway to a gRPC server in the same process.
+type Dialer func(ctx context.Context, addr string) (net.Conn, error)
+
 type Server struct {
 	httpServer *http.Server
+	conn       *grpc.ClientConn
+	inProcess  Dialer
 	cfg        config.HTTPConfig
 	log        zerolog.Logger
+
+	streams      context.Context
+	closeStreams context.CancelFunc
 }
 
-func NewServer(cfg config.HTTPConfig, log zerolog.Logger) *Server {
-	return &Server{cfg: cfg, log: log}
+// NewServer creates the REST gateway. Without a GRPCServerEndpoint it reaches
+// the gRPC server through inProcess instead of over the network.
+func NewServer(cfg config.HTTPConfig, inProcess Dialer, log zerolog.Logger) *Server {
+	streams, closeStreams := context.WithCancel(context.Background())
+
+	return &Server{cfg: cfg, inProcess: inProcess, log: log, streams: streams, closeStreams: closeStreams}
 }
 
 func (s *Server) Start() error {
 	const op = "http.Start"
 	s.log = s.log.With().Str("op", op).Logger()
 
-	mux := runtime.NewServeMux()
-	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
-
-	err := gen.RegisterDNSHostnameServiceHandlerFromEndpoint(context.Background(), mux, s.cfg.GRPCServerEndpoint, opts)
+	handler, err := s.Handler()
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
 	s.httpServer = &http.Server{
 		Addr:    ":" + s.cfg.Port,
-		Handler: mux,
+		Handler: handler,
 	}
 
 	s.log.Info().Msgf("http server started on %s", s.httpServer.Addr)
 
 	go func() {
-		if err = s.httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		if err := s.httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
 			log.Error().Err(err).Msg("http server start failed")
 		}
 	}()
@@ -54,12 +77,111 @@ func (s *Server) Start() error {
 	return nil
 }
 
+// Handler connects the gateway to the gRPC server and returns the REST API
+// with the metrics, health and API docs endpoints, without listening on a port.
+func (s *Server) Handler() (http.Handler, error) {
+	const op = "http.Handler"
+
+	gwMux := runtime.NewServeMux(
+		runtime.WithErrorHandler(errorHandler),
+		runtime.WithRoutingErrorHandler(routingErrorHandler),
+		runtime.WithIncomingHeaderMatcher(incomingHeaderMatcher),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher),
+	)
+	var api http.Handler = gwMux
+	target := s.cfg.GRPCServerEndpoint
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	if target == "" {
+		target = inProcessTarget
+		opts = append(opts, grpc.WithContextDialer(s.inProcess))
+	} else if tlsCfg := s.cfg.GRPCClientTLS; tlsCfg.CAFile != "" || tlsCfg.CertFile != "" {
+		clientTLS, err := certs.ClientConfig(tlsCfg)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		opts = []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(clientTLS))}
+		// The server would take a call without a token as one of the
+		// gateway's own certificate.
+		if tlsCfg.CertFile != "" {
+			api = withBearerToken(api)
+		}
+	}
+	opts = append(opts, grpc.WithStatsHandler(otelgrpc.NewClientHandler()))
+
+	conn, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	s.conn = conn
+
+	if err := gen.RegisterDNSHostnameServiceHandler(context.Background(), gwMux, conn); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := gen.RegisterAdminServiceHandler(context.Background(), gwMux, conn); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := genv2.RegisterHostConfigServiceHandler(context.Background(), gwMux, conn); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := gwMux.HandlePath(http.MethodGet, eventsPath, s.events(gwMux)); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/healthz", healthz)
+	mux.HandleFunc("/readyz", s.readyz)
+	mux.HandleFunc("GET /openapi.json", serveOpenAPIJSON)
+	mux.HandleFunc("GET /openapi.yaml", serveOpenAPIYAML)
+	mux.HandleFunc("GET /docs", serveDocs)
+	mux.Handle("GET /docs/", swaggerUI)
+	// The HTTP span picks up the caller's traceparent header and becomes the
+	// parent of the gateway's gRPC call.
+	mux.Handle("/", otelhttp.NewHandler(api, "gateway"))
+
+	return withRequestID(withCORS(s.cfg.CORS, withBodyLimit(s.cfg.MaxBodyBytes, mux))), nil
+}
+
+// CloseStreams ends open event streams, which would otherwise keep a
+// graceful shutdown waiting.
+func (s *Server) CloseStreams() {
+	s.closeStreams()
+}
+
 func (s *Server) Stop() error {
 	const op = "http.Stop"
 
-	if err := s.httpServer.Shutdown(context.Background()); err != nil {
+	if s.httpServer != nil {
+		if err := s.httpServer.Shutdown(context.Background()); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := s.conn.Close(); err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
 	return nil
 }
+
+// incomingHeaderMatcher forwards the request ID to the gRPC server.
+func incomingHeaderMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, requestIDHeader) {
+		return requestIDHeader, true
+	}
+
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// outgoingHeaderMatcher returns the request ID to the caller under its own
+// name and other metadata with the default prefix.
+func outgoingHeaderMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, requestIDHeader) {
+		return requestIDHeader, true
+	}
+
+	return runtime.MetadataHeaderPrefix + key, true
+}
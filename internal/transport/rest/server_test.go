@@ -0,0 +1,133 @@
+package rest
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"hostManager/internal/config"
+)
+
+// writeClientCert writes a self-signed client certificate and its key to dir.
+func writeClientCert(t *testing.T, dir string) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "gateway"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile = filepath.Join(dir, "gateway.crt")
+	keyFile = filepath.Join(dir, "gateway.key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return certFile, keyFile
+}
+
+// TestGatewayCertificateRequiresToken checks that REST callers cannot call
+// the API as the gateway's client certificate by leaving out a token.
+func TestGatewayCertificateRequiresToken(t *testing.T) {
+	certFile, keyFile := writeClientCert(t, t.TempDir())
+
+	tests := []struct {
+		name          string
+		clientTLS     config.ClientTLSConfig
+		path          string
+		authorization string
+		wantDenied    bool
+	}{
+		{
+			name:       "no token",
+			clientTLS:  config.ClientTLSConfig{CertFile: certFile, KeyFile: keyFile},
+			path:       "/v1/hostname",
+			wantDenied: true,
+		},
+		{
+			name:          "other scheme",
+			clientTLS:     config.ClientTLSConfig{CertFile: certFile, KeyFile: keyFile},
+			path:          "/v1/hostname",
+			authorization: "Basic YWxpY2U6c2VjcmV0",
+			wantDenied:    true,
+		},
+		{
+			name:       "event stream without a token",
+			clientTLS:  config.ClientTLSConfig{CertFile: certFile, KeyFile: keyFile},
+			path:       eventsPath,
+			wantDenied: true,
+		},
+		{
+			name:          "bearer token",
+			clientTLS:     config.ClientTLSConfig{CertFile: certFile, KeyFile: keyFile},
+			path:          "/v1/hostname",
+			authorization: "Bearer token",
+		},
+		{
+			name:      "health without a token",
+			clientTLS: config.ClientTLSConfig{CertFile: certFile, KeyFile: keyFile},
+			path:      "/healthz",
+		},
+		{
+			name: "gateway without a certificate",
+			path: "/v1/hostname",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Nothing listens on the endpoint, so calls the gateway lets
+			// through fail as unavailable.
+			s := NewServer(config.HTTPConfig{GRPCServerEndpoint: "127.0.0.1:1", GRPCClientTLS: tt.clientTLS, MaxBodyBytes: 1 << 20}, nil, zerolog.Nop())
+			handler, err := s.Handler()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer s.conn.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			r := httptest.NewRequest(http.MethodGet, tt.path, nil).WithContext(ctx)
+			if tt.authorization != "" {
+				r.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if denied := w.Code == http.StatusUnauthorized; denied != tt.wantDenied {
+				t.Errorf("status = %d, want denied %v", w.Code, tt.wantDenied)
+			}
+		})
+	}
+}
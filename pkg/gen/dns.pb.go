@@ -7,9 +7,13 @@
 package gen
 
 import (
+	_ "github.com/google/gnostic-models/openapiv3"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	v2 "hostManager/pkg/gen/v2"
 	reflect "reflect"
 	sync "sync"
 )
@@ -27,6 +31,16 @@ type SetHostnameRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	Hostname string `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	// If set, the change fails unless the hostname still has this version.
+	ExpectedVersion string `protobuf:"bytes,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	// If set, the change is rolled back unless confirmed within this duration.
+	ConfirmWithin *durationpb.Duration `protobuf:"bytes,3,opt,name=confirm_within,json=confirmWithin,proto3" json:"confirm_within,omitempty"`
+	// If set, the change waits for another identity to call ApproveChange.
+	RequireApproval bool `protobuf:"varint,4,opt,name=require_approval,json=requireApproval,proto3" json:"require_approval,omitempty"`
+	// If set, the change is only checked against the expected version, the
+	// admission policy and the current state, and nothing is written. Checks
+	// also run while the server is frozen.
+	ValidateOnly bool `protobuf:"varint,5,opt,name=validate_only,json=validateOnly,proto3" json:"validate_only,omitempty"`
 }
 
 func (x *SetHostnameRequest) Reset() {
@@ -68,6 +82,72 @@ func (x *SetHostnameRequest) GetHostname() string {
 	return ""
 }
 
+func (x *SetHostnameRequest) GetExpectedVersion() string {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return ""
+}
+
+func (x *SetHostnameRequest) GetConfirmWithin() *durationpb.Duration {
+	if x != nil {
+		return x.ConfirmWithin
+	}
+	return nil
+}
+
+func (x *SetHostnameRequest) GetRequireApproval() bool {
+	if x != nil {
+		return x.RequireApproval
+	}
+	return false
+}
+
+func (x *SetHostnameRequest) GetValidateOnly() bool {
+	if x != nil {
+		return x.ValidateOnly
+	}
+	return false
+}
+
+type GetHostnameRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetHostnameRequest) Reset() {
+	*x = GetHostnameRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dns_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetHostnameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHostnameRequest) ProtoMessage() {}
+
+func (x *GetHostnameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dns_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHostnameRequest.ProtoReflect.Descriptor instead.
+func (*GetHostnameRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dns_proto_rawDescGZIP(), []int{1}
+}
+
 type ListDNSServersRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -77,7 +157,7 @@ type ListDNSServersRequest struct {
 func (x *ListDNSServersRequest) Reset() {
 	*x = ListDNSServersRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_dns_proto_msgTypes[1]
+		mi := &file_proto_dns_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -90,7 +170,7 @@ func (x *ListDNSServersRequest) String() string {
 func (*ListDNSServersRequest) ProtoMessage() {}
 
 func (x *ListDNSServersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_dns_proto_msgTypes[1]
+	mi := &file_proto_dns_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -103,7 +183,7 @@ func (x *ListDNSServersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListDNSServersRequest.ProtoReflect.Descriptor instead.
 func (*ListDNSServersRequest) Descriptor() ([]byte, []int) {
-	return file_proto_dns_proto_rawDescGZIP(), []int{1}
+	return file_proto_dns_proto_rawDescGZIP(), []int{2}
 }
 
 type AddDNSServerRequest struct {
@@ -111,13 +191,28 @@ type AddDNSServerRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
+	// IP address of the nameserver.
 	DnsServer string `protobuf:"bytes,1,opt,name=dns_server,json=dnsServer,proto3" json:"dns_server,omitempty"`
+	// If set, the change fails unless resolv.conf still has this version.
+	ExpectedVersion string `protobuf:"bytes,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	// If set, the change is rolled back unless confirmed within this duration.
+	ConfirmWithin *durationpb.Duration `protobuf:"bytes,3,opt,name=confirm_within,json=confirmWithin,proto3" json:"confirm_within,omitempty"`
+	// Temporary servers are removed once they expire. Set at most one of ttl
+	// and expire_time.
+	Ttl        *durationpb.Duration   `protobuf:"bytes,4,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	ExpireTime *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
+	// If set, the change waits for another identity to call ApproveChange.
+	RequireApproval bool `protobuf:"varint,6,opt,name=require_approval,json=requireApproval,proto3" json:"require_approval,omitempty"`
+	// If set, the change is only checked against the expected version, the
+	// admission policy and the current state, and nothing is written. Checks
+	// also run while the server is frozen.
+	ValidateOnly bool `protobuf:"varint,7,opt,name=validate_only,json=validateOnly,proto3" json:"validate_only,omitempty"`
 }
 
 func (x *AddDNSServerRequest) Reset() {
 	*x = AddDNSServerRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_dns_proto_msgTypes[2]
+		mi := &file_proto_dns_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -130,7 +225,7 @@ func (x *AddDNSServerRequest) String() string {
 func (*AddDNSServerRequest) ProtoMessage() {}
 
 func (x *AddDNSServerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_dns_proto_msgTypes[2]
+	mi := &file_proto_dns_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -143,7 +238,7 @@ func (x *AddDNSServerRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddDNSServerRequest.ProtoReflect.Descriptor instead.
 func (*AddDNSServerRequest) Descriptor() ([]byte, []int) {
-	return file_proto_dns_proto_rawDescGZIP(), []int{2}
+	return file_proto_dns_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *AddDNSServerRequest) GetDnsServer() string {
@@ -153,18 +248,71 @@ func (x *AddDNSServerRequest) GetDnsServer() string {
 	return ""
 }
 
+func (x *AddDNSServerRequest) GetExpectedVersion() string {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return ""
+}
+
+func (x *AddDNSServerRequest) GetConfirmWithin() *durationpb.Duration {
+	if x != nil {
+		return x.ConfirmWithin
+	}
+	return nil
+}
+
+func (x *AddDNSServerRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+func (x *AddDNSServerRequest) GetExpireTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return nil
+}
+
+func (x *AddDNSServerRequest) GetRequireApproval() bool {
+	if x != nil {
+		return x.RequireApproval
+	}
+	return false
+}
+
+func (x *AddDNSServerRequest) GetValidateOnly() bool {
+	if x != nil {
+		return x.ValidateOnly
+	}
+	return false
+}
+
 type RemoveDNSServerRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
+	// IP address of the nameserver.
 	DnsServer string `protobuf:"bytes,1,opt,name=dns_server,json=dnsServer,proto3" json:"dns_server,omitempty"`
+	// If set, the change fails unless resolv.conf still has this version.
+	ExpectedVersion string `protobuf:"bytes,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	// If set, the change is rolled back unless confirmed within this duration.
+	ConfirmWithin *durationpb.Duration `protobuf:"bytes,3,opt,name=confirm_within,json=confirmWithin,proto3" json:"confirm_within,omitempty"`
+	// If set, the change waits for another identity to call ApproveChange.
+	RequireApproval bool `protobuf:"varint,4,opt,name=require_approval,json=requireApproval,proto3" json:"require_approval,omitempty"`
+	// If set, the change is only checked against the expected version, the
+	// admission policy and the current state, and nothing is written. Checks
+	// also run while the server is frozen.
+	ValidateOnly bool `protobuf:"varint,5,opt,name=validate_only,json=validateOnly,proto3" json:"validate_only,omitempty"`
 }
 
 func (x *RemoveDNSServerRequest) Reset() {
 	*x = RemoveDNSServerRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_dns_proto_msgTypes[3]
+		mi := &file_proto_dns_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -177,7 +325,7 @@ func (x *RemoveDNSServerRequest) String() string {
 func (*RemoveDNSServerRequest) ProtoMessage() {}
 
 func (x *RemoveDNSServerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_dns_proto_msgTypes[3]
+	mi := &file_proto_dns_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -190,7 +338,7 @@ func (x *RemoveDNSServerRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveDNSServerRequest.ProtoReflect.Descriptor instead.
 func (*RemoveDNSServerRequest) Descriptor() ([]byte, []int) {
-	return file_proto_dns_proto_rawDescGZIP(), []int{3}
+	return file_proto_dns_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *RemoveDNSServerRequest) GetDnsServer() string {
@@ -200,31 +348,57 @@ func (x *RemoveDNSServerRequest) GetDnsServer() string {
 	return ""
 }
 
-type ListDNSServersResponse struct {
+func (x *RemoveDNSServerRequest) GetExpectedVersion() string {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return ""
+}
+
+func (x *RemoveDNSServerRequest) GetConfirmWithin() *durationpb.Duration {
+	if x != nil {
+		return x.ConfirmWithin
+	}
+	return nil
+}
+
+func (x *RemoveDNSServerRequest) GetRequireApproval() bool {
+	if x != nil {
+		return x.RequireApproval
+	}
+	return false
+}
+
+func (x *RemoveDNSServerRequest) GetValidateOnly() bool {
+	if x != nil {
+		return x.ValidateOnly
+	}
+	return false
+}
+
+type GetHostsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	DnsServers []string `protobuf:"bytes,1,rep,name=dns_servers,json=dnsServers,proto3" json:"dns_servers,omitempty"`
 }
 
-func (x *ListDNSServersResponse) Reset() {
-	*x = ListDNSServersResponse{}
+func (x *GetHostsRequest) Reset() {
+	*x = GetHostsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_dns_proto_msgTypes[4]
+		mi := &file_proto_dns_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListDNSServersResponse) String() string {
+func (x *GetHostsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListDNSServersResponse) ProtoMessage() {}
+func (*GetHostsRequest) ProtoMessage() {}
 
-func (x *ListDNSServersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_dns_proto_msgTypes[4]
+func (x *GetHostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dns_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -235,41 +409,41 @@ func (x *ListDNSServersResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListDNSServersResponse.ProtoReflect.Descriptor instead.
-func (*ListDNSServersResponse) Descriptor() ([]byte, []int) {
-	return file_proto_dns_proto_rawDescGZIP(), []int{4}
-}
-
-func (x *ListDNSServersResponse) GetDnsServers() []string {
-	if x != nil {
-		return x.DnsServers
-	}
-	return nil
+// Deprecated: Use GetHostsRequest.ProtoReflect.Descriptor instead.
+func (*GetHostsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dns_proto_rawDescGZIP(), []int{5}
 }
 
-type AddDNSServerResponse struct {
+type SetHostsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// The new content of /etc/hosts.
+	Content string `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	// If set, the change fails unless /etc/hosts still has this version.
+	ExpectedVersion string `protobuf:"bytes,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	// If set, the change is rolled back unless confirmed within this duration.
+	ConfirmWithin *durationpb.Duration `protobuf:"bytes,3,opt,name=confirm_within,json=confirmWithin,proto3" json:"confirm_within,omitempty"`
 }
 
-func (x *AddDNSServerResponse) Reset() {
-	*x = AddDNSServerResponse{}
+func (x *SetHostsRequest) Reset() {
+	*x = SetHostsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_dns_proto_msgTypes[5]
+		mi := &file_proto_dns_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *AddDNSServerResponse) String() string {
+func (x *SetHostsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddDNSServerResponse) ProtoMessage() {}
+func (*SetHostsRequest) ProtoMessage() {}
 
-func (x *AddDNSServerResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_dns_proto_msgTypes[5]
+func (x *SetHostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dns_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -280,34 +454,58 @@ func (x *AddDNSServerResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddDNSServerResponse.ProtoReflect.Descriptor instead.
-func (*AddDNSServerResponse) Descriptor() ([]byte, []int) {
-	return file_proto_dns_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use SetHostsRequest.ProtoReflect.Descriptor instead.
+func (*SetHostsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dns_proto_rawDescGZIP(), []int{6}
 }
 
-type RemoveDNSServerResponse struct {
+func (x *SetHostsRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *SetHostsRequest) GetExpectedVersion() string {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return ""
+}
+
+func (x *SetHostsRequest) GetConfirmWithin() *durationpb.Duration {
+	if x != nil {
+		return x.ConfirmWithin
+	}
+	return nil
+}
+
+type ConfirmChangeRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// The change_id returned by the mutation.
+	ChangeId string `protobuf:"bytes,1,opt,name=change_id,json=changeId,proto3" json:"change_id,omitempty"`
 }
 
-func (x *RemoveDNSServerResponse) Reset() {
-	*x = RemoveDNSServerResponse{}
+func (x *ConfirmChangeRequest) Reset() {
+	*x = ConfirmChangeRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_dns_proto_msgTypes[6]
+		mi := &file_proto_dns_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RemoveDNSServerResponse) String() string {
+func (x *ConfirmChangeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveDNSServerResponse) ProtoMessage() {}
+func (*ConfirmChangeRequest) ProtoMessage() {}
 
-func (x *RemoveDNSServerResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_dns_proto_msgTypes[6]
+func (x *ConfirmChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dns_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -318,34 +516,55 @@ func (x *RemoveDNSServerResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveDNSServerResponse.ProtoReflect.Descriptor instead.
-func (*RemoveDNSServerResponse) Descriptor() ([]byte, []int) {
-	return file_proto_dns_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use ConfirmChangeRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmChangeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dns_proto_rawDescGZIP(), []int{7}
 }
 
-type SetHostnameResponse struct {
+func (x *ConfirmChangeRequest) GetChangeId() string {
+	if x != nil {
+		return x.ChangeId
+	}
+	return ""
+}
+
+type ScheduleChangeRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// The change to run; exactly one must be set.
+	//
+	// Types that are assignable to Change:
+	//	*ScheduleChangeRequest_SetHostname
+	//	*ScheduleChangeRequest_AddDnsServer
+	//	*ScheduleChangeRequest_RemoveDnsServer
+	//	*ScheduleChangeRequest_UpdateHostname
+	//	*ScheduleChangeRequest_UpdateResolverConfig
+	Change isScheduleChangeRequest_Change `protobuf_oneof:"change"`
+	// Exactly one of run_time and cron must be set.
+	RunTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=run_time,json=runTime,proto3" json:"run_time,omitempty"`
+	// Standard five-field cron expression in the server's time zone.
+	Cron string `protobuf:"bytes,5,opt,name=cron,proto3" json:"cron,omitempty"`
 }
 
-func (x *SetHostnameResponse) Reset() {
-	*x = SetHostnameResponse{}
+func (x *ScheduleChangeRequest) Reset() {
+	*x = ScheduleChangeRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_dns_proto_msgTypes[7]
+		mi := &file_proto_dns_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SetHostnameResponse) String() string {
+func (x *ScheduleChangeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetHostnameResponse) ProtoMessage() {}
+func (*ScheduleChangeRequest) ProtoMessage() {}
 
-func (x *SetHostnameResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_dns_proto_msgTypes[7]
+func (x *ScheduleChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dns_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -356,137 +575,2186 @@ func (x *SetHostnameResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetHostnameResponse.ProtoReflect.Descriptor instead.
-func (*SetHostnameResponse) Descriptor() ([]byte, []int) {
-	return file_proto_dns_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use ScheduleChangeRequest.ProtoReflect.Descriptor instead.
+func (*ScheduleChangeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dns_proto_rawDescGZIP(), []int{8}
 }
 
-var File_proto_dns_proto protoreflect.FileDescriptor
+func (m *ScheduleChangeRequest) GetChange() isScheduleChangeRequest_Change {
+	if m != nil {
+		return m.Change
+	}
+	return nil
+}
 
-var file_proto_dns_proto_rawDesc = []byte{
-	0x0a, 0x0f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x64, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x12, 0x03, 0x64, 0x6e, 0x73, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61,
-	0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x30, 0x0a, 0x12, 0x53, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x6e,
-	0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f,
-	0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f,
-	0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x17, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x4e,
-	0x53, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
-	0x34, 0x0a, 0x13, 0x41, 0x64, 0x64, 0x44, 0x4e, 0x53, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x6e, 0x73, 0x5f, 0x73, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x6e, 0x73, 0x53,
-	0x65, 0x72, 0x76, 0x65, 0x72, 0x22, 0x37, 0x0a, 0x16, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x44,
-	0x4e, 0x53, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x1d, 0x0a, 0x0a, 0x64, 0x6e, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x6e, 0x73, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x22, 0x39,
-	0x0a, 0x16, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x4e, 0x53, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x6e, 0x73, 0x5f,
-	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x64,
-	0x6e, 0x73, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x22, 0x16, 0x0a, 0x14, 0x41, 0x64, 0x64,
-	0x44, 0x4e, 0x53, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x19, 0x0a, 0x17, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x44, 0x4e, 0x53, 0x53, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x15, 0x0a, 0x13,
-	0x53, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x32, 0x90, 0x03, 0x0a, 0x12, 0x44, 0x4e, 0x53, 0x48, 0x6f, 0x73, 0x74, 0x6e,
-	0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x59, 0x0a, 0x0b, 0x53, 0x65,
-	0x74, 0x48, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x17, 0x2e, 0x64, 0x6e, 0x73, 0x2e,
-	0x53, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x18, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x53, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74,
-	0x6e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x17, 0x82, 0xd3,
-	0xe4, 0x93, 0x02, 0x11, 0x3a, 0x01, 0x2a, 0x22, 0x0c, 0x2f, 0x76, 0x31, 0x2f, 0x68, 0x6f, 0x73,
-	0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x5a, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x4e, 0x53,
-	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x12, 0x1a, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x4c, 0x69,
-	0x73, 0x74, 0x44, 0x4e, 0x53, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x4e,
-	0x53, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x0f, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x09, 0x12, 0x07, 0x2f, 0x76, 0x31, 0x2f, 0x64, 0x6e,
-	0x73, 0x12, 0x57, 0x0a, 0x0c, 0x41, 0x64, 0x64, 0x44, 0x4e, 0x53, 0x53, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x12, 0x18, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x41, 0x64, 0x64, 0x44, 0x4e, 0x53, 0x53, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x64, 0x6e,
-	0x73, 0x2e, 0x41, 0x64, 0x64, 0x44, 0x4e, 0x53, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x12, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0c, 0x3a, 0x01,
-	0x2a, 0x22, 0x07, 0x2f, 0x76, 0x31, 0x2f, 0x64, 0x6e, 0x73, 0x12, 0x6a, 0x0a, 0x0f, 0x52, 0x65,
-	0x6d, 0x6f, 0x76, 0x65, 0x44, 0x4e, 0x53, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x1b, 0x2e,
-	0x64, 0x6e, 0x73, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x44, 0x4e, 0x53, 0x53, 0x65, 0x72,
-	0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x64, 0x6e, 0x73,
-	0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x44, 0x4e, 0x53, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1c, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x16,
-	0x2a, 0x14, 0x2f, 0x76, 0x31, 0x2f, 0x64, 0x6e, 0x73, 0x2f, 0x7b, 0x64, 0x6e, 0x73, 0x5f, 0x73,
-	0x65, 0x72, 0x76, 0x65, 0x72, 0x7d, 0x42, 0x0c, 0x5a, 0x0a, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
-	0x3b, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *ScheduleChangeRequest) GetSetHostname() *SetHostnameRequest {
+	if x, ok := x.GetChange().(*ScheduleChangeRequest_SetHostname); ok {
+		return x.SetHostname
+	}
+	return nil
 }
 
-var (
-	file_proto_dns_proto_rawDescOnce sync.Once
-	file_proto_dns_proto_rawDescData = file_proto_dns_proto_rawDesc
-)
+func (x *ScheduleChangeRequest) GetAddDnsServer() *AddDNSServerRequest {
+	if x, ok := x.GetChange().(*ScheduleChangeRequest_AddDnsServer); ok {
+		return x.AddDnsServer
+	}
+	return nil
+}
 
-func file_proto_dns_proto_rawDescGZIP() []byte {
-	file_proto_dns_proto_rawDescOnce.Do(func() {
-		file_proto_dns_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_dns_proto_rawDescData)
-	})
-	return file_proto_dns_proto_rawDescData
+func (x *ScheduleChangeRequest) GetRemoveDnsServer() *RemoveDNSServerRequest {
+	if x, ok := x.GetChange().(*ScheduleChangeRequest_RemoveDnsServer); ok {
+		return x.RemoveDnsServer
+	}
+	return nil
 }
 
-var file_proto_dns_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
-var file_proto_dns_proto_goTypes = []any{
-	(*SetHostnameRequest)(nil),      // 0: dns.SetHostnameRequest
-	(*ListDNSServersRequest)(nil),   // 1: dns.ListDNSServersRequest
-	(*AddDNSServerRequest)(nil),     // 2: dns.AddDNSServerRequest
-	(*RemoveDNSServerRequest)(nil),  // 3: dns.RemoveDNSServerRequest
-	(*ListDNSServersResponse)(nil),  // 4: dns.ListDNSServersResponse
-	(*AddDNSServerResponse)(nil),    // 5: dns.AddDNSServerResponse
-	(*RemoveDNSServerResponse)(nil), // 6: dns.RemoveDNSServerResponse
-	(*SetHostnameResponse)(nil),     // 7: dns.SetHostnameResponse
+func (x *ScheduleChangeRequest) GetUpdateHostname() *v2.UpdateHostnameRequest {
+	if x, ok := x.GetChange().(*ScheduleChangeRequest_UpdateHostname); ok {
+		return x.UpdateHostname
+	}
+	return nil
 }
-var file_proto_dns_proto_depIdxs = []int32{
-	0, // 0: dns.DNSHostnameService.SetHostname:input_type -> dns.SetHostnameRequest
-	1, // 1: dns.DNSHostnameService.ListDNSServers:input_type -> dns.ListDNSServersRequest
-	2, // 2: dns.DNSHostnameService.AddDNSServer:input_type -> dns.AddDNSServerRequest
-	3, // 3: dns.DNSHostnameService.RemoveDNSServer:input_type -> dns.RemoveDNSServerRequest
-	7, // 4: dns.DNSHostnameService.SetHostname:output_type -> dns.SetHostnameResponse
-	4, // 5: dns.DNSHostnameService.ListDNSServers:output_type -> dns.ListDNSServersResponse
-	5, // 6: dns.DNSHostnameService.AddDNSServer:output_type -> dns.AddDNSServerResponse
-	6, // 7: dns.DNSHostnameService.RemoveDNSServer:output_type -> dns.RemoveDNSServerResponse
-	4, // [4:8] is the sub-list for method output_type
-	0, // [0:4] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+
+func (x *ScheduleChangeRequest) GetUpdateResolverConfig() *v2.UpdateResolverConfigRequest {
+	if x, ok := x.GetChange().(*ScheduleChangeRequest_UpdateResolverConfig); ok {
+		return x.UpdateResolverConfig
+	}
+	return nil
 }
 
-func init() { file_proto_dns_proto_init() }
-func file_proto_dns_proto_init() {
-	if File_proto_dns_proto != nil {
-		return
+func (x *ScheduleChangeRequest) GetRunTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RunTime
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_proto_dns_proto_msgTypes[0].Exporter = func(v any, i int) any {
-			switch v := v.(*SetHostnameRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_proto_dns_proto_msgTypes[1].Exporter = func(v any, i int) any {
-			switch v := v.(*ListDNSServersRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
+	return nil
+}
+
+func (x *ScheduleChangeRequest) GetCron() string {
+	if x != nil {
+		return x.Cron
+	}
+	return ""
+}
+
+type isScheduleChangeRequest_Change interface {
+	isScheduleChangeRequest_Change()
+}
+
+type ScheduleChangeRequest_SetHostname struct {
+	SetHostname *SetHostnameRequest `protobuf:"bytes,1,opt,name=set_hostname,json=setHostname,proto3,oneof"`
+}
+
+type ScheduleChangeRequest_AddDnsServer struct {
+	AddDnsServer *AddDNSServerRequest `protobuf:"bytes,2,opt,name=add_dns_server,json=addDnsServer,proto3,oneof"`
+}
+
+type ScheduleChangeRequest_RemoveDnsServer struct {
+	RemoveDnsServer *RemoveDNSServerRequest `protobuf:"bytes,3,opt,name=remove_dns_server,json=removeDnsServer,proto3,oneof"`
+}
+
+type ScheduleChangeRequest_UpdateHostname struct {
+	UpdateHostname *v2.UpdateHostnameRequest `protobuf:"bytes,6,opt,name=update_hostname,json=updateHostname,proto3,oneof"`
+}
+
+type ScheduleChangeRequest_UpdateResolverConfig struct {
+	UpdateResolverConfig *v2.UpdateResolverConfigRequest `protobuf:"bytes,7,opt,name=update_resolver_config,json=updateResolverConfig,proto3,oneof"`
+}
+
+func (*ScheduleChangeRequest_SetHostname) isScheduleChangeRequest_Change() {}
+
+func (*ScheduleChangeRequest_AddDnsServer) isScheduleChangeRequest_Change() {}
+
+func (*ScheduleChangeRequest_RemoveDnsServer) isScheduleChangeRequest_Change() {}
+
+func (*ScheduleChangeRequest_UpdateHostname) isScheduleChangeRequest_Change() {}
+
+func (*ScheduleChangeRequest_UpdateResolverConfig) isScheduleChangeRequest_Change() {}
+
+type ListScheduledChangesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListScheduledChangesRequest) Reset() {
+	*x = ListScheduledChangesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dns_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListScheduledChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledChangesRequest) ProtoMessage() {}
+
+func (x *ListScheduledChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dns_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledChangesRequest.ProtoReflect.Descriptor instead.
+func (*ListScheduledChangesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dns_proto_rawDescGZIP(), []int{9}
+}
+
+type CancelScheduledChangeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *CancelScheduledChangeRequest) Reset() {
+	*x = CancelScheduledChangeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dns_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelScheduledChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduledChangeRequest) ProtoMessage() {}
+
+func (x *CancelScheduledChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dns_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduledChangeRequest.ProtoReflect.Descriptor instead.
+func (*CancelScheduledChangeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dns_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CancelScheduledChangeRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListPendingChangesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListPendingChangesRequest) Reset() {
+	*x = ListPendingChangesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dns_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPendingChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPendingChangesRequest) ProtoMessage() {}
+
+func (x *ListPendingChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dns_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPendingChangesRequest.ProtoReflect.Descriptor instead.
+func (*ListPendingChangesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dns_proto_rawDescGZIP(), []int{11}
+}
+
+type ApproveChangeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *ApproveChangeRequest) Reset() {
+	*x = ApproveChangeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dns_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApproveChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveChangeRequest) ProtoMessage() {}
+
+func (x *ApproveChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dns_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveChangeRequest.ProtoReflect.Descriptor instead.
+func (*ApproveChangeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dns_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ApproveChangeRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RejectChangeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Recorded in the audit log.
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *RejectChangeRequest) Reset() {
+	*x = RejectChangeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dns_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RejectChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectChangeRequest) ProtoMessage() {}
+
+func (x *RejectChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dns_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectChangeRequest.ProtoReflect.Descriptor instead.
+func (*RejectChangeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dns_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RejectChangeRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RejectChangeRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type WatchChangesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Resumes the stream after this event ID. Events still buffered on the
+	// server are replayed; if some are no longer buffered, the stream starts
+	// with snapshot events instead. Zero streams new events only.
+	AfterId uint64 `protobuf:"varint,1,opt,name=after_id,json=afterId,proto3" json:"after_id,omitempty"`
+}
+
+func (x *WatchChangesRequest) Reset() {
+	*x = WatchChangesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dns_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchChangesRequest) ProtoMessage() {}
+
+func (x *WatchChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dns_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchChangesRequest.ProtoReflect.Descriptor instead.
+func (*WatchChangesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dns_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *WatchChangesRequest) GetAfterId() uint64 {
+	if x != nil {
+		return x.AfterId
+	}
+	return 0
+}
+
+type ChangeEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Event IDs increase, also across server restarts.
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// "hostname", "resolv.conf" or "hosts".
+	Resource string `protobuf:"bytes,2,opt,name=resource,proto3" json:"resource,omitempty"`
+	// Version of the file after the change, empty if the file was removed.
+	Version string                 `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	Time    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
+	// Set for hostname events.
+	Hostname string `protobuf:"bytes,5,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	// Set for resolv.conf events.
+	DnsServers []string `protobuf:"bytes,6,rep,name=dns_servers,json=dnsServers,proto3" json:"dns_servers,omitempty"`
+	// Snapshot events report the current state of a resource rather than a
+	// change; they carry the ID of the last change.
+	Snapshot bool `protobuf:"varint,7,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+}
+
+func (x *ChangeEvent) Reset() {
+	*x = ChangeEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dns_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChangeEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeEvent) ProtoMessage() {}
+
+func (x *ChangeEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dns_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeEvent.ProtoReflect.Descriptor instead.
+func (*ChangeEvent) Descriptor() ([]byte, []int) {
+	return file_proto_dns_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ChangeEvent) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ChangeEvent) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
+}
+
+func (x *ChangeEvent) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *ChangeEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *ChangeEvent) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *ChangeEvent) GetDnsServers() []string {
+	if x != nil {
+		return x.DnsServers
+	}
+	return nil
+}
+
+func (x *ChangeEvent) GetSnapshot() bool {
+	if x != nil {
+		return x.Snapshot
+	}
+	return false
+}
+
+type ListDNSServersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DnsServers []string `protobuf:"bytes,1,rep,name=dns_servers,json=dnsServers,proto3" json:"dns_servers,omitempty"`
+	// Version of resolv.conf, for expected_version.
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// Keyed by DNS server; only servers with metadata are present.
+	Metadata map[string]*DNSServerMetadata `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *ListDNSServersResponse) Reset() {
+	*x = ListDNSServersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dns_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDNSServersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDNSServersResponse) ProtoMessage() {}
+
+func (x *ListDNSServersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dns_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDNSServersResponse.ProtoReflect.Descriptor instead.
+func (*ListDNSServersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dns_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListDNSServersResponse) GetDnsServers() []string {
+	if x != nil {
+		return x.DnsServers
+	}
+	return nil
+}
+
+func (x *ListDNSServersResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *ListDNSServersResponse) GetMetadata() map[string]*DNSServerMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type DNSServerMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// When a temporary server is removed.
+	ExpireTime *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
+}
+
+func (x *DNSServerMetadata) Reset() {
+	*x = DNSServerMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dns_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DNSServerMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DNSServerMetadata) ProtoMessage() {}
+
+func (x *DNSServerMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dns_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DNSServerMetadata.ProtoReflect.Descriptor instead.
+func (*DNSServerMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_dns_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DNSServerMetadata) GetExpireTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return nil
+}
+
+type AddDNSServerResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Version of resolv.conf after the change.
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// Set when confirm_within was requested; pass it to ConfirmChange.
+	ChangeId string `protobuf:"bytes,2,opt,name=change_id,json=changeId,proto3" json:"change_id,omitempty"`
+	// Set instead of version when the change waits for approval.
+	PendingChangeId string `protobuf:"bytes,3,opt,name=pending_change_id,json=pendingChangeId,proto3" json:"pending_change_id,omitempty"`
+}
+
+func (x *AddDNSServerResponse) Reset() {
+	*x = AddDNSServerResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dns_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddDNSServerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDNSServerResponse) ProtoMessage() {}
+
+func (x *AddDNSServerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dns_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddDNSServerResponse.ProtoReflect.Descriptor instead.
+func (*AddDNSServerResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dns_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AddDNSServerResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *AddDNSServerResponse) GetChangeId() string {
+	if x != nil {
+		return x.ChangeId
+	}
+	return ""
+}
+
+func (x *AddDNSServerResponse) GetPendingChangeId() string {
+	if x != nil {
+		return x.PendingChangeId
+	}
+	return ""
+}
+
+type RemoveDNSServerResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Version of resolv.conf after the change.
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// Set when confirm_within was requested; pass it to ConfirmChange.
+	ChangeId string `protobuf:"bytes,2,opt,name=change_id,json=changeId,proto3" json:"change_id,omitempty"`
+	// Set instead of version when the change waits for approval.
+	PendingChangeId string `protobuf:"bytes,3,opt,name=pending_change_id,json=pendingChangeId,proto3" json:"pending_change_id,omitempty"`
+}
+
+func (x *RemoveDNSServerResponse) Reset() {
+	*x = RemoveDNSServerResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dns_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveDNSServerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveDNSServerResponse) ProtoMessage() {}
+
+func (x *RemoveDNSServerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dns_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveDNSServerResponse.ProtoReflect.Descriptor instead.
+func (*RemoveDNSServerResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dns_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RemoveDNSServerResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *RemoveDNSServerResponse) GetChangeId() string {
+	if x != nil {
+		return x.ChangeId
+	}
+	return ""
+}
+
+func (x *RemoveDNSServerResponse) GetPendingChangeId() string {
+	if x != nil {
+		return x.PendingChangeId
+	}
+	return ""
+}
+
+type GetHostsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Content string `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	// Version of /etc/hosts, for expected_version.
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *GetHostsResponse) Reset() {
+	*x = GetHostsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dns_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetHostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHostsResponse) ProtoMessage() {}
+
+func (x *GetHostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dns_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHostsResponse.ProtoReflect.Descriptor instead.
+func (*GetHostsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dns_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetHostsResponse) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *GetHostsResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type SetHostsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Version of /etc/hosts after the change.
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// Set when confirm_within was requested; pass it to ConfirmChange.
+	ChangeId string `protobuf:"bytes,2,opt,name=change_id,json=changeId,proto3" json:"change_id,omitempty"`
+}
+
+func (x *SetHostsResponse) Reset() {
+	*x = SetHostsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dns_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetHostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetHostsResponse) ProtoMessage() {}
+
+func (x *SetHostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dns_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetHostsResponse.ProtoReflect.Descriptor instead.
+func (*SetHostsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dns_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SetHostsResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *SetHostsResponse) GetChangeId() string {
+	if x != nil {
+		return x.ChangeId
+	}
+	return ""
+}
+
+type ConfirmChangeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ConfirmChangeResponse) Reset() {
+	*x = ConfirmChangeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dns_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfirmChangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmChangeResponse) ProtoMessage() {}
+
+func (x *ConfirmChangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dns_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmChangeResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmChangeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dns_proto_rawDescGZIP(), []int{22}
+}
+
+// ScheduledChange is a change the server runs on its own at a set time.
+type ScheduledChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Types that are assignable to Change:
+	//	*ScheduledChange_SetHostname
+	//	*ScheduledChange_AddDnsServer
+	//	*ScheduledChange_RemoveDnsServer
+	//	*ScheduledChange_UpdateHostname
+	//	*ScheduledChange_UpdateResolverConfig
+	Change      isScheduledChange_Change `protobuf_oneof:"change"`
+	RunTime     *timestamppb.Timestamp   `protobuf:"bytes,5,opt,name=run_time,json=runTime,proto3" json:"run_time,omitempty"`
+	Cron        string                   `protobuf:"bytes,6,opt,name=cron,proto3" json:"cron,omitempty"`
+	NextRunTime *timestamppb.Timestamp   `protobuf:"bytes,7,opt,name=next_run_time,json=nextRunTime,proto3" json:"next_run_time,omitempty"`
+	LastRunTime *timestamppb.Timestamp   `protobuf:"bytes,8,opt,name=last_run_time,json=lastRunTime,proto3" json:"last_run_time,omitempty"`
+	// Error of the last run, empty if it succeeded.
+	LastError  string                 `protobuf:"bytes,9,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	CreateTime *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+}
+
+func (x *ScheduledChange) Reset() {
+	*x = ScheduledChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dns_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScheduledChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledChange) ProtoMessage() {}
+
+func (x *ScheduledChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dns_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledChange.ProtoReflect.Descriptor instead.
+func (*ScheduledChange) Descriptor() ([]byte, []int) {
+	return file_proto_dns_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ScheduledChange) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (m *ScheduledChange) GetChange() isScheduledChange_Change {
+	if m != nil {
+		return m.Change
+	}
+	return nil
+}
+
+func (x *ScheduledChange) GetSetHostname() *SetHostnameRequest {
+	if x, ok := x.GetChange().(*ScheduledChange_SetHostname); ok {
+		return x.SetHostname
+	}
+	return nil
+}
+
+func (x *ScheduledChange) GetAddDnsServer() *AddDNSServerRequest {
+	if x, ok := x.GetChange().(*ScheduledChange_AddDnsServer); ok {
+		return x.AddDnsServer
+	}
+	return nil
+}
+
+func (x *ScheduledChange) GetRemoveDnsServer() *RemoveDNSServerRequest {
+	if x, ok := x.GetChange().(*ScheduledChange_RemoveDnsServer); ok {
+		return x.RemoveDnsServer
+	}
+	return nil
+}
+
+func (x *ScheduledChange) GetUpdateHostname() *v2.UpdateHostnameRequest {
+	if x, ok := x.GetChange().(*ScheduledChange_UpdateHostname); ok {
+		return x.UpdateHostname
+	}
+	return nil
+}
+
+func (x *ScheduledChange) GetUpdateResolverConfig() *v2.UpdateResolverConfigRequest {
+	if x, ok := x.GetChange().(*ScheduledChange_UpdateResolverConfig); ok {
+		return x.UpdateResolverConfig
+	}
+	return nil
+}
+
+func (x *ScheduledChange) GetRunTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RunTime
+	}
+	return nil
+}
+
+func (x *ScheduledChange) GetCron() string {
+	if x != nil {
+		return x.Cron
+	}
+	return ""
+}
+
+func (x *ScheduledChange) GetNextRunTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextRunTime
+	}
+	return nil
+}
+
+func (x *ScheduledChange) GetLastRunTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastRunTime
+	}
+	return nil
+}
+
+func (x *ScheduledChange) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *ScheduledChange) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+type isScheduledChange_Change interface {
+	isScheduledChange_Change()
+}
+
+type ScheduledChange_SetHostname struct {
+	SetHostname *SetHostnameRequest `protobuf:"bytes,2,opt,name=set_hostname,json=setHostname,proto3,oneof"`
+}
+
+type ScheduledChange_AddDnsServer struct {
+	AddDnsServer *AddDNSServerRequest `protobuf:"bytes,3,opt,name=add_dns_server,json=addDnsServer,proto3,oneof"`
+}
+
+type ScheduledChange_RemoveDnsServer struct {
+	RemoveDnsServer *RemoveDNSServerRequest `protobuf:"bytes,4,opt,name=remove_dns_server,json=removeDnsServer,proto3,oneof"`
+}
+
+type ScheduledChange_UpdateHostname struct {
+	UpdateHostname *v2.UpdateHostnameRequest `protobuf:"bytes,11,opt,name=update_hostname,json=updateHostname,proto3,oneof"`
+}
+
+type ScheduledChange_UpdateResolverConfig struct {
+	UpdateResolverConfig *v2.UpdateResolverConfigRequest `protobuf:"bytes,12,opt,name=update_resolver_config,json=updateResolverConfig,proto3,oneof"`
+}
+
+func (*ScheduledChange_SetHostname) isScheduledChange_Change() {}
+
+func (*ScheduledChange_AddDnsServer) isScheduledChange_Change() {}
+
+func (*ScheduledChange_RemoveDnsServer) isScheduledChange_Change() {}
+
+func (*ScheduledChange_UpdateHostname) isScheduledChange_Change() {}
+
+func (*ScheduledChange_UpdateResolverConfig) isScheduledChange_Change() {}
+
+type ListScheduledChangesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Changes []*ScheduledChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+}
+
+func (x *ListScheduledChangesResponse) Reset() {
+	*x = ListScheduledChangesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dns_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListScheduledChangesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledChangesResponse) ProtoMessage() {}
+
+func (x *ListScheduledChangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dns_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledChangesResponse.ProtoReflect.Descriptor instead.
+func (*ListScheduledChangesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dns_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListScheduledChangesResponse) GetChanges() []*ScheduledChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type CancelScheduledChangeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CancelScheduledChangeResponse) Reset() {
+	*x = CancelScheduledChangeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dns_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelScheduledChangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduledChangeResponse) ProtoMessage() {}
+
+func (x *CancelScheduledChangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dns_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduledChangeResponse.ProtoReflect.Descriptor instead.
+func (*CancelScheduledChangeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dns_proto_rawDescGZIP(), []int{25}
+}
+
+type GetHostnameResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hostname string `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	// Version of /etc/hostname, for expected_version.
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *GetHostnameResponse) Reset() {
+	*x = GetHostnameResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dns_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetHostnameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHostnameResponse) ProtoMessage() {}
+
+func (x *GetHostnameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dns_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHostnameResponse.ProtoReflect.Descriptor instead.
+func (*GetHostnameResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dns_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetHostnameResponse) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *GetHostnameResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type SetHostnameResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Version of /etc/hostname after the change.
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// Set when confirm_within was requested; pass it to ConfirmChange.
+	ChangeId string `protobuf:"bytes,2,opt,name=change_id,json=changeId,proto3" json:"change_id,omitempty"`
+	// Set instead of version when the change waits for approval; pass it to
+	// ApproveChange.
+	PendingChangeId string `protobuf:"bytes,3,opt,name=pending_change_id,json=pendingChangeId,proto3" json:"pending_change_id,omitempty"`
+}
+
+func (x *SetHostnameResponse) Reset() {
+	*x = SetHostnameResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dns_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetHostnameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetHostnameResponse) ProtoMessage() {}
+
+func (x *SetHostnameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dns_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetHostnameResponse.ProtoReflect.Descriptor instead.
+func (*SetHostnameResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dns_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SetHostnameResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *SetHostnameResponse) GetChangeId() string {
+	if x != nil {
+		return x.ChangeId
+	}
+	return ""
+}
+
+func (x *SetHostnameResponse) GetPendingChangeId() string {
+	if x != nil {
+		return x.PendingChangeId
+	}
+	return ""
+}
+
+// PendingChange is a change waiting for a second identity to approve it.
+type PendingChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Types that are assignable to Change:
+	//	*PendingChange_SetHostname
+	//	*PendingChange_AddDnsServer
+	//	*PendingChange_RemoveDnsServer
+	//	*PendingChange_UpdateHostname
+	//	*PendingChange_UpdateResolverConfig
+	Change isPendingChange_Change `protobuf_oneof:"change"`
+	// Requester is the identity that asked for the change, e.g. "mtls:alice".
+	Requester string `protobuf:"bytes,5,opt,name=requester,proto3" json:"requester,omitempty"`
+	// Diff shows the change against the host at request time.
+	Diff       string                 `protobuf:"bytes,6,opt,name=diff,proto3" json:"diff,omitempty"`
+	CreateTime *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	ExpireTime *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
+}
+
+func (x *PendingChange) Reset() {
+	*x = PendingChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dns_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PendingChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PendingChange) ProtoMessage() {}
+
+func (x *PendingChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dns_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PendingChange.ProtoReflect.Descriptor instead.
+func (*PendingChange) Descriptor() ([]byte, []int) {
+	return file_proto_dns_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *PendingChange) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (m *PendingChange) GetChange() isPendingChange_Change {
+	if m != nil {
+		return m.Change
+	}
+	return nil
+}
+
+func (x *PendingChange) GetSetHostname() *SetHostnameRequest {
+	if x, ok := x.GetChange().(*PendingChange_SetHostname); ok {
+		return x.SetHostname
+	}
+	return nil
+}
+
+func (x *PendingChange) GetAddDnsServer() *AddDNSServerRequest {
+	if x, ok := x.GetChange().(*PendingChange_AddDnsServer); ok {
+		return x.AddDnsServer
+	}
+	return nil
+}
+
+func (x *PendingChange) GetRemoveDnsServer() *RemoveDNSServerRequest {
+	if x, ok := x.GetChange().(*PendingChange_RemoveDnsServer); ok {
+		return x.RemoveDnsServer
+	}
+	return nil
+}
+
+func (x *PendingChange) GetUpdateHostname() *v2.UpdateHostnameRequest {
+	if x, ok := x.GetChange().(*PendingChange_UpdateHostname); ok {
+		return x.UpdateHostname
+	}
+	return nil
+}
+
+func (x *PendingChange) GetUpdateResolverConfig() *v2.UpdateResolverConfigRequest {
+	if x, ok := x.GetChange().(*PendingChange_UpdateResolverConfig); ok {
+		return x.UpdateResolverConfig
+	}
+	return nil
+}
+
+func (x *PendingChange) GetRequester() string {
+	if x != nil {
+		return x.Requester
+	}
+	return ""
+}
+
+func (x *PendingChange) GetDiff() string {
+	if x != nil {
+		return x.Diff
+	}
+	return ""
+}
+
+func (x *PendingChange) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *PendingChange) GetExpireTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return nil
+}
+
+type isPendingChange_Change interface {
+	isPendingChange_Change()
+}
+
+type PendingChange_SetHostname struct {
+	SetHostname *SetHostnameRequest `protobuf:"bytes,2,opt,name=set_hostname,json=setHostname,proto3,oneof"`
+}
+
+type PendingChange_AddDnsServer struct {
+	AddDnsServer *AddDNSServerRequest `protobuf:"bytes,3,opt,name=add_dns_server,json=addDnsServer,proto3,oneof"`
+}
+
+type PendingChange_RemoveDnsServer struct {
+	RemoveDnsServer *RemoveDNSServerRequest `protobuf:"bytes,4,opt,name=remove_dns_server,json=removeDnsServer,proto3,oneof"`
+}
+
+type PendingChange_UpdateHostname struct {
+	UpdateHostname *v2.UpdateHostnameRequest `protobuf:"bytes,9,opt,name=update_hostname,json=updateHostname,proto3,oneof"`
+}
+
+type PendingChange_UpdateResolverConfig struct {
+	UpdateResolverConfig *v2.UpdateResolverConfigRequest `protobuf:"bytes,10,opt,name=update_resolver_config,json=updateResolverConfig,proto3,oneof"`
+}
+
+func (*PendingChange_SetHostname) isPendingChange_Change() {}
+
+func (*PendingChange_AddDnsServer) isPendingChange_Change() {}
+
+func (*PendingChange_RemoveDnsServer) isPendingChange_Change() {}
+
+func (*PendingChange_UpdateHostname) isPendingChange_Change() {}
+
+func (*PendingChange_UpdateResolverConfig) isPendingChange_Change() {}
+
+type ListPendingChangesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Changes []*PendingChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+}
+
+func (x *ListPendingChangesResponse) Reset() {
+	*x = ListPendingChangesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dns_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPendingChangesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPendingChangesResponse) ProtoMessage() {}
+
+func (x *ListPendingChangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dns_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPendingChangesResponse.ProtoReflect.Descriptor instead.
+func (*ListPendingChangesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dns_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListPendingChangesResponse) GetChanges() []*PendingChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type ApproveChangeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Version of the changed file after the change.
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// Set when the change was requested with confirm_within.
+	ChangeId string `protobuf:"bytes,2,opt,name=change_id,json=changeId,proto3" json:"change_id,omitempty"`
+}
+
+func (x *ApproveChangeResponse) Reset() {
+	*x = ApproveChangeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dns_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApproveChangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveChangeResponse) ProtoMessage() {}
+
+func (x *ApproveChangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dns_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveChangeResponse.ProtoReflect.Descriptor instead.
+func (*ApproveChangeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dns_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ApproveChangeResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *ApproveChangeResponse) GetChangeId() string {
+	if x != nil {
+		return x.ChangeId
+	}
+	return ""
+}
+
+type RejectChangeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RejectChangeResponse) Reset() {
+	*x = RejectChangeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dns_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RejectChangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectChangeResponse) ProtoMessage() {}
+
+func (x *RejectChangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dns_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectChangeResponse.ProtoReflect.Descriptor instead.
+func (*RejectChangeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dns_proto_rawDescGZIP(), []int{31}
+}
+
+var File_proto_dns_proto protoreflect.FileDescriptor
+
+var file_proto_dns_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x64, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x03, 0x64, 0x6e, 0x73, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61,
+	0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x70, 0x69, 0x76, 0x33,
+	0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x12, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x76, 0x32, 0x2f, 0x64, 0x6e, 0x73,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xed, 0x01, 0x0a, 0x12, 0x53, 0x65, 0x74, 0x48, 0x6f,
+	0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a,
+	0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x65, 0x78, 0x70,
+	0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x40, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x5f,
+	0x77, 0x69, 0x74, 0x68, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d,
+	0x57, 0x69, 0x74, 0x68, 0x69, 0x6e, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72,
+	0x65, 0x5f, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61,
+	0x6c, 0x12, 0x23, 0x0a, 0x0d, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x6f, 0x6e,
+	0x6c, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x65, 0x4f, 0x6e, 0x6c, 0x79, 0x22, 0x14, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73,
+	0x74, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x17, 0x0a, 0x15,
+	0x4c, 0x69, 0x73, 0x74, 0x44, 0x4e, 0x53, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xdb, 0x02, 0x0a, 0x13, 0x41, 0x64, 0x64, 0x44, 0x4e, 0x53,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a,
+	0x0a, 0x64, 0x6e, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x64, 0x6e, 0x73, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x29, 0x0a, 0x10,
+	0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x40, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x72, 0x6d, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x72, 0x6d, 0x57, 0x69, 0x74, 0x68, 0x69, 0x6e, 0x12, 0x2b, 0x0a, 0x03, 0x74, 0x74, 0x6c,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x12, 0x3b, 0x0a, 0x0b, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x54,
+	0x69, 0x6d, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x5f, 0x61,
+	0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x72,
+	0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x12, 0x23,
+	0x0a, 0x0d, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x4f,
+	0x6e, 0x6c, 0x79, 0x22, 0xf4, 0x01, 0x0a, 0x16, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x44, 0x4e,
+	0x53, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d,
+	0x0a, 0x0a, 0x64, 0x6e, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x64, 0x6e, 0x73, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x29, 0x0a,
+	0x10, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65,
+	0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x40, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x72, 0x6d, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x72, 0x6d, 0x57, 0x69, 0x74, 0x68, 0x69, 0x6e, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65,
+	0x71, 0x75, 0x69, 0x72, 0x65, 0x5f, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x41, 0x70, 0x70,
+	0x72, 0x6f, 0x76, 0x61, 0x6c, 0x12, 0x23, 0x0a, 0x0d, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x65, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x4f, 0x6e, 0x6c, 0x79, 0x22, 0x11, 0x0a, 0x0f, 0x47, 0x65,
+	0x74, 0x48, 0x6f, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x98, 0x01,
+	0x0a, 0x0f, 0x53, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x65,
+	0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x40, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72,
+	0x6d, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x72, 0x6d, 0x57, 0x69, 0x74, 0x68, 0x69, 0x6e, 0x22, 0x33, 0x0a, 0x14, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x72, 0x6d, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1b, 0x0a, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x64, 0x22, 0xde, 0x03,
+	0x0a, 0x15, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3c, 0x0a, 0x0c, 0x73, 0x65, 0x74, 0x5f, 0x68,
+	0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x64, 0x6e, 0x73, 0x2e, 0x53, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x73, 0x65, 0x74, 0x48, 0x6f, 0x73,
+	0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x40, 0x0a, 0x0e, 0x61, 0x64, 0x64, 0x5f, 0x64, 0x6e, 0x73,
+	0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e,
+	0x64, 0x6e, 0x73, 0x2e, 0x41, 0x64, 0x64, 0x44, 0x4e, 0x53, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0c, 0x61, 0x64, 0x64, 0x44, 0x6e,
+	0x73, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x49, 0x0a, 0x11, 0x72, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x5f, 0x64, 0x6e, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x44,
+	0x4e, 0x53, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48,
+	0x00, 0x52, 0x0f, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x44, 0x6e, 0x73, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x12, 0x48, 0x0a, 0x0f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x68, 0x6f, 0x73,
+	0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x64, 0x6e,
+	0x73, 0x2e, 0x76, 0x32, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x48, 0x6f, 0x73, 0x74, 0x6e,
+	0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0e, 0x75, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x48, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x5b, 0x0a, 0x16,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x72, 0x5f,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x64,
+	0x6e, 0x73, 0x2e, 0x76, 0x32, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x6f,
+	0x6c, 0x76, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x48, 0x00, 0x52, 0x14, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x6c,
+	0x76, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x35, 0x0a, 0x08, 0x72, 0x75, 0x6e,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x72, 0x75, 0x6e, 0x54, 0x69, 0x6d, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x63, 0x72, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x63, 0x72, 0x6f, 0x6e, 0x42, 0x08, 0x0a, 0x06, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x22, 0x1d,
+	0x0a, 0x1b, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x2e, 0x0a,
+	0x1c, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x1b, 0x0a,
+	0x19, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x26, 0x0a, 0x14, 0x41, 0x70,
+	0x70, 0x72, 0x6f, 0x76, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x64, 0x22, 0x3d, 0x0a, 0x13, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x22, 0x30, 0x0a, 0x13, 0x57, 0x61, 0x74, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x66, 0x74, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x61, 0x66, 0x74, 0x65,
+	0x72, 0x49, 0x64, 0x22, 0xdc, 0x01, 0x0a, 0x0b, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x73,
+	0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x73,
+	0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x6e, 0x73, 0x5f, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x6e, 0x73, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x22, 0xef, 0x01, 0x0a, 0x16, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x4e, 0x53, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a,
+	0x0b, 0x64, 0x6e, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0a, 0x64, 0x6e, 0x73, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x12, 0x18,
+	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x45, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x64, 0x6e, 0x73,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x4e, 0x53, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x1a,
+	0x53, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x44, 0x4e, 0x53, 0x53, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x50, 0x0a, 0x11, 0x44, 0x4e, 0x53, 0x53, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x3b, 0x0a, 0x0b, 0x65, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x79, 0x0a, 0x14, 0x41, 0x64, 0x64, 0x44, 0x4e, 0x53,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x11, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0f, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x49,
+	0x64, 0x22, 0x7c, 0x0a, 0x17, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x44, 0x4e, 0x53, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x11, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f,
+	0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x64, 0x22,
+	0x46, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x49, 0x0a, 0x10, 0x53, 0x65, 0x74, 0x48, 0x6f,
+	0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x49, 0x64, 0x22, 0x17, 0x0a, 0x15, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x43, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xc4, 0x05, 0x0a, 0x0f,
+	0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x3c, 0x0a, 0x0c, 0x73, 0x65, 0x74, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x53, 0x65, 0x74, 0x48,
+	0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00,
+	0x52, 0x0b, 0x73, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x40, 0x0a,
+	0x0e, 0x61, 0x64, 0x64, 0x5f, 0x64, 0x6e, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x41, 0x64, 0x64, 0x44,
+	0x4e, 0x53, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48,
+	0x00, 0x52, 0x0c, 0x61, 0x64, 0x64, 0x44, 0x6e, 0x73, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12,
+	0x49, 0x0a, 0x11, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x5f, 0x64, 0x6e, 0x73, 0x5f, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x64, 0x6e, 0x73,
+	0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x44, 0x4e, 0x53, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0f, 0x72, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x44, 0x6e, 0x73, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x48, 0x0a, 0x0f, 0x75, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x76, 0x32, 0x2e, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x48, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x48, 0x00, 0x52, 0x0e, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x48, 0x6f, 0x73, 0x74,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x5b, 0x0a, 0x16, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x72,
+	0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x76, 0x32, 0x2e, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x14, 0x75, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x12, 0x35, 0x0a, 0x08, 0x72, 0x75, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x07, 0x72, 0x75, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x72, 0x6f, 0x6e,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x72, 0x6f, 0x6e, 0x12, 0x3e, 0x0a, 0x0d,
+	0x6e, 0x65, 0x78, 0x74, 0x5f, 0x72, 0x75, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x0b, 0x6e, 0x65, 0x78, 0x74, 0x52, 0x75, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x3e, 0x0a, 0x0d,
+	0x6c, 0x61, 0x73, 0x74, 0x5f, 0x72, 0x75, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x0b, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x75, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a,
+	0x6c, 0x61, 0x73, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x3b, 0x0a, 0x0b, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x42, 0x08, 0x0a, 0x06, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x22, 0x4e, 0x0a, 0x1c, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x2e, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x73, 0x22, 0x1f, 0x0a, 0x1d, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x4b, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x6e, 0x61,
+	0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f,
+	0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f,
+	0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x22, 0x78, 0x0a, 0x13, 0x53, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x64, 0x12, 0x2a,
+	0x0a, 0x11, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x70, 0x65, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x64, 0x22, 0xc7, 0x04, 0x0a, 0x0d, 0x50,
+	0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x3c, 0x0a, 0x0c,
+	0x73, 0x65, 0x74, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x53, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74,
+	0x6e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x73,
+	0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x40, 0x0a, 0x0e, 0x61, 0x64,
+	0x64, 0x5f, 0x64, 0x6e, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x41, 0x64, 0x64, 0x44, 0x4e, 0x53, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0c,
+	0x61, 0x64, 0x64, 0x44, 0x6e, 0x73, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x49, 0x0a, 0x11,
+	0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x5f, 0x64, 0x6e, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x52, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x44, 0x4e, 0x53, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0f, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x44, 0x6e,
+	0x73, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x48, 0x0a, 0x0f, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x76, 0x32, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x48, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48,
+	0x00, 0x52, 0x0e, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x48, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x5b, 0x0a, 0x16, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x72, 0x65, 0x73, 0x6f,
+	0x6c, 0x76, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x23, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x76, 0x32, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x14, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1c,
+	0x0a, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04,
+	0x64, 0x69, 0x66, 0x66, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x69, 0x66, 0x66,
+	0x12, 0x3b, 0x0a, 0x0b, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x3b, 0x0a,
+	0x0b, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a,
+	0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x42, 0x08, 0x0a, 0x06, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x22, 0x4a, 0x0a, 0x1a, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x2c, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73,
+	0x22, 0x4e, 0x0a, 0x15, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x64,
+	0x22, 0x16, 0x0a, 0x14, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0xba, 0x10, 0x0a, 0x12, 0x44, 0x4e, 0x53,
+	0x48, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x82, 0x01, 0x0a, 0x0b, 0x53, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x17, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x53, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x53,
+	0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x40, 0xba, 0x47, 0x26, 0x42, 0x24, 0x0a, 0x22, 0x12, 0x20, 0x0a, 0x1e, 0x23,
+	0x2f, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x72, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x73, 0x2f, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x11, 0x3a, 0x01, 0x2a, 0x22, 0x0c, 0x2f, 0x76, 0x31, 0x2f, 0x68, 0x6f, 0x73, 0x74,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x7f, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x17, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73,
+	0x74, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x64,
+	0x6e, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x3d, 0xba, 0x47, 0x26, 0x42, 0x24, 0x0a, 0x22, 0x12,
+	0x20, 0x0a, 0x1e, 0x23, 0x2f, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x2f,
+	0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x2f, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65,
+	0x6d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0e, 0x12, 0x0c, 0x2f, 0x76, 0x31, 0x2f, 0x68, 0x6f, 0x73,
+	0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x83, 0x01, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x4e,
+	0x53, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x12, 0x1a, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x44, 0x4e, 0x53, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44,
+	0x4e, 0x53, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x38, 0xba, 0x47, 0x26, 0x42, 0x24, 0x0a, 0x22, 0x12, 0x20, 0x0a, 0x1e, 0x23, 0x2f,
+	0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x72, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x73, 0x2f, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x09, 0x12, 0x07, 0x2f, 0x76, 0x31, 0x2f, 0x64, 0x6e, 0x73, 0x12, 0x80, 0x01, 0x0a, 0x0c,
+	0x41, 0x64, 0x64, 0x44, 0x4e, 0x53, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x18, 0x2e, 0x64,
+	0x6e, 0x73, 0x2e, 0x41, 0x64, 0x64, 0x44, 0x4e, 0x53, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x41, 0x64, 0x64,
+	0x44, 0x4e, 0x53, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x3b, 0xba, 0x47, 0x26, 0x42, 0x24, 0x0a, 0x22, 0x12, 0x20, 0x0a, 0x1e, 0x23, 0x2f,
+	0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x72, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x73, 0x2f, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x0c, 0x3a, 0x01, 0x2a, 0x22, 0x07, 0x2f, 0x76, 0x31, 0x2f, 0x64, 0x6e, 0x73, 0x12, 0x93,
+	0x01, 0x0a, 0x0f, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x44, 0x4e, 0x53, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x12, 0x1b, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x44,
+	0x4e, 0x53, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1c, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x44, 0x4e, 0x53, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x45, 0xba,
+	0x47, 0x26, 0x42, 0x24, 0x0a, 0x22, 0x12, 0x20, 0x0a, 0x1e, 0x23, 0x2f, 0x63, 0x6f, 0x6d, 0x70,
+	0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73,
+	0x2f, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x16, 0x2a, 0x14,
+	0x2f, 0x76, 0x31, 0x2f, 0x64, 0x6e, 0x73, 0x2f, 0x7b, 0x64, 0x6e, 0x73, 0x5f, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x7d, 0x12, 0x73, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x73,
+	0x12, 0x14, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x47, 0x65, 0x74,
+	0x48, 0x6f, 0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x3a, 0xba,
+	0x47, 0x26, 0x42, 0x24, 0x0a, 0x22, 0x12, 0x20, 0x0a, 0x1e, 0x23, 0x2f, 0x63, 0x6f, 0x6d, 0x70,
+	0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73,
+	0x2f, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0b, 0x12, 0x09,
+	0x2f, 0x76, 0x31, 0x2f, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x76, 0x0a, 0x08, 0x53, 0x65, 0x74,
+	0x48, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x14, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x53, 0x65, 0x74, 0x48,
+	0x6f, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x64, 0x6e,
+	0x73, 0x2e, 0x53, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x3d, 0xba, 0x47, 0x26, 0x42, 0x24, 0x0a, 0x22, 0x12, 0x20, 0x0a, 0x1e, 0x23,
+	0x2f, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x72, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x73, 0x2f, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x0e, 0x3a, 0x01, 0x2a, 0x1a, 0x09, 0x2f, 0x76, 0x31, 0x2f, 0x68, 0x6f, 0x73, 0x74,
+	0x73, 0x12, 0x98, 0x01, 0x0a, 0x0d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x43, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x12, 0x19, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72,
+	0x6d, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a,
+	0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x50, 0xba, 0x47, 0x26, 0x42,
+	0x24, 0x0a, 0x22, 0x12, 0x20, 0x0a, 0x1e, 0x23, 0x2f, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65,
+	0x6e, 0x74, 0x73, 0x2f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x2f, 0x50, 0x72,
+	0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x21, 0x22, 0x1f, 0x2f, 0x76, 0x31,
+	0x2f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x2f, 0x7b, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x5f, 0x69, 0x64, 0x7d, 0x3a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x12, 0x8d, 0x01, 0x0a,
+	0x0e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12,
+	0x1a, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x64, 0x6e,
+	0x73, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x22, 0x49, 0xba, 0x47, 0x26, 0x42, 0x24, 0x0a, 0x22, 0x12, 0x20, 0x0a, 0x1e, 0x23, 0x2f,
+	0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x72, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x73, 0x2f, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x1a, 0x3a, 0x01, 0x2a, 0x22, 0x15, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x64, 0x2d, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0xa3, 0x01, 0x0a,
+	0x14, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x20, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x46, 0xba, 0x47, 0x26, 0x42,
+	0x24, 0x0a, 0x22, 0x12, 0x20, 0x0a, 0x1e, 0x23, 0x2f, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65,
+	0x6e, 0x74, 0x73, 0x2f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x2f, 0x50, 0x72,
+	0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x17, 0x12, 0x15, 0x2f, 0x76, 0x31,
+	0x2f, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x2d, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x73, 0x12, 0xab, 0x01, 0x0a, 0x15, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x21, 0x2e, 0x64,
+	0x6e, 0x73, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c,
+	0x65, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x22, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x4b, 0xba, 0x47, 0x26, 0x42, 0x24, 0x0a, 0x22, 0x12, 0x20, 0x0a, 0x1e,
+	0x23, 0x2f, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x72, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x2f, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x1c, 0x2a, 0x1a, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x64, 0x2d, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d,
+	0x12, 0x9b, 0x01, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x1e, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x44, 0xba, 0x47, 0x26, 0x42, 0x24, 0x0a,
+	0x22, 0x12, 0x20, 0x0a, 0x1e, 0x23, 0x2f, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74,
+	0x73, 0x2f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x2f, 0x50, 0x72, 0x6f, 0x62,
+	0x6c, 0x65, 0x6d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x15, 0x12, 0x13, 0x2f, 0x76, 0x31, 0x2f, 0x70,
+	0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x2d, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x99,
+	0x01, 0x0a, 0x0d, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x12, 0x19, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x64, 0x6e,
+	0x73, 0x2e, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x51, 0xba, 0x47, 0x26, 0x42, 0x24, 0x0a, 0x22,
+	0x12, 0x20, 0x0a, 0x1e, 0x23, 0x2f, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73,
+	0x2f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x2f, 0x50, 0x72, 0x6f, 0x62, 0x6c,
+	0x65, 0x6d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x22, 0x22, 0x20, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x65,
+	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x2d, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x2f, 0x7b, 0x69,
+	0x64, 0x7d, 0x3a, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x12, 0x98, 0x01, 0x0a, 0x0c, 0x52,
+	0x65, 0x6a, 0x65, 0x63, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x18, 0x2e, 0x64, 0x6e,
+	0x73, 0x2e, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x52, 0x65, 0x6a, 0x65,
+	0x63, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x53, 0xba, 0x47, 0x26, 0x42, 0x24, 0x0a, 0x22, 0x12, 0x20, 0x0a, 0x1e, 0x23, 0x2f, 0x63,
+	0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x73, 0x2f, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x24, 0x3a, 0x01, 0x2a, 0x22, 0x1f, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x2d, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x3a, 0x72,
+	0x65, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x3e, 0x0a, 0x0c, 0x57, 0x61, 0x74, 0x63, 0x68, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x18, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x10, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x22, 0x00, 0x30, 0x01, 0x42, 0xfe, 0x02, 0xba, 0x47, 0xee, 0x02, 0x2a, 0xeb, 0x02, 0x0a,
+	0xe9, 0x01, 0x0a, 0xe6, 0x01, 0x0a, 0x07, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x12, 0xda,
+	0x01, 0x0a, 0xd7, 0x01, 0xca, 0x01, 0x06, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0xfa, 0x01, 0xca,
+	0x01, 0x0a, 0x13, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x09, 0xca, 0x01, 0x06,
+	0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x0a, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12,
+	0x0b, 0x0a, 0x09, 0xca, 0x01, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x0a, 0x1e, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x12, 0xca, 0x01, 0x07, 0x69, 0x6e, 0x74,
+	0x65, 0x67, 0x65, 0x72, 0x9a, 0x02, 0x05, 0x69, 0x6e, 0x74, 0x33, 0x32, 0x0a, 0x15, 0x0a, 0x06,
+	0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x0b, 0x0a, 0x09, 0xca, 0x01, 0x06, 0x73, 0x74, 0x72,
+	0x69, 0x6e, 0x67, 0x0a, 0x17, 0x0a, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12,
+	0x0b, 0x0a, 0x09, 0xca, 0x01, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x0a, 0x33, 0x0a, 0x04,
+	0x63, 0x6f, 0x64, 0x65, 0x12, 0x2b, 0x0a, 0x29, 0xca, 0x01, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e,
+	0x67, 0x92, 0x02, 0x1d, 0x4e, 0x61, 0x6d, 0x65, 0x20, 0x6f, 0x66, 0x20, 0x74, 0x68, 0x65, 0x20,
+	0x67, 0x52, 0x50, 0x43, 0x20, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x20, 0x63, 0x6f, 0x64, 0x65,
+	0x2e, 0x0a, 0x18, 0x0a, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x0b,
+	0x0a, 0x09, 0xca, 0x01, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x7d, 0x0a, 0x7b, 0x0a,
+	0x07, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x12, 0x70, 0x0a, 0x6e, 0x0a, 0x2a, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x20, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x20, 0x61, 0x73, 0x20,
+	0x52, 0x46, 0x43, 0x20, 0x37, 0x38, 0x30, 0x37, 0x20, 0x70, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d,
+	0x20, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x1a, 0x40, 0x0a, 0x3e, 0x0a, 0x18, 0x61, 0x70,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x62, 0x6c, 0x65,
+	0x6d, 0x2b, 0x6a, 0x73, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x20, 0x12, 0x1e, 0x0a, 0x1c, 0x23, 0x2f,
+	0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x73, 0x2f, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x5a, 0x0a, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x3b, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_dns_proto_rawDescOnce sync.Once
+	file_proto_dns_proto_rawDescData = file_proto_dns_proto_rawDesc
+)
+
+func file_proto_dns_proto_rawDescGZIP() []byte {
+	file_proto_dns_proto_rawDescOnce.Do(func() {
+		file_proto_dns_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_dns_proto_rawDescData)
+	})
+	return file_proto_dns_proto_rawDescData
+}
+
+var file_proto_dns_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
+var file_proto_dns_proto_goTypes = []any{
+	(*SetHostnameRequest)(nil),             // 0: dns.SetHostnameRequest
+	(*GetHostnameRequest)(nil),             // 1: dns.GetHostnameRequest
+	(*ListDNSServersRequest)(nil),          // 2: dns.ListDNSServersRequest
+	(*AddDNSServerRequest)(nil),            // 3: dns.AddDNSServerRequest
+	(*RemoveDNSServerRequest)(nil),         // 4: dns.RemoveDNSServerRequest
+	(*GetHostsRequest)(nil),                // 5: dns.GetHostsRequest
+	(*SetHostsRequest)(nil),                // 6: dns.SetHostsRequest
+	(*ConfirmChangeRequest)(nil),           // 7: dns.ConfirmChangeRequest
+	(*ScheduleChangeRequest)(nil),          // 8: dns.ScheduleChangeRequest
+	(*ListScheduledChangesRequest)(nil),    // 9: dns.ListScheduledChangesRequest
+	(*CancelScheduledChangeRequest)(nil),   // 10: dns.CancelScheduledChangeRequest
+	(*ListPendingChangesRequest)(nil),      // 11: dns.ListPendingChangesRequest
+	(*ApproveChangeRequest)(nil),           // 12: dns.ApproveChangeRequest
+	(*RejectChangeRequest)(nil),            // 13: dns.RejectChangeRequest
+	(*WatchChangesRequest)(nil),            // 14: dns.WatchChangesRequest
+	(*ChangeEvent)(nil),                    // 15: dns.ChangeEvent
+	(*ListDNSServersResponse)(nil),         // 16: dns.ListDNSServersResponse
+	(*DNSServerMetadata)(nil),              // 17: dns.DNSServerMetadata
+	(*AddDNSServerResponse)(nil),           // 18: dns.AddDNSServerResponse
+	(*RemoveDNSServerResponse)(nil),        // 19: dns.RemoveDNSServerResponse
+	(*GetHostsResponse)(nil),               // 20: dns.GetHostsResponse
+	(*SetHostsResponse)(nil),               // 21: dns.SetHostsResponse
+	(*ConfirmChangeResponse)(nil),          // 22: dns.ConfirmChangeResponse
+	(*ScheduledChange)(nil),                // 23: dns.ScheduledChange
+	(*ListScheduledChangesResponse)(nil),   // 24: dns.ListScheduledChangesResponse
+	(*CancelScheduledChangeResponse)(nil),  // 25: dns.CancelScheduledChangeResponse
+	(*GetHostnameResponse)(nil),            // 26: dns.GetHostnameResponse
+	(*SetHostnameResponse)(nil),            // 27: dns.SetHostnameResponse
+	(*PendingChange)(nil),                  // 28: dns.PendingChange
+	(*ListPendingChangesResponse)(nil),     // 29: dns.ListPendingChangesResponse
+	(*ApproveChangeResponse)(nil),          // 30: dns.ApproveChangeResponse
+	(*RejectChangeResponse)(nil),           // 31: dns.RejectChangeResponse
+	nil,                                    // 32: dns.ListDNSServersResponse.MetadataEntry
+	(*durationpb.Duration)(nil),            // 33: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),          // 34: google.protobuf.Timestamp
+	(*v2.UpdateHostnameRequest)(nil),       // 35: dns.v2.UpdateHostnameRequest
+	(*v2.UpdateResolverConfigRequest)(nil), // 36: dns.v2.UpdateResolverConfigRequest
+}
+var file_proto_dns_proto_depIdxs = []int32{
+	33, // 0: dns.SetHostnameRequest.confirm_within:type_name -> google.protobuf.Duration
+	33, // 1: dns.AddDNSServerRequest.confirm_within:type_name -> google.protobuf.Duration
+	33, // 2: dns.AddDNSServerRequest.ttl:type_name -> google.protobuf.Duration
+	34, // 3: dns.AddDNSServerRequest.expire_time:type_name -> google.protobuf.Timestamp
+	33, // 4: dns.RemoveDNSServerRequest.confirm_within:type_name -> google.protobuf.Duration
+	33, // 5: dns.SetHostsRequest.confirm_within:type_name -> google.protobuf.Duration
+	0,  // 6: dns.ScheduleChangeRequest.set_hostname:type_name -> dns.SetHostnameRequest
+	3,  // 7: dns.ScheduleChangeRequest.add_dns_server:type_name -> dns.AddDNSServerRequest
+	4,  // 8: dns.ScheduleChangeRequest.remove_dns_server:type_name -> dns.RemoveDNSServerRequest
+	35, // 9: dns.ScheduleChangeRequest.update_hostname:type_name -> dns.v2.UpdateHostnameRequest
+	36, // 10: dns.ScheduleChangeRequest.update_resolver_config:type_name -> dns.v2.UpdateResolverConfigRequest
+	34, // 11: dns.ScheduleChangeRequest.run_time:type_name -> google.protobuf.Timestamp
+	34, // 12: dns.ChangeEvent.time:type_name -> google.protobuf.Timestamp
+	32, // 13: dns.ListDNSServersResponse.metadata:type_name -> dns.ListDNSServersResponse.MetadataEntry
+	34, // 14: dns.DNSServerMetadata.expire_time:type_name -> google.protobuf.Timestamp
+	0,  // 15: dns.ScheduledChange.set_hostname:type_name -> dns.SetHostnameRequest
+	3,  // 16: dns.ScheduledChange.add_dns_server:type_name -> dns.AddDNSServerRequest
+	4,  // 17: dns.ScheduledChange.remove_dns_server:type_name -> dns.RemoveDNSServerRequest
+	35, // 18: dns.ScheduledChange.update_hostname:type_name -> dns.v2.UpdateHostnameRequest
+	36, // 19: dns.ScheduledChange.update_resolver_config:type_name -> dns.v2.UpdateResolverConfigRequest
+	34, // 20: dns.ScheduledChange.run_time:type_name -> google.protobuf.Timestamp
+	34, // 21: dns.ScheduledChange.next_run_time:type_name -> google.protobuf.Timestamp
+	34, // 22: dns.ScheduledChange.last_run_time:type_name -> google.protobuf.Timestamp
+	34, // 23: dns.ScheduledChange.create_time:type_name -> google.protobuf.Timestamp
+	23, // 24: dns.ListScheduledChangesResponse.changes:type_name -> dns.ScheduledChange
+	0,  // 25: dns.PendingChange.set_hostname:type_name -> dns.SetHostnameRequest
+	3,  // 26: dns.PendingChange.add_dns_server:type_name -> dns.AddDNSServerRequest
+	4,  // 27: dns.PendingChange.remove_dns_server:type_name -> dns.RemoveDNSServerRequest
+	35, // 28: dns.PendingChange.update_hostname:type_name -> dns.v2.UpdateHostnameRequest
+	36, // 29: dns.PendingChange.update_resolver_config:type_name -> dns.v2.UpdateResolverConfigRequest
+	34, // 30: dns.PendingChange.create_time:type_name -> google.protobuf.Timestamp
+	34, // 31: dns.PendingChange.expire_time:type_name -> google.protobuf.Timestamp
+	28, // 32: dns.ListPendingChangesResponse.changes:type_name -> dns.PendingChange
+	17, // 33: dns.ListDNSServersResponse.MetadataEntry.value:type_name -> dns.DNSServerMetadata
+	0,  // 34: dns.DNSHostnameService.SetHostname:input_type -> dns.SetHostnameRequest
+	1,  // 35: dns.DNSHostnameService.GetHostname:input_type -> dns.GetHostnameRequest
+	2,  // 36: dns.DNSHostnameService.ListDNSServers:input_type -> dns.ListDNSServersRequest
+	3,  // 37: dns.DNSHostnameService.AddDNSServer:input_type -> dns.AddDNSServerRequest
+	4,  // 38: dns.DNSHostnameService.RemoveDNSServer:input_type -> dns.RemoveDNSServerRequest
+	5,  // 39: dns.DNSHostnameService.GetHosts:input_type -> dns.GetHostsRequest
+	6,  // 40: dns.DNSHostnameService.SetHosts:input_type -> dns.SetHostsRequest
+	7,  // 41: dns.DNSHostnameService.ConfirmChange:input_type -> dns.ConfirmChangeRequest
+	8,  // 42: dns.DNSHostnameService.ScheduleChange:input_type -> dns.ScheduleChangeRequest
+	9,  // 43: dns.DNSHostnameService.ListScheduledChanges:input_type -> dns.ListScheduledChangesRequest
+	10, // 44: dns.DNSHostnameService.CancelScheduledChange:input_type -> dns.CancelScheduledChangeRequest
+	11, // 45: dns.DNSHostnameService.ListPendingChanges:input_type -> dns.ListPendingChangesRequest
+	12, // 46: dns.DNSHostnameService.ApproveChange:input_type -> dns.ApproveChangeRequest
+	13, // 47: dns.DNSHostnameService.RejectChange:input_type -> dns.RejectChangeRequest
+	14, // 48: dns.DNSHostnameService.WatchChanges:input_type -> dns.WatchChangesRequest
+	27, // 49: dns.DNSHostnameService.SetHostname:output_type -> dns.SetHostnameResponse
+	26, // 50: dns.DNSHostnameService.GetHostname:output_type -> dns.GetHostnameResponse
+	16, // 51: dns.DNSHostnameService.ListDNSServers:output_type -> dns.ListDNSServersResponse
+	18, // 52: dns.DNSHostnameService.AddDNSServer:output_type -> dns.AddDNSServerResponse
+	19, // 53: dns.DNSHostnameService.RemoveDNSServer:output_type -> dns.RemoveDNSServerResponse
+	20, // 54: dns.DNSHostnameService.GetHosts:output_type -> dns.GetHostsResponse
+	21, // 55: dns.DNSHostnameService.SetHosts:output_type -> dns.SetHostsResponse
+	22, // 56: dns.DNSHostnameService.ConfirmChange:output_type -> dns.ConfirmChangeResponse
+	23, // 57: dns.DNSHostnameService.ScheduleChange:output_type -> dns.ScheduledChange
+	24, // 58: dns.DNSHostnameService.ListScheduledChanges:output_type -> dns.ListScheduledChangesResponse
+	25, // 59: dns.DNSHostnameService.CancelScheduledChange:output_type -> dns.CancelScheduledChangeResponse
+	29, // 60: dns.DNSHostnameService.ListPendingChanges:output_type -> dns.ListPendingChangesResponse
+	30, // 61: dns.DNSHostnameService.ApproveChange:output_type -> dns.ApproveChangeResponse
+	31, // 62: dns.DNSHostnameService.RejectChange:output_type -> dns.RejectChangeResponse
+	15, // 63: dns.DNSHostnameService.WatchChanges:output_type -> dns.ChangeEvent
+	49, // [49:64] is the sub-list for method output_type
+	34, // [34:49] is the sub-list for method input_type
+	34, // [34:34] is the sub-list for extension type_name
+	34, // [34:34] is the sub-list for extension extendee
+	0,  // [0:34] is the sub-list for field type_name
+}
+
+func init() { file_proto_dns_proto_init() }
+func file_proto_dns_proto_init() {
+	if File_proto_dns_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_dns_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*SetHostnameRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dns_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*GetHostnameRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
 				return &v.unknownFields
 			default:
 				return nil
 			}
 		}
 		file_proto_dns_proto_msgTypes[2].Exporter = func(v any, i int) any {
-			switch v := v.(*AddDNSServerRequest); i {
+			switch v := v.(*ListDNSServersRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -498,7 +2766,7 @@ func file_proto_dns_proto_init() {
 			}
 		}
 		file_proto_dns_proto_msgTypes[3].Exporter = func(v any, i int) any {
-			switch v := v.(*RemoveDNSServerRequest); i {
+			switch v := v.(*AddDNSServerRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -510,7 +2778,7 @@ func file_proto_dns_proto_init() {
 			}
 		}
 		file_proto_dns_proto_msgTypes[4].Exporter = func(v any, i int) any {
-			switch v := v.(*ListDNSServersResponse); i {
+			switch v := v.(*RemoveDNSServerRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -522,7 +2790,7 @@ func file_proto_dns_proto_init() {
 			}
 		}
 		file_proto_dns_proto_msgTypes[5].Exporter = func(v any, i int) any {
-			switch v := v.(*AddDNSServerResponse); i {
+			switch v := v.(*GetHostsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -534,7 +2802,7 @@ func file_proto_dns_proto_init() {
 			}
 		}
 		file_proto_dns_proto_msgTypes[6].Exporter = func(v any, i int) any {
-			switch v := v.(*RemoveDNSServerResponse); i {
+			switch v := v.(*SetHostsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -546,6 +2814,246 @@ func file_proto_dns_proto_init() {
 			}
 		}
 		file_proto_dns_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*ConfirmChangeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dns_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*ScheduleChangeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dns_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*ListScheduledChangesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dns_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*CancelScheduledChangeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dns_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*ListPendingChangesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dns_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*ApproveChangeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dns_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*RejectChangeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dns_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*WatchChangesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dns_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*ChangeEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dns_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*ListDNSServersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dns_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*DNSServerMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dns_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*AddDNSServerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dns_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*RemoveDNSServerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dns_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*GetHostsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dns_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*SetHostsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dns_proto_msgTypes[22].Exporter = func(v any, i int) any {
+			switch v := v.(*ConfirmChangeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dns_proto_msgTypes[23].Exporter = func(v any, i int) any {
+			switch v := v.(*ScheduledChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dns_proto_msgTypes[24].Exporter = func(v any, i int) any {
+			switch v := v.(*ListScheduledChangesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dns_proto_msgTypes[25].Exporter = func(v any, i int) any {
+			switch v := v.(*CancelScheduledChangeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dns_proto_msgTypes[26].Exporter = func(v any, i int) any {
+			switch v := v.(*GetHostnameResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dns_proto_msgTypes[27].Exporter = func(v any, i int) any {
 			switch v := v.(*SetHostnameResponse); i {
 			case 0:
 				return &v.state
@@ -557,6 +3065,75 @@ func file_proto_dns_proto_init() {
 				return nil
 			}
 		}
+		file_proto_dns_proto_msgTypes[28].Exporter = func(v any, i int) any {
+			switch v := v.(*PendingChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dns_proto_msgTypes[29].Exporter = func(v any, i int) any {
+			switch v := v.(*ListPendingChangesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dns_proto_msgTypes[30].Exporter = func(v any, i int) any {
+			switch v := v.(*ApproveChangeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dns_proto_msgTypes[31].Exporter = func(v any, i int) any {
+			switch v := v.(*RejectChangeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_proto_dns_proto_msgTypes[8].OneofWrappers = []any{
+		(*ScheduleChangeRequest_SetHostname)(nil),
+		(*ScheduleChangeRequest_AddDnsServer)(nil),
+		(*ScheduleChangeRequest_RemoveDnsServer)(nil),
+		(*ScheduleChangeRequest_UpdateHostname)(nil),
+		(*ScheduleChangeRequest_UpdateResolverConfig)(nil),
+	}
+	file_proto_dns_proto_msgTypes[23].OneofWrappers = []any{
+		(*ScheduledChange_SetHostname)(nil),
+		(*ScheduledChange_AddDnsServer)(nil),
+		(*ScheduledChange_RemoveDnsServer)(nil),
+		(*ScheduledChange_UpdateHostname)(nil),
+		(*ScheduledChange_UpdateResolverConfig)(nil),
+	}
+	file_proto_dns_proto_msgTypes[28].OneofWrappers = []any{
+		(*PendingChange_SetHostname)(nil),
+		(*PendingChange_AddDnsServer)(nil),
+		(*PendingChange_RemoveDnsServer)(nil),
+		(*PendingChange_UpdateHostname)(nil),
+		(*PendingChange_UpdateResolverConfig)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -564,7 +3141,7 @@ func file_proto_dns_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_dns_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   33,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,20 +19,71 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	DNSHostnameService_SetHostname_FullMethodName     = "/dns.DNSHostnameService/SetHostname"
-	DNSHostnameService_ListDNSServers_FullMethodName  = "/dns.DNSHostnameService/ListDNSServers"
-	DNSHostnameService_AddDNSServer_FullMethodName    = "/dns.DNSHostnameService/AddDNSServer"
-	DNSHostnameService_RemoveDNSServer_FullMethodName = "/dns.DNSHostnameService/RemoveDNSServer"
+	DNSHostnameService_SetHostname_FullMethodName           = "/dns.DNSHostnameService/SetHostname"
+	DNSHostnameService_GetHostname_FullMethodName           = "/dns.DNSHostnameService/GetHostname"
+	DNSHostnameService_ListDNSServers_FullMethodName        = "/dns.DNSHostnameService/ListDNSServers"
+	DNSHostnameService_AddDNSServer_FullMethodName          = "/dns.DNSHostnameService/AddDNSServer"
+	DNSHostnameService_RemoveDNSServer_FullMethodName       = "/dns.DNSHostnameService/RemoveDNSServer"
+	DNSHostnameService_GetHosts_FullMethodName              = "/dns.DNSHostnameService/GetHosts"
+	DNSHostnameService_SetHosts_FullMethodName              = "/dns.DNSHostnameService/SetHosts"
+	DNSHostnameService_ConfirmChange_FullMethodName         = "/dns.DNSHostnameService/ConfirmChange"
+	DNSHostnameService_ScheduleChange_FullMethodName        = "/dns.DNSHostnameService/ScheduleChange"
+	DNSHostnameService_ListScheduledChanges_FullMethodName  = "/dns.DNSHostnameService/ListScheduledChanges"
+	DNSHostnameService_CancelScheduledChange_FullMethodName = "/dns.DNSHostnameService/CancelScheduledChange"
+	DNSHostnameService_ListPendingChanges_FullMethodName    = "/dns.DNSHostnameService/ListPendingChanges"
+	DNSHostnameService_ApproveChange_FullMethodName         = "/dns.DNSHostnameService/ApproveChange"
+	DNSHostnameService_RejectChange_FullMethodName          = "/dns.DNSHostnameService/RejectChange"
+	DNSHostnameService_WatchChanges_FullMethodName          = "/dns.DNSHostnameService/WatchChanges"
 )
 
 // DNSHostnameServiceClient is the client API for DNSHostnameService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// DNSHostnameService manages the hostname and the nameservers in
+// /etc/resolv.conf of the host.
+//
+// Every mutation returns the new version of the file it changed, a content
+// hash. Passing it back as expected_version makes the next change fail with
+// ABORTED (412 Precondition Failed over REST) if the file changed meanwhile.
+// Mutations fail with FAILED_PRECONDITION while the server is frozen or when
+// the admission policy denies them.
 type DNSHostnameServiceClient interface {
+	// Sets the hostname of the host and writes it to /etc/hostname.
 	SetHostname(ctx context.Context, in *SetHostnameRequest, opts ...grpc.CallOption) (*SetHostnameResponse, error)
+	// Returns the current hostname and its version.
+	GetHostname(ctx context.Context, in *GetHostnameRequest, opts ...grpc.CallOption) (*GetHostnameResponse, error)
+	// Lists the nameservers in /etc/resolv.conf and their version.
 	ListDNSServers(ctx context.Context, in *ListDNSServersRequest, opts ...grpc.CallOption) (*ListDNSServersResponse, error)
+	// Appends a nameserver to /etc/resolv.conf. Fails with ALREADY_EXISTS if
+	// it is already present.
 	AddDNSServer(ctx context.Context, in *AddDNSServerRequest, opts ...grpc.CallOption) (*AddDNSServerResponse, error)
+	// Removes a nameserver from /etc/resolv.conf. Fails with NOT_FOUND if it is
+	// not present.
 	RemoveDNSServer(ctx context.Context, in *RemoveDNSServerRequest, opts ...grpc.CallOption) (*RemoveDNSServerResponse, error)
+	// Returns the content of /etc/hosts and its version.
+	GetHosts(ctx context.Context, in *GetHostsRequest, opts ...grpc.CallOption) (*GetHostsResponse, error)
+	// Replaces the content of /etc/hosts.
+	SetHosts(ctx context.Context, in *SetHostsRequest, opts ...grpc.CallOption) (*SetHostsResponse, error)
+	// Keeps a change made with confirm_within, which is otherwise rolled back.
+	ConfirmChange(ctx context.Context, in *ConfirmChangeRequest, opts ...grpc.CallOption) (*ConfirmChangeResponse, error)
+	// Schedules a change to run once at run_time or repeatedly on cron.
+	ScheduleChange(ctx context.Context, in *ScheduleChangeRequest, opts ...grpc.CallOption) (*ScheduledChange, error)
+	// Lists scheduled changes with their next and last runs.
+	ListScheduledChanges(ctx context.Context, in *ListScheduledChangesRequest, opts ...grpc.CallOption) (*ListScheduledChangesResponse, error)
+	// Cancels a scheduled change.
+	CancelScheduledChange(ctx context.Context, in *CancelScheduledChangeRequest, opts ...grpc.CallOption) (*CancelScheduledChangeResponse, error)
+	// Lists changes waiting for approval.
+	ListPendingChanges(ctx context.Context, in *ListPendingChangesRequest, opts ...grpc.CallOption) (*ListPendingChangesResponse, error)
+	// Approves a pending change and applies it. The approver must be a
+	// different identity than the requester.
+	ApproveChange(ctx context.Context, in *ApproveChangeRequest, opts ...grpc.CallOption) (*ApproveChangeResponse, error)
+	// Rejects a pending change, which is then dropped.
+	RejectChange(ctx context.Context, in *RejectChangeRequest, opts ...grpc.CallOption) (*RejectChangeResponse, error)
+	// Streams changes of the hostname, resolv.conf and /etc/hosts, whether
+	// made through the API or externally. Over REST, GET /v1/events serves the
+	// stream as Server-Sent Events.
+	WatchChanges(ctx context.Context, in *WatchChangesRequest, opts ...grpc.CallOption) (DNSHostnameService_WatchChangesClient, error)
 }
 
 type dNSHostnameServiceClient struct {
@@ -53,6 +104,16 @@ func (c *dNSHostnameServiceClient) SetHostname(ctx context.Context, in *SetHostn
 	return out, nil
 }
 
+func (c *dNSHostnameServiceClient) GetHostname(ctx context.Context, in *GetHostnameRequest, opts ...grpc.CallOption) (*GetHostnameResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetHostnameResponse)
+	err := c.cc.Invoke(ctx, DNSHostnameService_GetHostname_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dNSHostnameServiceClient) ListDNSServers(ctx context.Context, in *ListDNSServersRequest, opts ...grpc.CallOption) (*ListDNSServersResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListDNSServersResponse)
@@ -83,14 +144,177 @@ func (c *dNSHostnameServiceClient) RemoveDNSServer(ctx context.Context, in *Remo
 	return out, nil
 }
 
+func (c *dNSHostnameServiceClient) GetHosts(ctx context.Context, in *GetHostsRequest, opts ...grpc.CallOption) (*GetHostsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetHostsResponse)
+	err := c.cc.Invoke(ctx, DNSHostnameService_GetHosts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dNSHostnameServiceClient) SetHosts(ctx context.Context, in *SetHostsRequest, opts ...grpc.CallOption) (*SetHostsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetHostsResponse)
+	err := c.cc.Invoke(ctx, DNSHostnameService_SetHosts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dNSHostnameServiceClient) ConfirmChange(ctx context.Context, in *ConfirmChangeRequest, opts ...grpc.CallOption) (*ConfirmChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmChangeResponse)
+	err := c.cc.Invoke(ctx, DNSHostnameService_ConfirmChange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dNSHostnameServiceClient) ScheduleChange(ctx context.Context, in *ScheduleChangeRequest, opts ...grpc.CallOption) (*ScheduledChange, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduledChange)
+	err := c.cc.Invoke(ctx, DNSHostnameService_ScheduleChange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dNSHostnameServiceClient) ListScheduledChanges(ctx context.Context, in *ListScheduledChangesRequest, opts ...grpc.CallOption) (*ListScheduledChangesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListScheduledChangesResponse)
+	err := c.cc.Invoke(ctx, DNSHostnameService_ListScheduledChanges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dNSHostnameServiceClient) CancelScheduledChange(ctx context.Context, in *CancelScheduledChangeRequest, opts ...grpc.CallOption) (*CancelScheduledChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelScheduledChangeResponse)
+	err := c.cc.Invoke(ctx, DNSHostnameService_CancelScheduledChange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dNSHostnameServiceClient) ListPendingChanges(ctx context.Context, in *ListPendingChangesRequest, opts ...grpc.CallOption) (*ListPendingChangesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPendingChangesResponse)
+	err := c.cc.Invoke(ctx, DNSHostnameService_ListPendingChanges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dNSHostnameServiceClient) ApproveChange(ctx context.Context, in *ApproveChangeRequest, opts ...grpc.CallOption) (*ApproveChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApproveChangeResponse)
+	err := c.cc.Invoke(ctx, DNSHostnameService_ApproveChange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dNSHostnameServiceClient) RejectChange(ctx context.Context, in *RejectChangeRequest, opts ...grpc.CallOption) (*RejectChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RejectChangeResponse)
+	err := c.cc.Invoke(ctx, DNSHostnameService_RejectChange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dNSHostnameServiceClient) WatchChanges(ctx context.Context, in *WatchChangesRequest, opts ...grpc.CallOption) (DNSHostnameService_WatchChangesClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DNSHostnameService_ServiceDesc.Streams[0], DNSHostnameService_WatchChanges_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dNSHostnameServiceWatchChangesClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DNSHostnameService_WatchChangesClient interface {
+	Recv() (*ChangeEvent, error)
+	grpc.ClientStream
+}
+
+type dNSHostnameServiceWatchChangesClient struct {
+	grpc.ClientStream
+}
+
+func (x *dNSHostnameServiceWatchChangesClient) Recv() (*ChangeEvent, error) {
+	m := new(ChangeEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // DNSHostnameServiceServer is the server API for DNSHostnameService service.
 // All implementations must embed UnimplementedDNSHostnameServiceServer
 // for forward compatibility
+//
+// DNSHostnameService manages the hostname and the nameservers in
+// /etc/resolv.conf of the host.
+//
+// Every mutation returns the new version of the file it changed, a content
+// hash. Passing it back as expected_version makes the next change fail with
+// ABORTED (412 Precondition Failed over REST) if the file changed meanwhile.
+// Mutations fail with FAILED_PRECONDITION while the server is frozen or when
+// the admission policy denies them.
 type DNSHostnameServiceServer interface {
+	// Sets the hostname of the host and writes it to /etc/hostname.
 	SetHostname(context.Context, *SetHostnameRequest) (*SetHostnameResponse, error)
+	// Returns the current hostname and its version.
+	GetHostname(context.Context, *GetHostnameRequest) (*GetHostnameResponse, error)
+	// Lists the nameservers in /etc/resolv.conf and their version.
 	ListDNSServers(context.Context, *ListDNSServersRequest) (*ListDNSServersResponse, error)
+	// Appends a nameserver to /etc/resolv.conf. Fails with ALREADY_EXISTS if
+	// it is already present.
 	AddDNSServer(context.Context, *AddDNSServerRequest) (*AddDNSServerResponse, error)
+	// Removes a nameserver from /etc/resolv.conf. Fails with NOT_FOUND if it is
+	// not present.
 	RemoveDNSServer(context.Context, *RemoveDNSServerRequest) (*RemoveDNSServerResponse, error)
+	// Returns the content of /etc/hosts and its version.
+	GetHosts(context.Context, *GetHostsRequest) (*GetHostsResponse, error)
+	// Replaces the content of /etc/hosts.
+	SetHosts(context.Context, *SetHostsRequest) (*SetHostsResponse, error)
+	// Keeps a change made with confirm_within, which is otherwise rolled back.
+	ConfirmChange(context.Context, *ConfirmChangeRequest) (*ConfirmChangeResponse, error)
+	// Schedules a change to run once at run_time or repeatedly on cron.
+	ScheduleChange(context.Context, *ScheduleChangeRequest) (*ScheduledChange, error)
+	// Lists scheduled changes with their next and last runs.
+	ListScheduledChanges(context.Context, *ListScheduledChangesRequest) (*ListScheduledChangesResponse, error)
+	// Cancels a scheduled change.
+	CancelScheduledChange(context.Context, *CancelScheduledChangeRequest) (*CancelScheduledChangeResponse, error)
+	// Lists changes waiting for approval.
+	ListPendingChanges(context.Context, *ListPendingChangesRequest) (*ListPendingChangesResponse, error)
+	// Approves a pending change and applies it. The approver must be a
+	// different identity than the requester.
+	ApproveChange(context.Context, *ApproveChangeRequest) (*ApproveChangeResponse, error)
+	// Rejects a pending change, which is then dropped.
+	RejectChange(context.Context, *RejectChangeRequest) (*RejectChangeResponse, error)
+	// Streams changes of the hostname, resolv.conf and /etc/hosts, whether
+	// made through the API or externally. Over REST, GET /v1/events serves the
+	// stream as Server-Sent Events.
+	WatchChanges(*WatchChangesRequest, DNSHostnameService_WatchChangesServer) error
 	mustEmbedUnimplementedDNSHostnameServiceServer()
 }
 
@@ -101,6 +325,9 @@ type UnimplementedDNSHostnameServiceServer struct {
 func (UnimplementedDNSHostnameServiceServer) SetHostname(context.Context, *SetHostnameRequest) (*SetHostnameResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetHostname not implemented")
 }
+func (UnimplementedDNSHostnameServiceServer) GetHostname(context.Context, *GetHostnameRequest) (*GetHostnameResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHostname not implemented")
+}
 func (UnimplementedDNSHostnameServiceServer) ListDNSServers(context.Context, *ListDNSServersRequest) (*ListDNSServersResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListDNSServers not implemented")
 }
@@ -110,6 +337,36 @@ func (UnimplementedDNSHostnameServiceServer) AddDNSServer(context.Context, *AddD
 func (UnimplementedDNSHostnameServiceServer) RemoveDNSServer(context.Context, *RemoveDNSServerRequest) (*RemoveDNSServerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveDNSServer not implemented")
 }
+func (UnimplementedDNSHostnameServiceServer) GetHosts(context.Context, *GetHostsRequest) (*GetHostsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHosts not implemented")
+}
+func (UnimplementedDNSHostnameServiceServer) SetHosts(context.Context, *SetHostsRequest) (*SetHostsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetHosts not implemented")
+}
+func (UnimplementedDNSHostnameServiceServer) ConfirmChange(context.Context, *ConfirmChangeRequest) (*ConfirmChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmChange not implemented")
+}
+func (UnimplementedDNSHostnameServiceServer) ScheduleChange(context.Context, *ScheduleChangeRequest) (*ScheduledChange, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScheduleChange not implemented")
+}
+func (UnimplementedDNSHostnameServiceServer) ListScheduledChanges(context.Context, *ListScheduledChangesRequest) (*ListScheduledChangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListScheduledChanges not implemented")
+}
+func (UnimplementedDNSHostnameServiceServer) CancelScheduledChange(context.Context, *CancelScheduledChangeRequest) (*CancelScheduledChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelScheduledChange not implemented")
+}
+func (UnimplementedDNSHostnameServiceServer) ListPendingChanges(context.Context, *ListPendingChangesRequest) (*ListPendingChangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPendingChanges not implemented")
+}
+func (UnimplementedDNSHostnameServiceServer) ApproveChange(context.Context, *ApproveChangeRequest) (*ApproveChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveChange not implemented")
+}
+func (UnimplementedDNSHostnameServiceServer) RejectChange(context.Context, *RejectChangeRequest) (*RejectChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectChange not implemented")
+}
+func (UnimplementedDNSHostnameServiceServer) WatchChanges(*WatchChangesRequest, DNSHostnameService_WatchChangesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchChanges not implemented")
+}
 func (UnimplementedDNSHostnameServiceServer) mustEmbedUnimplementedDNSHostnameServiceServer() {}
 
 // UnsafeDNSHostnameServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -141,6 +398,24 @@ func _DNSHostnameService_SetHostname_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DNSHostnameService_GetHostname_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHostnameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DNSHostnameServiceServer).GetHostname(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DNSHostnameService_GetHostname_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DNSHostnameServiceServer).GetHostname(ctx, req.(*GetHostnameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DNSHostnameService_ListDNSServers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListDNSServersRequest)
 	if err := dec(in); err != nil {
@@ -195,6 +470,189 @@ func _DNSHostnameService_RemoveDNSServer_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DNSHostnameService_GetHosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DNSHostnameServiceServer).GetHosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DNSHostnameService_GetHosts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DNSHostnameServiceServer).GetHosts(ctx, req.(*GetHostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DNSHostnameService_SetHosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetHostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DNSHostnameServiceServer).SetHosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DNSHostnameService_SetHosts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DNSHostnameServiceServer).SetHosts(ctx, req.(*SetHostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DNSHostnameService_ConfirmChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DNSHostnameServiceServer).ConfirmChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DNSHostnameService_ConfirmChange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DNSHostnameServiceServer).ConfirmChange(ctx, req.(*ConfirmChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DNSHostnameService_ScheduleChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DNSHostnameServiceServer).ScheduleChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DNSHostnameService_ScheduleChange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DNSHostnameServiceServer).ScheduleChange(ctx, req.(*ScheduleChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DNSHostnameService_ListScheduledChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScheduledChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DNSHostnameServiceServer).ListScheduledChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DNSHostnameService_ListScheduledChanges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DNSHostnameServiceServer).ListScheduledChanges(ctx, req.(*ListScheduledChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DNSHostnameService_CancelScheduledChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelScheduledChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DNSHostnameServiceServer).CancelScheduledChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DNSHostnameService_CancelScheduledChange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DNSHostnameServiceServer).CancelScheduledChange(ctx, req.(*CancelScheduledChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DNSHostnameService_ListPendingChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPendingChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DNSHostnameServiceServer).ListPendingChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DNSHostnameService_ListPendingChanges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DNSHostnameServiceServer).ListPendingChanges(ctx, req.(*ListPendingChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DNSHostnameService_ApproveChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DNSHostnameServiceServer).ApproveChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DNSHostnameService_ApproveChange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DNSHostnameServiceServer).ApproveChange(ctx, req.(*ApproveChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DNSHostnameService_RejectChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DNSHostnameServiceServer).RejectChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DNSHostnameService_RejectChange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DNSHostnameServiceServer).RejectChange(ctx, req.(*RejectChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DNSHostnameService_WatchChanges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchChangesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DNSHostnameServiceServer).WatchChanges(m, &dNSHostnameServiceWatchChangesServer{ServerStream: stream})
+}
+
+type DNSHostnameService_WatchChangesServer interface {
+	Send(*ChangeEvent) error
+	grpc.ServerStream
+}
+
+type dNSHostnameServiceWatchChangesServer struct {
+	grpc.ServerStream
+}
+
+func (x *dNSHostnameServiceWatchChangesServer) Send(m *ChangeEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // DNSHostnameService_ServiceDesc is the grpc.ServiceDesc for DNSHostnameService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -206,6 +664,10 @@ var DNSHostnameService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SetHostname",
 			Handler:    _DNSHostnameService_SetHostname_Handler,
 		},
+		{
+			MethodName: "GetHostname",
+			Handler:    _DNSHostnameService_GetHostname_Handler,
+		},
 		{
 			MethodName: "ListDNSServers",
 			Handler:    _DNSHostnameService_ListDNSServers_Handler,
@@ -218,7 +680,49 @@ var DNSHostnameService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveDNSServer",
 			Handler:    _DNSHostnameService_RemoveDNSServer_Handler,
 		},
+		{
+			MethodName: "GetHosts",
+			Handler:    _DNSHostnameService_GetHosts_Handler,
+		},
+		{
+			MethodName: "SetHosts",
+			Handler:    _DNSHostnameService_SetHosts_Handler,
+		},
+		{
+			MethodName: "ConfirmChange",
+			Handler:    _DNSHostnameService_ConfirmChange_Handler,
+		},
+		{
+			MethodName: "ScheduleChange",
+			Handler:    _DNSHostnameService_ScheduleChange_Handler,
+		},
+		{
+			MethodName: "ListScheduledChanges",
+			Handler:    _DNSHostnameService_ListScheduledChanges_Handler,
+		},
+		{
+			MethodName: "CancelScheduledChange",
+			Handler:    _DNSHostnameService_CancelScheduledChange_Handler,
+		},
+		{
+			MethodName: "ListPendingChanges",
+			Handler:    _DNSHostnameService_ListPendingChanges_Handler,
+		},
+		{
+			MethodName: "ApproveChange",
+			Handler:    _DNSHostnameService_ApproveChange_Handler,
+		},
+		{
+			MethodName: "RejectChange",
+			Handler:    _DNSHostnameService_RejectChange_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchChanges",
+			Handler:       _DNSHostnameService_WatchChanges_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/dns.proto",
 }
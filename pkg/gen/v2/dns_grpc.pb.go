@@ -0,0 +1,260 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             v3.21.12
+// source: proto/v2/dns.proto
+
+package v2
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	HostConfigService_GetHostname_FullMethodName          = "/dns.v2.HostConfigService/GetHostname"
+	HostConfigService_UpdateHostname_FullMethodName       = "/dns.v2.HostConfigService/UpdateHostname"
+	HostConfigService_GetResolverConfig_FullMethodName    = "/dns.v2.HostConfigService/GetResolverConfig"
+	HostConfigService_UpdateResolverConfig_FullMethodName = "/dns.v2.HostConfigService/UpdateResolverConfig"
+)
+
+// HostConfigServiceClient is the client API for HostConfigService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// HostConfigService manages the host configuration as resources: the
+// hostname and the resolver configuration in /etc/resolv.conf.
+//
+// Resources carry an etag, the content hash of the file they are read from.
+// Passing it back on update makes the update fail with ABORTED (412
+// Precondition Failed over REST) if the file changed meanwhile. Updates fail
+// with FAILED_PRECONDITION while the server is frozen or when the admission
+// policy denies them. Updates the policy holds for approval, or requested with
+// require_approval, are queued and approved through the v1 API, and updates
+// requested with confirm_within are confirmed through it.
+type HostConfigServiceClient interface {
+	// Returns the hostname resource.
+	GetHostname(ctx context.Context, in *GetHostnameRequest, opts ...grpc.CallOption) (*Hostname, error)
+	// Updates the hostname. PUT replaces the resource; PATCH updates the
+	// fields present in the body.
+	UpdateHostname(ctx context.Context, in *UpdateHostnameRequest, opts ...grpc.CallOption) (*Hostname, error)
+	// Returns the resolver configuration.
+	GetResolverConfig(ctx context.Context, in *GetResolverConfigRequest, opts ...grpc.CallOption) (*ResolverConfig, error)
+	// Updates the resolver configuration in a single write. PUT replaces the
+	// resource; PATCH updates the fields present in the body. Nameservers are
+	// replaced as a whole list.
+	UpdateResolverConfig(ctx context.Context, in *UpdateResolverConfigRequest, opts ...grpc.CallOption) (*ResolverConfig, error)
+}
+
+type hostConfigServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHostConfigServiceClient(cc grpc.ClientConnInterface) HostConfigServiceClient {
+	return &hostConfigServiceClient{cc}
+}
+
+func (c *hostConfigServiceClient) GetHostname(ctx context.Context, in *GetHostnameRequest, opts ...grpc.CallOption) (*Hostname, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Hostname)
+	err := c.cc.Invoke(ctx, HostConfigService_GetHostname_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hostConfigServiceClient) UpdateHostname(ctx context.Context, in *UpdateHostnameRequest, opts ...grpc.CallOption) (*Hostname, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Hostname)
+	err := c.cc.Invoke(ctx, HostConfigService_UpdateHostname_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hostConfigServiceClient) GetResolverConfig(ctx context.Context, in *GetResolverConfigRequest, opts ...grpc.CallOption) (*ResolverConfig, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResolverConfig)
+	err := c.cc.Invoke(ctx, HostConfigService_GetResolverConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hostConfigServiceClient) UpdateResolverConfig(ctx context.Context, in *UpdateResolverConfigRequest, opts ...grpc.CallOption) (*ResolverConfig, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResolverConfig)
+	err := c.cc.Invoke(ctx, HostConfigService_UpdateResolverConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HostConfigServiceServer is the server API for HostConfigService service.
+// All implementations must embed UnimplementedHostConfigServiceServer
+// for forward compatibility
+//
+// HostConfigService manages the host configuration as resources: the
+// hostname and the resolver configuration in /etc/resolv.conf.
+//
+// Resources carry an etag, the content hash of the file they are read from.
+// Passing it back on update makes the update fail with ABORTED (412
+// Precondition Failed over REST) if the file changed meanwhile. Updates fail
+// with FAILED_PRECONDITION while the server is frozen or when the admission
+// policy denies them. Updates the policy holds for approval, or requested with
+// require_approval, are queued and approved through the v1 API, and updates
+// requested with confirm_within are confirmed through it.
+type HostConfigServiceServer interface {
+	// Returns the hostname resource.
+	GetHostname(context.Context, *GetHostnameRequest) (*Hostname, error)
+	// Updates the hostname. PUT replaces the resource; PATCH updates the
+	// fields present in the body.
+	UpdateHostname(context.Context, *UpdateHostnameRequest) (*Hostname, error)
+	// Returns the resolver configuration.
+	GetResolverConfig(context.Context, *GetResolverConfigRequest) (*ResolverConfig, error)
+	// Updates the resolver configuration in a single write. PUT replaces the
+	// resource; PATCH updates the fields present in the body. Nameservers are
+	// replaced as a whole list.
+	UpdateResolverConfig(context.Context, *UpdateResolverConfigRequest) (*ResolverConfig, error)
+	mustEmbedUnimplementedHostConfigServiceServer()
+}
+
+// UnimplementedHostConfigServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedHostConfigServiceServer struct {
+}
+
+func (UnimplementedHostConfigServiceServer) GetHostname(context.Context, *GetHostnameRequest) (*Hostname, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHostname not implemented")
+}
+func (UnimplementedHostConfigServiceServer) UpdateHostname(context.Context, *UpdateHostnameRequest) (*Hostname, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateHostname not implemented")
+}
+func (UnimplementedHostConfigServiceServer) GetResolverConfig(context.Context, *GetResolverConfigRequest) (*ResolverConfig, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResolverConfig not implemented")
+}
+func (UnimplementedHostConfigServiceServer) UpdateResolverConfig(context.Context, *UpdateResolverConfigRequest) (*ResolverConfig, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateResolverConfig not implemented")
+}
+func (UnimplementedHostConfigServiceServer) mustEmbedUnimplementedHostConfigServiceServer() {}
+
+// UnsafeHostConfigServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HostConfigServiceServer will
+// result in compilation errors.
+type UnsafeHostConfigServiceServer interface {
+	mustEmbedUnimplementedHostConfigServiceServer()
+}
+
+func RegisterHostConfigServiceServer(s grpc.ServiceRegistrar, srv HostConfigServiceServer) {
+	s.RegisterService(&HostConfigService_ServiceDesc, srv)
+}
+
+func _HostConfigService_GetHostname_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHostnameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostConfigServiceServer).GetHostname(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HostConfigService_GetHostname_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostConfigServiceServer).GetHostname(ctx, req.(*GetHostnameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HostConfigService_UpdateHostname_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateHostnameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostConfigServiceServer).UpdateHostname(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HostConfigService_UpdateHostname_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostConfigServiceServer).UpdateHostname(ctx, req.(*UpdateHostnameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HostConfigService_GetResolverConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetResolverConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostConfigServiceServer).GetResolverConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HostConfigService_GetResolverConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostConfigServiceServer).GetResolverConfig(ctx, req.(*GetResolverConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HostConfigService_UpdateResolverConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateResolverConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostConfigServiceServer).UpdateResolverConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HostConfigService_UpdateResolverConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostConfigServiceServer).UpdateResolverConfig(ctx, req.(*UpdateResolverConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// HostConfigService_ServiceDesc is the grpc.ServiceDesc for HostConfigService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var HostConfigService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "dns.v2.HostConfigService",
+	HandlerType: (*HostConfigServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetHostname",
+			Handler:    _HostConfigService_GetHostname_Handler,
+		},
+		{
+			MethodName: "UpdateHostname",
+			Handler:    _HostConfigService_UpdateHostname_Handler,
+		},
+		{
+			MethodName: "GetResolverConfig",
+			Handler:    _HostConfigService_GetResolverConfig_Handler,
+		},
+		{
+			MethodName: "UpdateResolverConfig",
+			Handler:    _HostConfigService_UpdateResolverConfig_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/v2/dns.proto",
+}